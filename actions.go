@@ -0,0 +1,122 @@
+package nebo
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// NewButton returns a button that sends callbackID back when pressed.
+func NewButton(label, callbackID string) MessageAction {
+	return MessageAction{Type: ActionButton, Label: label, CallbackID: callbackID}
+}
+
+// NewURLButton returns a button that opens url in the user's client.
+func NewURLButton(label, url string) MessageAction {
+	return MessageAction{Type: ActionURL, Label: label, URL: url}
+}
+
+// NewSelect returns a select menu. The chosen option's value arrives as the
+// callback payload.
+func NewSelect(label, callbackID string, options ...SelectOption) MessageAction {
+	return MessageAction{Type: ActionSelect, Label: label, CallbackID: callbackID, Options: options}
+}
+
+// Keyboard lays out rows of actions, setting each action's Row.
+//
+//	env.Actions = nebo.Keyboard(
+//		[]nebo.MessageAction{nebo.NewButton("Approve", "approve:42"), nebo.NewButton("Reject", "reject:42")},
+//		[]nebo.MessageAction{nebo.NewURLButton("Open", "https://example.com/42")},
+//	)
+func Keyboard(rows ...[]MessageAction) []MessageAction {
+	var out []MessageAction
+	for i, row := range rows {
+		for _, a := range row {
+			a.Row = i
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// CallbackFunc handles an EventCallback. arg is the part of the callback ID
+// after the matched prefix, or "" for an exact match.
+type CallbackFunc func(ctx context.Context, env ChannelEnvelope, arg string) error
+
+// CallbackRouter dispatches EventCallback envelopes by callback ID, so apps can
+// handle their own buttons instead of decoding Metadata or PlatformData.
+//
+//	router := nebo.NewCallbackRouter()
+//	router.HandlePrefix("approve:", func(ctx context.Context, env nebo.ChannelEnvelope, id string) error {
+//		return approve(ctx, id)
+//	})
+//
+//	if handled, err := router.Route(ctx, env); !handled {
+//		s.Deliver(env) // let Nebo see it
+//	}
+type CallbackRouter struct {
+	mu       sync.RWMutex
+	exact    map[string]CallbackFunc
+	prefixes []prefixRoute // longest first
+}
+
+type prefixRoute struct {
+	prefix string
+	fn     CallbackFunc
+}
+
+// NewCallbackRouter creates an empty CallbackRouter.
+func NewCallbackRouter() *CallbackRouter {
+	return &CallbackRouter{exact: make(map[string]CallbackFunc)}
+}
+
+// Handle routes callbacks whose ID is exactly callbackID to fn.
+func (r *CallbackRouter) Handle(callbackID string, fn CallbackFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exact[callbackID] = fn
+}
+
+// HandlePrefix routes callbacks whose ID starts with prefix to fn. When several
+// prefixes match, the longest wins; an exact match beats any prefix.
+func (r *CallbackRouter) HandlePrefix(prefix string, fn CallbackFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, p := range r.prefixes {
+		if p.prefix == prefix {
+			r.prefixes[i].fn = fn
+			return
+		}
+	}
+	r.prefixes = append(r.prefixes, prefixRoute{prefix, fn})
+	sort.SliceStable(r.prefixes, func(i, j int) bool {
+		return len(r.prefixes[i].prefix) > len(r.prefixes[j].prefix)
+	})
+}
+
+// Route calls the handler for env's callback. It reports whether one matched;
+// envelopes that are not callbacks are never handled.
+func (r *CallbackRouter) Route(ctx context.Context, env ChannelEnvelope) (bool, error) {
+	if env.Kind != EventCallback || env.Callback == nil {
+		return false, nil
+	}
+	id := env.Callback.CallbackID
+
+	r.mu.RLock()
+	fn, arg := r.exact[id], ""
+	if fn == nil {
+		for _, p := range r.prefixes {
+			if strings.HasPrefix(id, p.prefix) {
+				fn, arg = p.fn, strings.TrimPrefix(id, p.prefix)
+				break
+			}
+		}
+	}
+	r.mu.RUnlock()
+
+	if fn == nil {
+		return false, nil
+	}
+	return true, fn(ctx, env, arg)
+}
@@ -0,0 +1,71 @@
+package nebo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestKeyboardRows(t *testing.T) {
+	actions := Keyboard(
+		[]MessageAction{NewButton("Yes", "yes"), NewButton("No", "no")},
+		[]MessageAction{NewURLButton("Docs", "https://example.com")},
+	)
+	if len(actions) != 3 || actions[1].Row != 0 || actions[2].Row != 1 || actions[2].Type != ActionURL {
+		t.Errorf("Keyboard = %+v", actions)
+	}
+
+	pbActions := toProtoActions([]MessageAction{NewSelect("Size", "size", SelectOption{"Small", "s"}, SelectOption{"Large", "l"})})
+	back := fromProtoActions(pbActions)
+	if len(back) != 1 || back[0].Type != ActionSelect || len(back[0].Options) != 2 || back[0].Options[1].Value != "l" {
+		t.Errorf("select round trip = %+v", back)
+	}
+}
+
+func TestCallbackRouter(t *testing.T) {
+	r := NewCallbackRouter()
+	var got string
+	record := func(name string) CallbackFunc {
+		return func(_ context.Context, _ ChannelEnvelope, arg string) error {
+			got = name + "(" + arg + ")"
+			return nil
+		}
+	}
+	r.Handle("menu", record("menu"))
+	r.HandlePrefix("order:", record("order"))
+	r.HandlePrefix("order:cancel:", record("cancel"))
+
+	tests := []struct {
+		id      string
+		handled bool
+		want    string
+	}{
+		{"menu", true, "menu()"},
+		{"order:42", true, "order(42)"},
+		{"order:cancel:42", true, "cancel(42)"},
+		{"other", false, ""},
+	}
+	for _, tt := range tests {
+		got = ""
+		env := ChannelEnvelope{Kind: EventCallback, Callback: &ActionCallback{CallbackID: tt.id}}
+		handled, err := r.Route(context.Background(), env)
+		if err != nil || handled != tt.handled || got != tt.want {
+			t.Errorf("Route(%q) = %v, %v, called %q; want %v, %q", tt.id, handled, err, got, tt.handled, tt.want)
+		}
+	}
+
+	if handled, _ := r.Route(context.Background(), ChannelEnvelope{Text: "menu"}); handled {
+		t.Error("plain message was routed")
+	}
+}
+
+func TestToInboundMessageCallback(t *testing.T) {
+	msg := toInboundMessage(ChannelEnvelope{
+		Kind:      EventCallback,
+		MessageID: "m1",
+		UserID:    "u1",
+		Callback:  &ActionCallback{CallbackID: "size", Payload: "l"},
+	})
+	if msg.Kind != "callback" || msg.Callback.GetCallbackId() != "size" || msg.Callback.GetPayload() != "l" {
+		t.Errorf("inbound = %+v", msg)
+	}
+}
@@ -0,0 +1,258 @@
+package nebo
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+)
+
+// DefaultAttachmentLimit is the per-attachment size limit used when
+// NewAttachmentStore is given a limit of 0.
+const DefaultAttachmentLimit = 50 << 20
+
+// attachmentChunkSize is the payload size of each AttachmentChunk sent to Nebo.
+const attachmentChunkSize = 64 << 10
+
+var attachmentIDPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// AttachmentStore keeps attachment bytes on disk, typically under the app's
+// DataDir, so files can be moved between the platform and Nebo by ID instead
+// of by URL. Register it with WithAttachmentStore to serve Nebo's
+// UploadAttachment and DownloadAttachment calls.
+//
+//	store, err := nebo.NewAttachmentStore(filepath.Join(app.Env().DataDir, "attachments"), 0)
+//	app.RegisterChannel(t, nebo.WithAttachmentStore(store))
+type AttachmentStore struct {
+	dir   string
+	limit int64
+}
+
+// NewAttachmentStore creates a store in dir that rejects attachments larger
+// than limit bytes (DefaultAttachmentLimit if 0).
+func NewAttachmentStore(dir string, limit int64) (*AttachmentStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = DefaultAttachmentLimit
+	}
+	return &AttachmentStore{dir: dir, limit: limit}, nil
+}
+
+// WithAttachmentStore serves Nebo's attachment upload and download calls from
+// store. Without it they return CodeUnimplemented.
+func WithAttachmentStore(store *AttachmentStore) ChannelRegisterOption {
+	return func(b *channelBridge) { b.attachments = store }
+}
+
+// Put reads r into the store and returns info with ID, Size and SHA256 set.
+// MIMEType is detected from the content if empty. If info.SHA256 is set, the
+// content must match it. Content over the size limit is rejected with
+// CodeInvalidArgument.
+func (s *AttachmentStore) Put(info Attachment, r io.Reader) (Attachment, error) {
+	if info.Size > s.limit {
+		return Attachment{}, s.tooLarge()
+	}
+	tmp, err := os.CreateTemp(s.dir, "upload-*")
+	if err != nil {
+		return Attachment{}, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	br := bufio.NewReader(r)
+	if info.MIMEType == "" {
+		head, _ := br.Peek(512)
+		info.MIMEType = http.DetectContentType(head)
+	}
+	hash := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmp, hash), io.LimitReader(br, s.limit+1))
+	if err != nil {
+		return Attachment{}, err
+	}
+	if n > s.limit {
+		return Attachment{}, s.tooLarge()
+	}
+	sum := hex.EncodeToString(hash.Sum(nil))
+	if info.SHA256 != "" && info.SHA256 != sum {
+		return Attachment{}, Errorf(CodeInvalidArgument, "attachment checksum mismatch: got %s, want %s", sum, info.SHA256)
+	}
+	if err := tmp.Sync(); err != nil {
+		return Attachment{}, err
+	}
+
+	info.ID = newAttachmentID()
+	info.Size = n
+	info.SHA256 = sum
+	meta, err := json.Marshal(info)
+	if err != nil {
+		return Attachment{}, err
+	}
+	if err := os.WriteFile(s.path(info.ID)+".json", meta, 0o600); err != nil {
+		return Attachment{}, err
+	}
+	if err := os.Rename(tmp.Name(), s.path(info.ID)); err != nil {
+		os.Remove(s.path(info.ID) + ".json")
+		return Attachment{}, err
+	}
+	return info, nil
+}
+
+// Open returns a stored attachment's metadata and content. The caller closes
+// the reader. Unknown IDs return CodeNotFound.
+func (s *AttachmentStore) Open(id string) (Attachment, io.ReadCloser, error) {
+	info, err := s.Stat(id)
+	if err != nil {
+		return Attachment{}, nil, err
+	}
+	f, err := os.Open(s.path(id))
+	if err != nil {
+		return Attachment{}, nil, err
+	}
+	return info, f, nil
+}
+
+// Stat returns a stored attachment's metadata.
+func (s *AttachmentStore) Stat(id string) (Attachment, error) {
+	if !attachmentIDPattern.MatchString(id) {
+		return Attachment{}, Errorf(CodeNotFound, "attachment %q not found", id)
+	}
+	data, err := os.ReadFile(s.path(id) + ".json")
+	if errors.Is(err, os.ErrNotExist) {
+		return Attachment{}, Errorf(CodeNotFound, "attachment %q not found", id)
+	}
+	if err != nil {
+		return Attachment{}, err
+	}
+	var info Attachment
+	if err := json.Unmarshal(data, &info); err != nil {
+		return Attachment{}, fmt.Errorf("attachment %s metadata: %w", id, err)
+	}
+	return info, nil
+}
+
+// Delete removes a stored attachment. Deleting an unknown ID is not an error.
+func (s *AttachmentStore) Delete(id string) error {
+	if !attachmentIDPattern.MatchString(id) {
+		return nil
+	}
+	err := os.Remove(s.path(id))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	err = os.Remove(s.path(id) + ".json")
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *AttachmentStore) path(id string) string {
+	return filepath.Join(s.dir, id)
+}
+
+func (s *AttachmentStore) tooLarge() error {
+	return Errorf(CodeInvalidArgument, "attachment exceeds the %d byte limit", s.limit)
+}
+
+func newAttachmentID() string { return newID() }
+
+func (b *channelBridge) UploadAttachment(stream pb.ChannelService_UploadAttachmentServer) error {
+	if b.attachments == nil {
+		err := unsupported("attachment upload")
+		return stream.SendAndClose(&pb.AttachmentUploadResponse{Error: err.Error(), ErrorDetail: toProtoError(err)})
+	}
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	r := &chunkReader{stream: stream, buf: first.Data}
+	info, err := b.attachments.Put(fromProtoAttachment(first.GetInfo()), r)
+	if err != nil {
+		return stream.SendAndClose(&pb.AttachmentUploadResponse{Error: err.Error(), ErrorDetail: toProtoError(err)})
+	}
+	return stream.SendAndClose(&pb.AttachmentUploadResponse{Attachment: toProtoAttachment(info)})
+}
+
+func (b *channelBridge) DownloadAttachment(req *pb.AttachmentDownloadRequest, stream pb.ChannelService_DownloadAttachmentServer) error {
+	if b.attachments == nil {
+		return unsupported("attachment download")
+	}
+	info, f, err := b.attachments.Open(req.Id)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	chunk := &pb.AttachmentChunk{Info: toProtoAttachment(info)}
+	buf := make([]byte, attachmentChunkSize)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 || chunk.Info != nil {
+			chunk.Data = buf[:n]
+			if err := stream.Send(chunk); err != nil {
+				return err
+			}
+			chunk = &pb.AttachmentChunk{}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// chunkReader reads the data of an AttachmentChunk stream.
+type chunkReader struct {
+	stream pb.ChannelService_UploadAttachmentServer
+	buf    []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		chunk, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = chunk.Data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func fromProtoAttachment(a *pb.Attachment) Attachment {
+	return Attachment{
+		Type:     a.GetType(),
+		URL:      a.GetUrl(),
+		Filename: a.GetFilename(),
+		Size:     a.GetSize(),
+		MIMEType: a.GetMimeType(),
+		SHA256:   a.GetSha256(),
+		ID:       a.GetId(),
+	}
+}
+
+func toProtoAttachment(a Attachment) *pb.Attachment {
+	return &pb.Attachment{
+		Type:     a.Type,
+		Url:      a.URL,
+		Filename: a.Filename,
+		Size:     a.Size,
+		MimeType: a.MIMEType,
+		Sha256:   a.SHA256,
+		Id:       a.ID,
+	}
+}
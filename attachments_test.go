@@ -0,0 +1,133 @@
+package nebo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+	"google.golang.org/grpc"
+)
+
+func TestAttachmentStorePutOpen(t *testing.T) {
+	store, err := NewAttachmentStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := store.Put(Attachment{Type: "file", Filename: "a.txt"}, strings.NewReader("hello"))
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if info.Size != 5 || info.SHA256 != "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824" || !strings.HasPrefix(info.MIMEType, "text/plain") {
+		t.Errorf("stored = %+v", info)
+	}
+
+	got, r, err := store.Open(info.ID)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer r.Close()
+	data, _ := io.ReadAll(r)
+	if string(data) != "hello" || got.Filename != "a.txt" {
+		t.Errorf("Open = %+v, %q", got, data)
+	}
+
+	store.Delete(info.ID)
+	if _, err := store.Stat(info.ID); !errors.Is(err, &Error{Code: CodeNotFound}) {
+		t.Errorf("Stat after Delete err = %v, want not_found", err)
+	}
+	if _, err := store.Stat("../../etc/passwd"); !errors.Is(err, &Error{Code: CodeNotFound}) {
+		t.Errorf("Stat(path) err = %v, want not_found", err)
+	}
+}
+
+func TestAttachmentStoreLimits(t *testing.T) {
+	store, _ := NewAttachmentStore(t.TempDir(), 4)
+
+	if _, err := store.Put(Attachment{}, strings.NewReader("12345")); !errors.Is(err, &Error{Code: CodeInvalidArgument}) {
+		t.Errorf("oversized Put err = %v, want invalid_argument", err)
+	}
+	if _, err := store.Put(Attachment{Size: 10}, strings.NewReader("")); !errors.Is(err, &Error{Code: CodeInvalidArgument}) {
+		t.Errorf("declared oversized Put err = %v, want invalid_argument", err)
+	}
+	if _, err := store.Put(Attachment{SHA256: "00"}, strings.NewReader("1234")); !errors.Is(err, &Error{Code: CodeInvalidArgument}) {
+		t.Errorf("checksum mismatch err = %v, want invalid_argument", err)
+	}
+}
+
+// fakeUploadStream feeds chunks to UploadAttachment.
+type fakeUploadStream struct {
+	grpc.ServerStream
+	chunks []*pb.AttachmentChunk
+	resp   *pb.AttachmentUploadResponse
+}
+
+func (s *fakeUploadStream) Recv() (*pb.AttachmentChunk, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+	c := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return c, nil
+}
+func (s *fakeUploadStream) SendAndClose(resp *pb.AttachmentUploadResponse) error {
+	s.resp = resp
+	return nil
+}
+
+// fakeDownloadStream collects chunks from DownloadAttachment.
+type fakeDownloadStream struct {
+	grpc.ServerStream
+	chunks []*pb.AttachmentChunk
+}
+
+func (s *fakeDownloadStream) Context() context.Context { return context.Background() }
+func (s *fakeDownloadStream) Send(c *pb.AttachmentChunk) error {
+	s.chunks = append(s.chunks, c)
+	return nil
+}
+
+func TestChannelBridgeAttachmentRoundTrip(t *testing.T) {
+	store, _ := NewAttachmentStore(t.TempDir(), 0)
+	b := &channelBridge{handler: &chanChannel{}, env: &AppEnv{}, attachments: store}
+
+	content := bytes.Repeat([]byte("x"), attachmentChunkSize+10)
+	up := &fakeUploadStream{chunks: []*pb.AttachmentChunk{
+		{Info: &pb.Attachment{Type: "image", Filename: "pic.png", MimeType: "image/png"}, Data: content[:100]},
+		{Data: content[100:]},
+	}}
+	if err := b.UploadAttachment(up); err != nil || up.resp.Error != "" {
+		t.Fatalf("UploadAttachment = %v, %+v", err, up.resp)
+	}
+	id := up.resp.Attachment.Id
+	if up.resp.Attachment.Size != int64(len(content)) || up.resp.Attachment.MimeType != "image/png" {
+		t.Errorf("uploaded = %+v", up.resp.Attachment)
+	}
+
+	down := &fakeDownloadStream{}
+	if err := b.DownloadAttachment(&pb.AttachmentDownloadRequest{Id: id}, down); err != nil {
+		t.Fatalf("DownloadAttachment: %v", err)
+	}
+	if len(down.chunks) != 2 || down.chunks[0].Info.GetFilename() != "pic.png" || down.chunks[1].Info != nil {
+		t.Fatalf("chunks = %d, first info = %+v", len(down.chunks), down.chunks[0].Info)
+	}
+	var got []byte
+	for _, c := range down.chunks {
+		got = append(got, c.Data...)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("downloaded %d bytes, want %d", len(got), len(content))
+	}
+}
+
+func TestChannelBridgeAttachmentsWithoutStore(t *testing.T) {
+	b := &channelBridge{handler: &chanChannel{}, env: &AppEnv{}}
+	up := &fakeUploadStream{}
+	b.UploadAttachment(up)
+	if up.resp.ErrorDetail.GetCode() != "unimplemented" {
+		t.Errorf("upload without store = %+v", up.resp)
+	}
+}
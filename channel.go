@@ -2,7 +2,11 @@ package nebo
 
 import (
 	"context"
+	"strconv"
+	"sync"
+	"time"
 
+	"github.com/neboloop/nebo-sdk-go/markdown"
 	pb "github.com/neboloop/nebo-sdk-go/pb"
 )
 
@@ -19,16 +23,84 @@ type Attachment struct {
 	URL      string
 	Filename string
 	Size     int64 // bytes
+	MIMEType string
+	SHA256   string // hex-encoded checksum of the content
+	ID       string // ID in the app's AttachmentStore, if stored there
 }
 
+// ActionType is the kind of interactive element a MessageAction is.
+type ActionType string
+
+const (
+	ActionButton ActionType = "button" // Sends an EventCallback when pressed; the default
+	ActionURL    ActionType = "url"    // Opens URL in the client; no callback
+	ActionSelect ActionType = "select" // Menu of Options; the chosen value is the callback payload
+)
+
 // MessageAction represents an interactive element (button, keyboard row).
 type MessageAction struct {
 	Label      string
 	CallbackID string
+	Type       ActionType // empty means ActionButton
+	URL        string     // ActionURL only
+	Options    []SelectOption
+	Row        int // keyboard row; actions sharing a row are laid out together
+}
+
+// SelectOption is one choice in an ActionSelect menu.
+type SelectOption struct {
+	Label string
+	Value string
+}
+
+// ActionCallback reports that a user pressed a button or chose from a select menu.
+type ActionCallback struct {
+	CallbackID string
+	Payload    string // chosen option value for select menus, else platform-specific
+}
+
+// EventKind says what an inbound envelope reports.
+type EventKind string
+
+const (
+	EventMessage  EventKind = "message"  // A new message; the default when Kind is empty
+	EventEdit     EventKind = "edit"     // MessageID was edited; Text is the new content
+	EventDelete   EventKind = "delete"   // MessageID was deleted
+	EventReaction EventKind = "reaction" // Reaction was added to (or removed from) MessageID
+	EventReceipt  EventKind = "receipt"  // Messages up to MessageID were delivered or read; see Receipt
+	EventPresence EventKind = "presence" // The sender's availability changed; see Presence
+	EventCallback EventKind = "callback" // An action on MessageID was used; see Callback
+)
+
+// Receipt statuses.
+const (
+	ReceiptDelivered = "delivered"
+	ReceiptRead      = "read"
+)
+
+// Receipt reports that the user received or read the bot's messages.
+type Receipt struct {
+	Status string // ReceiptDelivered or ReceiptRead
+	At     string // RFC3339
+}
+
+// Presence statuses.
+const (
+	PresenceOnline  = "online"
+	PresenceAway    = "away"
+	PresenceOffline = "offline"
+	PresenceTyping  = "typing"
+)
+
+// Presence reports a user's availability.
+type Presence struct {
+	Status   string // PresenceOnline, PresenceAway, PresenceOffline or PresenceTyping
+	LastSeen string // RFC3339, if the platform reports it
 }
 
 // ChannelEnvelope is the v1 message envelope used for both inbound and outbound messages.
 type ChannelEnvelope struct {
+	Kind         EventKind // inbound only; empty means EventMessage
 	MessageID    string
 	ChannelID    string
 	Sender       MessageSender
@@ -39,6 +111,21 @@ type ChannelEnvelope struct {
 	PlatformData []byte
 	Timestamp    string // RFC3339
 
+	// Set for EventReaction.
+	Reaction        string
+	ReactionRemoved bool
+
+	Receipt  *Receipt        // Set for EventReceipt
+	Presence *Presence       // Set for EventPresence
+	Callback *ActionCallback // Set for EventCallback
+
+	// Conversation context. ThreadID and Mentions also apply to outbound
+	// messages; the rest is inbound only.
+	ThreadID         string // thread or topic within ChannelID
+	ConversationType ConversationType
+	Participants     []Participant
+	Mentions         []Mention
+
 	// Legacy fields (inbound only)
 	UserID   string
 	Metadata string // JSON-encoded
@@ -54,20 +141,81 @@ type ChannelHandler interface {
 	Receive(ctx context.Context) (<-chan ChannelEnvelope, error)
 }
 
+// ChannelEditor is an optional extension for channels whose platform lets the
+// bot change or retract messages it sent. Without it, Edit and Delete return
+// CodeUnimplemented.
+type ChannelEditor interface {
+	ChannelHandler
+	// Edit replaces the message identified by env.ChannelID and env.MessageID
+	// with env's text, actions and platform data.
+	Edit(ctx context.Context, env ChannelEnvelope) error
+	Delete(ctx context.Context, channelID, messageID string) error
+}
+
+// ChannelReactor is an optional extension for channels that support emoji
+// reactions. Without it, React returns CodeUnimplemented.
+type ChannelReactor interface {
+	ChannelHandler
+	React(ctx context.Context, channelID, messageID, emoji string, remove bool) error
+}
+
+// ChannelIndicator is an optional extension for channels that can show the bot
+// typing and mark messages as read. A platform that supports only one of them
+// returns CodeUnimplemented from the other. Without it, SetTyping and MarkRead
+// return CodeUnimplemented.
+type ChannelIndicator interface {
+	ChannelHandler
+	SetTyping(ctx context.Context, channelID string, typing bool) error
+	MarkRead(ctx context.Context, channelID, messageID string) error
+}
+
+// ChannelFormatter is an optional extension for channels whose platform has
+// its own rich text syntax. The bridge converts the Markdown in outbound Text
+// to the TextFormat dialect before Send and Edit. Streamed replies reach
+// SendStream as written; set EditStreamer.Format to convert them.
+type ChannelFormatter interface {
+	ChannelHandler
+	TextFormat() markdown.Dialect
+}
+
+// formatText converts env's Markdown for handlers that implement ChannelFormatter.
+func formatText(h ChannelHandler, env ChannelEnvelope) ChannelEnvelope {
+	if f, ok := h.(ChannelFormatter); ok {
+		env.Text = markdown.Convert(env.Text, f.TextFormat())
+	}
+	return env
+}
+
 // channelBridge adapts a ChannelHandler to the pb.ChannelServiceServer gRPC interface.
 type channelBridge struct {
 	pb.UnimplementedChannelServiceServer
 	handler     ChannelHandler
 	onConfigure func(map[string]string)
 	env         *AppEnv
+
+	bufferSize  int
+	persist     bool
+	attachments *AttachmentStore
+	sendQueue   *SendQueue
+
+	reassembleWindow  time.Duration
+	reassembleMinPart int
+
+	idempotencyTTL     time.Duration
+	persistIdempotency bool
+
+	connMu sync.Mutex
+	conns  map[string]*channelConn
 }
 
 func (b *channelBridge) HealthCheck(_ context.Context, _ *pb.HealthCheckRequest) (*pb.HealthCheckResponse, error) {
-	return &pb.HealthCheckResponse{
+	resp := &pb.HealthCheckResponse{
 		Healthy: true,
 		Name:    b.env.Name,
 		Version: b.env.Version,
-	}, nil
+	}
+	b.health(resp)
+	return resp, nil
 }
 
 func (b *channelBridge) ID(_ context.Context, _ *pb.Empty) (*pb.IDResponse, error) {
@@ -75,20 +223,42 @@ func (b *channelBridge) ID(_ context.Context, _ *pb.Empty) (*pb.IDResponse, erro
 }
 
 func (b *channelBridge) Connect(ctx context.Context, req *pb.ChannelConnectRequest) (*pb.ChannelConnectResponse, error) {
-	if err := b.handler.Connect(ctx, req.Config); err != nil {
-		return &pb.ChannelConnectResponse{Error: err.Error()}, nil
+	c, err := b.conn(req.ConnectionId, true)
+	if err == nil {
+		err = c.handler.Connect(ctx, req.Config)
+	}
+	if err != nil {
+		return &pb.ChannelConnectResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
 	}
 	return &pb.ChannelConnectResponse{}, nil
 }
 
-func (b *channelBridge) Disconnect(ctx context.Context, _ *pb.Empty) (*pb.ChannelDisconnectResponse, error) {
-	if err := b.handler.Disconnect(ctx); err != nil {
-		return &pb.ChannelDisconnectResponse{Error: err.Error()}, nil
+func (b *channelBridge) Disconnect(ctx context.Context, req *pb.ChannelDisconnectRequest) (*pb.ChannelDisconnectResponse, error) {
+	c, err := b.conn(req.ConnectionId, false)
+	if err == nil {
+		err = c.handler.Disconnect(ctx)
+		c.stop()
+		b.drop(c)
+	}
+	if err != nil {
+		return &pb.ChannelDisconnectResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
 	}
 	return &pb.ChannelDisconnectResponse{}, nil
 }
 
 func (b *channelBridge) Send(ctx context.Context, req *pb.ChannelSendRequest) (*pb.ChannelSendResponse, error) {
+	c, err := b.conn(req.ConnectionId, false)
+	var ids []string
+	if err == nil {
+		env := fromSendRequest(req)
+		ids, err = c.idem.do(ctx, sendKey(env), func() ([]string, error) {
+			return b.send(ctx, c, env)
+		})
+	}
+	return sendResponse(ids, err), nil
+}
+
+func fromSendRequest(req *pb.ChannelSendRequest) ChannelEnvelope {
 	env := ChannelEnvelope{
 		ChannelID:    req.ChannelId,
 		Text:         req.Text,
@@ -104,78 +274,231 @@ func (b *channelBridge) Send(ctx context.Context, req *pb.ChannelSendRequest) (*
 		}
 	}
 	for _, a := range req.Attachments {
-		env.Attachments = append(env.Attachments, Attachment{
-			Type:     a.Type,
-			URL:      a.Url,
-			Filename: a.Filename,
-			Size:     a.Size,
-		})
+		env.Attachments = append(env.Attachments, fromProtoAttachment(a))
 	}
-	for _, a := range req.Actions {
-		env.Actions = append(env.Actions, MessageAction{
+	env.Actions = fromProtoActions(req.Actions)
+	env.ThreadID = req.ThreadId
+	env.Mentions = fromProtoMentions(req.Mentions)
+	return env
+}
+
+func fromProtoActions(actions []*pb.MessageAction) []MessageAction {
+	var out []MessageAction
+	for _, a := range actions {
+		action := MessageAction{
 			Label:      a.Label,
 			CallbackID: a.CallbackId,
-		})
+			Type:       ActionType(a.Type),
+			URL:        a.Url,
+			Row:        int(a.Row),
+		}
+		for _, o := range a.Options {
+			action.Options = append(action.Options, SelectOption{Label: o.Label, Value: o.Value})
+		}
+		out = append(out, action)
+	}
+	return out
+}
+
+func toProtoActions(actions []MessageAction) []*pb.MessageAction {
+	var out []*pb.MessageAction
+	for _, a := range actions {
+		action := &pb.MessageAction{
+			Label:      a.Label,
+			CallbackId: a.CallbackID,
+			Type:       string(a.Type),
+			Url:        a.URL,
+			Row:        int32(a.Row),
+		}
+		for _, o := range a.Options {
+			action.Options = append(action.Options, &pb.SelectOption{Label: o.Label, Value: o.Value})
+		}
+		out = append(out, action)
+	}
+	return out
+}
+
+func (b *channelBridge) Edit(ctx context.Context, req *pb.ChannelEditRequest) (*pb.ChannelEditResponse, error) {
+	c, err := b.conn(req.ConnectionId, false)
+	if err != nil {
+		return &pb.ChannelEditResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
+	}
+	editor, ok := c.handler.(ChannelEditor)
+	if !ok {
+		err := unsupported("edit")
+		return &pb.ChannelEditResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
+	}
+	err = editor.Edit(ctx, formatText(c.handler, ChannelEnvelope{
+		ChannelID:    req.ChannelId,
+		MessageID:    req.MessageId,
+		Text:         req.Text,
+		Actions:      fromProtoActions(req.Actions),
+		PlatformData: req.PlatformData,
+	}))
+	if err != nil {
+		return &pb.ChannelEditResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
+	}
+	return &pb.ChannelEditResponse{}, nil
+}
+
+func (b *channelBridge) Delete(ctx context.Context, req *pb.ChannelDeleteRequest) (*pb.ChannelDeleteResponse, error) {
+	c, err := b.conn(req.ConnectionId, false)
+	if err != nil {
+		return &pb.ChannelDeleteResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
+	}
+	editor, ok := c.handler.(ChannelEditor)
+	if !ok {
+		err := unsupported("delete")
+		return &pb.ChannelDeleteResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
+	}
+	if err := editor.Delete(ctx, req.ChannelId, req.MessageId); err != nil {
+		return &pb.ChannelDeleteResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
 	}
+	return &pb.ChannelDeleteResponse{}, nil
+}
+
+func (b *channelBridge) React(ctx context.Context, req *pb.ChannelReactRequest) (*pb.ChannelReactResponse, error) {
+	c, err := b.conn(req.ConnectionId, false)
+	if err != nil {
+		return &pb.ChannelReactResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
+	}
+	reactor, ok := c.handler.(ChannelReactor)
+	if !ok {
+		err := unsupported("react")
+		return &pb.ChannelReactResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
+	}
+	if err := reactor.React(ctx, req.ChannelId, req.MessageId, req.Emoji, req.Remove); err != nil {
+		return &pb.ChannelReactResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
+	}
+	return &pb.ChannelReactResponse{}, nil
+}
 
-	messageID, err := b.handler.Send(ctx, env)
+func (b *channelBridge) SetTyping(ctx context.Context, req *pb.ChannelTypingRequest) (*pb.ChannelTypingResponse, error) {
+	c, err := b.conn(req.ConnectionId, false)
 	if err != nil {
-		return &pb.ChannelSendResponse{Error: err.Error()}, nil
+		return &pb.ChannelTypingResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
+	}
+	indicator, ok := c.handler.(ChannelIndicator)
+	if !ok {
+		err := unsupported("typing indicators")
+		return &pb.ChannelTypingResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
+	}
+	if err := indicator.SetTyping(ctx, req.ChannelId, req.Typing); err != nil {
+		return &pb.ChannelTypingResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
+	}
+	return &pb.ChannelTypingResponse{}, nil
+}
+
+func (b *channelBridge) MarkRead(ctx context.Context, req *pb.ChannelMarkReadRequest) (*pb.ChannelMarkReadResponse, error) {
+	c, err := b.conn(req.ConnectionId, false)
+	if err != nil {
+		return &pb.ChannelMarkReadResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
+	}
+	indicator, ok := c.handler.(ChannelIndicator)
+	if !ok {
+		err := unsupported("read receipts")
+		return &pb.ChannelMarkReadResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
+	}
+	if err := indicator.MarkRead(ctx, req.ChannelId, req.MessageId); err != nil {
+		return &pb.ChannelMarkReadResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
 	}
-	return &pb.ChannelSendResponse{MessageId: messageID}, nil
+	return &pb.ChannelMarkReadResponse{}, nil
 }
 
-func (b *channelBridge) Receive(_ *pb.Empty, stream pb.ChannelService_ReceiveServer) error {
-	ch, err := b.handler.Receive(stream.Context())
+// unsupported reports an optional channel operation the handler does not implement.
+func unsupported(op string) *Error {
+	return Errorf(CodeUnimplemented, "channel does not support %s", op)
+}
+
+// Receive streams buffered inbound messages to Nebo. The handler's Receive is
+// started once and drained into the buffer independently of Nebo's stream, so
+// messages arriving between streams are kept. Each stream starts from the
+// oldest unacknowledged message. Each connection has its own stream and buffer.
+func (b *channelBridge) Receive(req *pb.ChannelReceiveRequest, stream pb.ChannelService_ReceiveServer) error {
+	c, err := b.conn(req.ConnectionId, true)
 	if err != nil {
 		return err
 	}
+	if err := c.startPump(); err != nil {
+		return err
+	}
+	buf := c.inbound
+	var after uint64
 	for {
-		select {
-		case msg, ok := <-ch:
-			if !ok {
-				return nil
-			}
-			pbMsg := &pb.InboundMessage{
-				ChannelId:    msg.ChannelID,
-				UserId:       msg.UserID,
-				Text:         msg.Text,
-				Metadata:     msg.Metadata,
-				MessageId:    msg.MessageID,
-				ReplyTo:      msg.ReplyTo,
-				PlatformData: msg.PlatformData,
-				Timestamp:    msg.Timestamp,
-			}
-			if msg.Sender != (MessageSender{}) {
-				pbMsg.Sender = &pb.MessageSender{
-					Name:  msg.Sender.Name,
-					Role:  msg.Sender.Role,
-					BotId: msg.Sender.BotID,
-				}
-			}
-			for _, a := range msg.Attachments {
-				pbMsg.Attachments = append(pbMsg.Attachments, &pb.Attachment{
-					Type:     a.Type,
-					Url:      a.URL,
-					Filename: a.Filename,
-					Size:     a.Size,
-				})
-			}
-			for _, a := range msg.Actions {
-				pbMsg.Actions = append(pbMsg.Actions, &pb.MessageAction{
-					Label:      a.Label,
-					CallbackId: a.CallbackID,
-				})
-			}
-			if err := stream.Send(pbMsg); err != nil {
+		msg := buf.next(stream.Context(), after)
+		if msg == nil {
+			return nil
+		}
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+		after, _ = strconv.ParseUint(msg.DeliveryId, 10, 64)
+		if !req.Ack {
+			if err := buf.ack(msg.DeliveryId); err != nil {
 				return err
 			}
-		case <-stream.Context().Done():
-			return nil
 		}
 	}
 }
 
+func (b *channelBridge) Ack(_ context.Context, req *pb.ChannelAckRequest) (*pb.ChannelAckResponse, error) {
+	c, err := b.conn(req.ConnectionId, true)
+	if err == nil {
+		err = c.startPump()
+	}
+	if err != nil {
+		return &pb.ChannelAckResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
+	}
+	if err := c.inbound.ack(req.DeliveryIds...); err != nil {
+		return &pb.ChannelAckResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
+	}
+	return &pb.ChannelAckResponse{}, nil
+}
+
+func toInboundMessage(msg ChannelEnvelope) *pb.InboundMessage {
+	pbMsg := &pb.InboundMessage{
+		ChannelId:    msg.ChannelID,
+		UserId:       msg.UserID,
+		Text:         msg.Text,
+		Metadata:     msg.Metadata,
+		MessageId:    msg.MessageID,
+		ReplyTo:      msg.ReplyTo,
+		PlatformData: msg.PlatformData,
+		Timestamp:    msg.Timestamp,
+
+		Kind:            string(msg.Kind),
+		Reaction:        msg.Reaction,
+		ReactionRemoved: msg.ReactionRemoved,
+	}
+	if msg.Sender != (MessageSender{}) {
+		pbMsg.Sender = &pb.MessageSender{
+			Name:  msg.Sender.Name,
+			Role:  msg.Sender.Role,
+			BotId: msg.Sender.BotID,
+		}
+	}
+	for _, a := range msg.Attachments {
+		pbMsg.Attachments = append(pbMsg.Attachments, toProtoAttachment(a))
+	}
+	pbMsg.Actions = toProtoActions(msg.Actions)
+	if msg.Receipt != nil {
+		pbMsg.Receipt = &pb.Receipt{Status: msg.Receipt.Status, At: msg.Receipt.At}
+	}
+	if msg.Presence != nil {
+		pbMsg.Presence = &pb.Presence{Status: msg.Presence.Status, LastSeen: msg.Presence.LastSeen}
+	}
+	if msg.Callback != nil {
+		pbMsg.Callback = &pb.ActionCallback{CallbackId: msg.Callback.CallbackID, Payload: msg.Callback.Payload}
+	}
+	pbMsg.ThreadId = msg.ThreadID
+	pbMsg.ConversationType = string(msg.ConversationType)
+	for _, p := range msg.Participants {
+		pbMsg.Participants = append(pbMsg.Participants, &pb.Participant{UserId: p.UserID, Name: p.Name, IsBot: p.IsBot})
+	}
+	pbMsg.Mentions = toProtoMentions(msg.Mentions)
+	return pbMsg
+}
+
 func (b *channelBridge) Configure(_ context.Context, req *pb.SettingsMap) (*pb.Empty, error) {
 	if b.onConfigure != nil {
 		b.onConfigure(req.Values)
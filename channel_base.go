@@ -0,0 +1,320 @@
+package nebo
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// ChannelState is the connection state of a channel's upstream platform.
+type ChannelState int
+
+const (
+	// ChannelDisconnected means there is no session and none is being attempted.
+	ChannelDisconnected ChannelState = iota
+	// ChannelConnecting means the first session is being established.
+	ChannelConnecting
+	// ChannelConnected means a session is up.
+	ChannelConnected
+	// ChannelDegraded means the session dropped and is reconnecting, or the
+	// session reported that it is impaired.
+	ChannelDegraded
+)
+
+func (s ChannelState) String() string {
+	switch s {
+	case ChannelConnecting:
+		return "connecting"
+	case ChannelConnected:
+		return "connected"
+	case ChannelDegraded:
+		return "degraded"
+	default:
+		return "disconnected"
+	}
+}
+
+// ChannelHandlerWithState is an optional extension for channel handlers that
+// report their connection state. When implemented, health checks are healthy
+// only while connected and carry the state and last error. ChannelBase
+// implements State.
+type ChannelHandlerWithState interface {
+	ChannelHandler
+	State() (ChannelState, error)
+}
+
+// ChannelRunFunc runs one upstream session. It should call s.Connected once
+// the platform connection is up, pass inbound messages to s.Deliver, and block
+// until ctx is cancelled (return nil) or the connection drops (return the
+// cause). Returning an Error with CodeUnauthenticated or CodeInvalidArgument
+// stops reconnection.
+type ChannelRunFunc func(ctx context.Context, config map[string]string, s *ChannelSession) error
+
+// ChannelOption configures a ChannelBase.
+type ChannelOption func(*ChannelBase)
+
+// WithReconnectBackoff sets the delay before the first reconnect attempt and
+// the cap it doubles up to. Defaults to 1s and 1m. Each delay is jittered
+// down by up to half.
+func WithReconnectBackoff(initial, max time.Duration) ChannelOption {
+	return func(b *ChannelBase) {
+		b.initialBackoff = initial
+		b.maxBackoff = max
+	}
+}
+
+// WithMaxReconnects gives up after n consecutive failed reconnect attempts.
+// Defaults to 0, which retries forever.
+func WithMaxReconnects(n int) ChannelOption {
+	return func(b *ChannelBase) { b.maxReconnects = n }
+}
+
+// WithStateChange registers a callback for every state transition and every
+// error, including a repeated one. It is called synchronously and must not
+// block.
+func WithStateChange(fn func(state ChannelState, err error)) ChannelOption {
+	return func(b *ChannelBase) { b.onState = fn }
+}
+
+// ChannelBase implements Connect, Disconnect, Receive and State for channel
+// apps whose platform connections drop, such as bot gateways and websockets.
+// Embed a *ChannelBase and implement ID and Send:
+//
+//	type Telegram struct{ *nebo.ChannelBase }
+//
+//	t := &Telegram{}
+//	t.ChannelBase = nebo.NewChannelBase(t.poll)
+//
+// Sessions are restarted with jittered exponential backoff when they fail.
+// The channel returned by Receive outlives individual sessions, so Nebo's
+// Receive stream is unaffected by reconnects.
+type ChannelBase struct {
+	run            ChannelRunFunc
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	maxReconnects  int
+	onState        func(ChannelState, error)
+
+	inbound chan ChannelEnvelope
+
+	mu      sync.Mutex
+	state   ChannelState
+	lastErr error
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// NewChannelBase creates a ChannelBase that runs sessions with run.
+func NewChannelBase(run ChannelRunFunc, opts ...ChannelOption) *ChannelBase {
+	b := &ChannelBase{
+		run:            run,
+		initialBackoff: time.Second,
+		maxBackoff:     time.Minute,
+		inbound:        make(chan ChannelEnvelope),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// errSessionEnded is reported when a session returns nil before being cancelled.
+var errSessionEnded = errors.New("channel session ended")
+
+// errConnectInterrupted is returned by Connect when Disconnect or another
+// Connect stops the loop before the first session is up.
+var errConnectInterrupted = NewError(CodeUnavailable, "channel disconnected while connecting")
+
+// Connect starts the session loop and waits for the first session to come up.
+// If it fails, Connect returns the error and does not retry. Calling Connect
+// while connected restarts the loop with the new config; a Connect still
+// waiting is then interrupted with CodeUnavailable, as it is by Disconnect.
+func (b *ChannelBase) Connect(ctx context.Context, config map[string]string) error {
+	b.Disconnect(ctx)
+
+	loopCtx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	done := make(chan struct{})
+	b.mu.Lock()
+	b.cancel = cancel
+	b.done = done
+	b.mu.Unlock()
+
+	b.setState(ChannelConnecting, nil)
+	go b.loop(loopCtx, config, first, done)
+
+	select {
+	case err := <-first:
+		return err
+	case <-done:
+		// The loop ended without a first session: either it reported
+		// one just before exiting, or Disconnect stopped it.
+		select {
+		case err := <-first:
+			return err
+		default:
+			return errConnectInterrupted
+		}
+	case <-ctx.Done():
+		b.Disconnect(context.Background())
+		return ctx.Err()
+	}
+}
+
+// Disconnect stops the current session and waits for it to exit, or for ctx.
+func (b *ChannelBase) Disconnect(ctx context.Context) error {
+	b.mu.Lock()
+	cancel, done := b.cancel, b.done
+	b.cancel, b.done = nil, nil
+	b.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Receive returns inbound messages from every session until ctx is done.
+func (b *ChannelBase) Receive(ctx context.Context) (<-chan ChannelEnvelope, error) {
+	out := make(chan ChannelEnvelope)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case env := <-b.inbound:
+				select {
+				case out <- env:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// State returns the connection state and the error behind it, if any.
+func (b *ChannelBase) State() (ChannelState, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state, b.lastErr
+}
+
+func (b *ChannelBase) setState(state ChannelState, err error) {
+	b.mu.Lock()
+	// Errors are not compared: their dynamic type may not be comparable.
+	changed := b.state != state || err != nil || b.lastErr != nil
+	b.state, b.lastErr = state, err
+	b.mu.Unlock()
+	if changed && b.onState != nil {
+		b.onState(state, err)
+	}
+}
+
+func (b *ChannelBase) loop(ctx context.Context, config map[string]string, first chan<- error, done chan struct{}) {
+	defer close(done)
+	everConnected := false
+	failures := 0
+	for {
+		s := &ChannelSession{base: b, ctx: ctx, first: first}
+		if everConnected {
+			s.first = nil
+		}
+		err := b.run(ctx, config, s)
+		if ctx.Err() != nil {
+			b.setState(ChannelDisconnected, nil)
+			return
+		}
+		if err == nil {
+			err = errSessionEnded
+		}
+		if !s.connected {
+			if !everConnected {
+				b.setState(ChannelDisconnected, err)
+				first <- err
+				return
+			}
+			failures++
+		} else {
+			everConnected = true
+			failures = 0
+		}
+		if stopsReconnect(err) || (b.maxReconnects > 0 && failures >= b.maxReconnects) {
+			b.setState(ChannelDisconnected, err)
+			return
+		}
+
+		b.setState(ChannelDegraded, err)
+		select {
+		case <-time.After(b.backoff(failures)):
+		case <-ctx.Done():
+			b.setState(ChannelDisconnected, nil)
+			return
+		}
+	}
+}
+
+// backoff returns the jittered delay after n consecutive failed attempts.
+func (b *ChannelBase) backoff(n int) time.Duration {
+	d := b.initialBackoff
+	for i := 0; i < n && d < b.maxBackoff; i++ {
+		d *= 2
+	}
+	d = min(d, b.maxBackoff)
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+func stopsReconnect(err error) bool {
+	var e *Error
+	if !errors.As(err, &e) {
+		return false
+	}
+	return e.Code == CodeUnauthenticated || e.Code == CodeInvalidArgument
+}
+
+// ChannelSession is the handle a ChannelRunFunc uses to report on its session.
+type ChannelSession struct {
+	base      *ChannelBase
+	ctx       context.Context
+	first     chan<- error
+	connected bool
+}
+
+// Connected marks the session as up. Connect returns once the first session
+// calls it.
+func (s *ChannelSession) Connected() {
+	if !s.connected && s.first != nil {
+		s.first <- nil
+	}
+	s.connected = true
+	s.base.setState(ChannelConnected, nil)
+}
+
+// Degraded reports that the session is up but impaired, for example rate
+// limited. Call Connected when it recovers.
+func (s *ChannelSession) Degraded(err error) {
+	s.base.setState(ChannelDegraded, err)
+}
+
+// Deliver passes an inbound message to Nebo, blocking until it is taken or the
+// session ends. It reports whether the message was taken.
+func (s *ChannelSession) Deliver(env ChannelEnvelope) bool {
+	select {
+	case s.base.inbound <- env:
+		return true
+	case <-s.ctx.Done():
+		return false
+	}
+}
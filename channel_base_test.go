@@ -0,0 +1,214 @@
+package nebo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+)
+
+// flakyChannel embeds ChannelBase and runs sessions controlled by the test.
+type flakyChannel struct {
+	*ChannelBase
+	sessions chan *ChannelSession
+	drop     chan error
+}
+
+func newFlakyChannel(opts ...ChannelOption) *flakyChannel {
+	c := &flakyChannel{sessions: make(chan *ChannelSession, 10), drop: make(chan error)}
+	opts = append([]ChannelOption{WithReconnectBackoff(time.Millisecond, 5*time.Millisecond)}, opts...)
+	c.ChannelBase = NewChannelBase(c.run, opts...)
+	return c
+}
+
+func (c *flakyChannel) run(ctx context.Context, _ map[string]string, s *ChannelSession) error {
+	s.Connected()
+	c.sessions <- s
+	select {
+	case err := <-c.drop:
+		return err
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+func (c *flakyChannel) ID() string { return "flaky" }
+func (c *flakyChannel) Send(context.Context, ChannelEnvelope) (string, error) {
+	return "", nil
+}
+
+func waitState(t *testing.T, b *ChannelBase, want ChannelState) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if got, _ := b.State(); got == want {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	got, err := b.State()
+	t.Fatalf("state = %s (%v), want %s", got, err, want)
+}
+
+func TestChannelBaseReconnects(t *testing.T) {
+	var mu sync.Mutex
+	var states []ChannelState
+	c := newFlakyChannel(WithStateChange(func(s ChannelState, _ error) {
+		mu.Lock()
+		states = append(states, s)
+		mu.Unlock()
+	}))
+	ctx := context.Background()
+
+	if err := c.Connect(ctx, nil); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	<-c.sessions
+	msgs, _ := c.Receive(ctx)
+
+	c.drop <- errors.New("socket closed")
+	s := <-c.sessions
+	waitState(t, c.ChannelBase, ChannelConnected)
+
+	// Messages from the new session arrive on the same Receive channel.
+	go s.Deliver(ChannelEnvelope{Text: "after reconnect"})
+	select {
+	case env := <-msgs:
+		if env.Text != "after reconnect" {
+			t.Errorf("Text = %q", env.Text)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("message not received after reconnect")
+	}
+
+	if err := c.Disconnect(ctx); err != nil {
+		t.Fatalf("Disconnect: %v", err)
+	}
+	waitState(t, c.ChannelBase, ChannelDisconnected)
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []ChannelState{ChannelConnecting, ChannelConnected, ChannelDegraded, ChannelConnected, ChannelDisconnected}
+	if len(states) != len(want) {
+		t.Fatalf("states = %v, want %v", states, want)
+	}
+	for i := range want {
+		if states[i] != want[i] {
+			t.Errorf("states = %v, want %v", states, want)
+			break
+		}
+	}
+}
+
+func TestChannelBaseStopsOnAuthError(t *testing.T) {
+	c := newFlakyChannel()
+	if err := c.Connect(context.Background(), nil); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	<-c.sessions
+	c.drop <- NewError(CodeUnauthenticated, "token revoked")
+	waitState(t, c.ChannelBase, ChannelDisconnected)
+
+	_, err := c.State()
+	if !errors.Is(err, &Error{Code: CodeUnauthenticated}) {
+		t.Errorf("State err = %v, want unauthenticated", err)
+	}
+	select {
+	case <-c.sessions:
+		t.Error("reconnected after unauthenticated error")
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestChannelBaseConnectFails(t *testing.T) {
+	b := NewChannelBase(func(context.Context, map[string]string, *ChannelSession) error {
+		return errors.New("bad token")
+	})
+	if err := b.Connect(context.Background(), nil); err == nil || err.Error() != "bad token" {
+		t.Errorf("Connect err = %v, want bad token", err)
+	}
+	if s, _ := b.State(); s != ChannelDisconnected {
+		t.Errorf("state = %s, want disconnected", s)
+	}
+}
+
+func TestChannelBaseDisconnectWhileConnecting(t *testing.T) {
+	started := make(chan struct{})
+	b := NewChannelBase(func(ctx context.Context, _ map[string]string, _ *ChannelSession) error {
+		close(started)
+		<-ctx.Done() // never calls Connected
+		return nil
+	})
+	errc := make(chan error, 1)
+	go func() { errc <- b.Connect(context.Background(), nil) }()
+	<-started
+	if err := b.Disconnect(context.Background()); err != nil {
+		t.Fatalf("Disconnect: %v", err)
+	}
+	select {
+	case err := <-errc:
+		if !errors.Is(err, &Error{Code: CodeUnavailable}) {
+			t.Errorf("Connect err = %v, want unavailable", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Connect still blocked after Disconnect")
+	}
+}
+
+// sliceError is not comparable; comparing two of them with == panics.
+type sliceError []string
+
+func (e sliceError) Error() string { return e[0] }
+
+func TestChannelBaseStateUncomparableError(t *testing.T) {
+	var errs []error
+	b := NewChannelBase(nil, WithStateChange(func(_ ChannelState, err error) { errs = append(errs, err) }))
+	b.setState(ChannelDegraded, sliceError{"a"})
+	b.setState(ChannelDegraded, sliceError{"b"})
+	b.setState(ChannelConnected, nil)
+	b.setState(ChannelConnected, nil)
+	if len(errs) != 3 || errs[2] != nil {
+		t.Errorf("state changes = %v, want a, b, nil", errs)
+	}
+}
+
+func TestChannelBaseBackoff(t *testing.T) {
+	b := NewChannelBase(nil, WithReconnectBackoff(time.Second, 8*time.Second))
+	tests := []struct {
+		failures int
+		max      time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{3, 8 * time.Second},
+		{10, 8 * time.Second},
+	}
+	for _, tt := range tests {
+		for range 50 {
+			if d := b.backoff(tt.failures); d < tt.max/2 || d > tt.max {
+				t.Fatalf("backoff(%d) = %v, want [%v, %v]", tt.failures, d, tt.max/2, tt.max)
+			}
+		}
+	}
+}
+
+func TestChannelBridgeHealthReportsState(t *testing.T) {
+	c := newFlakyChannel()
+	b := &channelBridge{handler: c, env: &AppEnv{Name: "flaky"}}
+
+	resp, _ := b.HealthCheck(context.Background(), &pb.HealthCheckRequest{})
+	if resp.Healthy || resp.Status != "disconnected" {
+		t.Errorf("before Connect = %+v", resp)
+	}
+
+	c.Connect(context.Background(), nil)
+	<-c.sessions
+	resp, _ = b.HealthCheck(context.Background(), &pb.HealthCheckRequest{})
+	if !resp.Healthy || resp.Status != "connected" {
+		t.Errorf("after Connect = %+v", resp)
+	}
+	c.Disconnect(context.Background())
+}
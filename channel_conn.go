@@ -0,0 +1,265 @@
+package nebo
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+)
+
+// ChannelConnector is an optional extension for channels that serve several
+// accounts or workspaces from one process. Nebo connects each account under
+// its own connection ID, and NewConnection returns a fresh handler for it. That
+// handler then gets its own Connect config, Receive stream, state and health.
+// Requests without a connection ID go to the registered handler itself.
+//
+//	func (s *Slack) NewConnection(connectionID string) (nebo.ChannelHandler, error) {
+//		w := &Workspace{id: connectionID}
+//		w.ChannelBase = nebo.NewChannelBase(w.run)
+//		return w, nil
+//	}
+//
+// Without it, requests for any connection other than the default return
+// CodeUnimplemented.
+type ChannelConnector interface {
+	ChannelHandler
+	NewConnection(connectionID string) (ChannelHandler, error)
+}
+
+// channelConn is one account connection served by a channelBridge.
+type channelConn struct {
+	id      string
+	app     string // AppEnv.Name, for logging
+	handler ChannelHandler
+
+	pumpMu   sync.Mutex
+	pumping  bool
+	pumpGen  int                // bumped on each start and stop
+	stopPump context.CancelFunc // cancels the handler's Receive
+	inbound  *inboundBuffer
+	idem     *idempotencyCache // nil unless WithIdempotency
+
+	window  time.Duration // inbound reassembly, if set
+	minPart int
+}
+
+// conn returns the connection with the given ID. The default connection ("")
+// always exists. With create set, an unknown ID gets a new handler from the
+// ChannelConnector; otherwise it is CodeNotFound.
+func (b *channelBridge) conn(id string, create bool) (*channelConn, error) {
+	b.connMu.Lock()
+	defer b.connMu.Unlock()
+	if c, ok := b.conns[id]; ok {
+		return c, nil
+	}
+	h := b.handler
+	if id != "" {
+		connector, ok := b.handler.(ChannelConnector)
+		if !ok {
+			return nil, unsupported("multiple connections")
+		}
+		if !create {
+			return nil, Errorf(CodeNotFound, "channel connection %q not found", id)
+		}
+		var err error
+		if h, err = connector.NewConnection(id); err != nil {
+			return nil, err
+		}
+	}
+	if b.conns == nil {
+		b.conns = make(map[string]*channelConn)
+	}
+	c := &channelConn{
+		id:      id,
+		app:     b.env.Name,
+		handler: h,
+		inbound: b.openInbound(id),
+		idem:    b.openIdempotency(id),
+		window:  b.reassembleWindow,
+		minPart: b.reassembleMinPart,
+	}
+	b.conns[id] = c
+	return c, nil
+}
+
+// drop forgets a disconnected connection and closes its files. The default
+// connection is kept, so its unacknowledged messages survive a reconnect.
+func (b *channelBridge) drop(c *channelConn) {
+	if c.id == "" {
+		return
+	}
+	b.connMu.Lock()
+	if b.conns[c.id] == c {
+		delete(b.conns, c.id)
+	}
+	b.connMu.Unlock()
+	c.inbound.close()
+	c.idem.close()
+}
+
+// dataPath returns the DataDir file holding a connection's persisted state of
+// the given kind. The handler and connection IDs are escaped, so neither can
+// name a path outside DataDir.
+func (b *channelBridge) dataPath(kind, connectionID string) string {
+	name := kind + "-" + url.PathEscape(b.handler.ID())
+	if connectionID != "" {
+		name += "-" + url.PathEscape(connectionID)
+	}
+	return filepath.Join(b.env.DataDir, name+".log")
+}
+
+// openInbound creates a connection's inbound buffer, persisted in DataDir if
+// WithPersistentInbound was given.
+func (b *channelBridge) openInbound(connectionID string) *inboundBuffer {
+	if b.persist && b.env.DataDir != "" {
+		buf, err := openInboundBuffer(b.dataPath("inbound", connectionID), b.bufferSize)
+		if err == nil {
+			return buf
+		}
+		fmt.Fprintf(os.Stderr, "[%s] inbound buffer: %v; falling back to memory\n", b.env.Name, err)
+	}
+	return newInboundBuffer(b.bufferSize)
+}
+
+// startPump starts draining the handler's Receive channel into the inbound
+// buffer, unless it is already running. The handler's Receive context lasts
+// until stopPump.
+func (c *channelConn) startPump() error {
+	c.pumpMu.Lock()
+	defer c.pumpMu.Unlock()
+	if c.pumping {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := c.handler.Receive(ctx)
+	if err != nil {
+		cancel()
+		return err
+	}
+	if c.window > 0 {
+		ch = Reassemble(ch, c.window, c.minPart)
+	}
+	c.pumping = true
+	c.pumpGen++
+	c.stopPump = cancel
+	gen := c.pumpGen
+	go func() {
+		defer cancel()
+		for {
+			var env ChannelEnvelope
+			var ok bool
+			select {
+			case env, ok = <-ch:
+			case <-ctx.Done():
+			}
+			if !ok {
+				break
+			}
+			if !c.idem.claim(inboundKey(env)) {
+				continue // redelivered by the platform
+			}
+			msg := toInboundMessage(env)
+			msg.ConnectionId = c.id
+			if err := c.inbound.push(msg); err != nil {
+				fmt.Fprintf(os.Stderr, "[%s] inbound buffer: %v\n", c.app, err)
+			}
+		}
+		c.pumpMu.Lock()
+		if c.pumpGen == gen {
+			c.pumping = false
+			c.stopPump = nil
+		}
+		c.pumpMu.Unlock()
+	}()
+	return nil
+}
+
+// stop cancels the handler's Receive. The next startPump calls it again.
+func (c *channelConn) stop() {
+	c.pumpMu.Lock()
+	defer c.pumpMu.Unlock()
+	if c.stopPump != nil {
+		c.stopPump()
+		c.stopPump = nil
+	}
+	c.pumping = false
+	c.pumpGen++
+}
+
+func (c *channelConn) status() *pb.ChannelConnectionStatus {
+	s := &pb.ChannelConnectionStatus{ConnectionId: c.id, Healthy: true}
+	if h, ok := c.handler.(ChannelHandlerWithState); ok {
+		state, err := h.State()
+		s.Healthy = state == ChannelConnected
+		s.Status = state.String()
+		if err != nil {
+			s.Detail = err.Error()
+		}
+	}
+	return s
+}
+
+// statuses reports every connection in ID order. Until any connection has
+// been used, that is just the default one.
+func (b *channelBridge) statuses() []*pb.ChannelConnectionStatus {
+	b.connMu.Lock()
+	conns := make([]*channelConn, 0, len(b.conns))
+	for _, c := range b.conns {
+		conns = append(conns, c)
+	}
+	b.connMu.Unlock()
+	if len(conns) == 0 {
+		// Not b.conn: reporting status must not open the buffers.
+		conns = append(conns, &channelConn{handler: b.handler})
+	}
+	sort.Slice(conns, func(i, j int) bool { return conns[i].id < conns[j].id })
+
+	out := make([]*pb.ChannelConnectionStatus, len(conns))
+	for i, c := range conns {
+		out[i] = c.status()
+	}
+	return out
+}
+
+func (b *channelBridge) Connections(_ context.Context, _ *pb.Empty) (*pb.ChannelConnectionsResponse, error) {
+	return &pb.ChannelConnectionsResponse{Connections: b.statuses()}, nil
+}
+
+// health summarises the connections for HealthCheck: healthy only if all of
+// them are, with the state of a single connection or the unhealthy ones listed.
+func (b *channelBridge) health(resp *pb.HealthCheckResponse) {
+	statuses := b.statuses()
+	if len(statuses) == 1 {
+		s := statuses[0]
+		resp.Healthy, resp.Status, resp.Detail = s.Healthy, s.Status, s.Detail
+		return
+	}
+	var bad []string
+	for _, s := range statuses {
+		if s.Healthy {
+			continue
+		}
+		id := s.ConnectionId
+		if id == "" {
+			id = "default"
+		}
+		line := fmt.Sprintf("%s: %s", id, s.Status)
+		if s.Detail != "" {
+			line += " (" + s.Detail + ")"
+		}
+		bad = append(bad, line)
+	}
+	resp.Healthy = len(bad) == 0
+	resp.Status = "connected"
+	if !resp.Healthy {
+		resp.Status = "degraded"
+		resp.Detail = strings.Join(bad, "; ")
+	}
+}
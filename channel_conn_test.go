@@ -0,0 +1,159 @@
+package nebo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+)
+
+// workspaceChannel is one account of multiWorkspace.
+type workspaceChannel struct {
+	chanChannel
+	config map[string]string
+	sent   []string
+	state  ChannelState
+}
+
+func (w *workspaceChannel) Connect(_ context.Context, config map[string]string) error {
+	w.config = config
+	w.state = ChannelConnected
+	return nil
+}
+func (w *workspaceChannel) Send(_ context.Context, env ChannelEnvelope) (string, error) {
+	w.sent = append(w.sent, env.Text)
+	return "m1", nil
+}
+func (w *workspaceChannel) State() (ChannelState, error) { return w.state, nil }
+
+// multiWorkspace is a ChannelConnector that keeps the handlers it creates.
+type multiWorkspace struct {
+	chanChannel
+	accounts map[string]*workspaceChannel
+}
+
+func (m *multiWorkspace) NewConnection(id string) (ChannelHandler, error) {
+	w := &workspaceChannel{chanChannel: chanChannel{in: make(chan ChannelEnvelope)}}
+	m.accounts[id] = w
+	return w, nil
+}
+
+func TestChannelBridgeConnections(t *testing.T) {
+	h := &multiWorkspace{accounts: map[string]*workspaceChannel{}}
+	b := &channelBridge{handler: h, env: &AppEnv{}}
+	ctx := context.Background()
+
+	for _, id := range []string{"acme", "globex"} {
+		resp, _ := b.Connect(ctx, &pb.ChannelConnectRequest{ConnectionId: id, Config: map[string]string{"team": id}})
+		if resp.Error != "" {
+			t.Fatalf("Connect(%s): %s", id, resp.Error)
+		}
+	}
+	if h.accounts["acme"].config["team"] != "acme" || h.accounts["globex"].config["team"] != "globex" {
+		t.Errorf("configs = %v, %v", h.accounts["acme"].config, h.accounts["globex"].config)
+	}
+
+	b.Send(ctx, &pb.ChannelSendRequest{ConnectionId: "globex", Text: "hi"})
+	if len(h.accounts["acme"].sent) != 0 || len(h.accounts["globex"].sent) != 1 {
+		t.Errorf("sent acme=%v globex=%v, want only globex", h.accounts["acme"].sent, h.accounts["globex"].sent)
+	}
+
+	sctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	s := &fakeReceiveStream{ctx: sctx, sent: make(chan *pb.InboundMessage, 10)}
+	go b.Receive(&pb.ChannelReceiveRequest{ConnectionId: "acme"}, s)
+	h.accounts["acme"].in <- ChannelEnvelope{Text: "from acme"}
+	if got := receiveOne(t, s.sent); got.ConnectionId != "acme" || got.Text != "from acme" {
+		t.Errorf("inbound = %s/%q", got.ConnectionId, got.Text)
+	}
+
+	h.accounts["globex"].state = ChannelDegraded
+	health, _ := b.HealthCheck(ctx, &pb.HealthCheckRequest{})
+	if health.Healthy || !strings.Contains(health.Detail, "globex: degraded") {
+		t.Errorf("health = %v %q, want unhealthy naming globex", health.Healthy, health.Detail)
+	}
+	conns, _ := b.Connections(ctx, &pb.Empty{})
+	if len(conns.Connections) != 2 || conns.Connections[0].ConnectionId != "acme" || !conns.Connections[0].Healthy {
+		t.Errorf("connections = %v", conns.Connections)
+	}
+}
+
+func TestChannelBridgeConnectionErrors(t *testing.T) {
+	ctx := context.Background()
+
+	multi := &channelBridge{handler: &multiWorkspace{accounts: map[string]*workspaceChannel{}}, env: &AppEnv{}}
+	resp, _ := multi.Send(ctx, &pb.ChannelSendRequest{ConnectionId: "nobody"})
+	if resp.ErrorDetail.GetCode() != "not_found" {
+		t.Errorf("send to unknown connection = %+v, want not_found", resp)
+	}
+
+	single := &channelBridge{handler: &chanChannel{}, env: &AppEnv{}}
+	conn, _ := single.Connect(ctx, &pb.ChannelConnectRequest{ConnectionId: "acme"})
+	if conn.ErrorDetail.GetCode() != "unimplemented" {
+		t.Errorf("second connection on a single-account channel = %+v, want unimplemented", conn)
+	}
+	if _, err := single.conn("", false); err != nil {
+		t.Errorf("default connection: %v", err)
+	}
+	if _, err := multi.conn("x", false); !errors.Is(err, &Error{Code: CodeNotFound}) {
+		t.Errorf("conn(x) err = %v, want not_found", err)
+	}
+}
+
+// ctxChannel records the context its Receive was called with.
+type ctxChannel struct {
+	chanChannel
+	ctxs []context.Context
+}
+
+func (c *ctxChannel) Receive(ctx context.Context) (<-chan ChannelEnvelope, error) {
+	c.ctxs = append(c.ctxs, ctx)
+	return c.in, nil
+}
+
+func TestChannelBridgeDisconnectStopsReceive(t *testing.T) {
+	h := &ctxChannel{chanChannel: chanChannel{in: make(chan ChannelEnvelope)}}
+	b := &channelBridge{handler: h, env: &AppEnv{}}
+	ctx := context.Background()
+
+	b.Ack(ctx, &pb.ChannelAckRequest{}) // starts the pump
+	if len(h.ctxs) != 1 || h.ctxs[0].Err() != nil {
+		t.Fatalf("Receive contexts = %v, want one live", h.ctxs)
+	}
+	b.Disconnect(ctx, &pb.ChannelDisconnectRequest{})
+	if h.ctxs[0].Err() == nil {
+		t.Error("Receive context not cancelled by Disconnect")
+	}
+	b.Ack(ctx, &pb.ChannelAckRequest{})
+	if len(h.ctxs) != 2 || h.ctxs[1].Err() != nil {
+		t.Errorf("Receive contexts = %v, want a new live one after reconnecting", h.ctxs)
+	}
+}
+
+func TestChannelBridgeDisconnectRemovesConnection(t *testing.T) {
+	h := &multiWorkspace{accounts: map[string]*workspaceChannel{}}
+	dir := t.TempDir()
+	b := &channelBridge{handler: h, env: &AppEnv{DataDir: dir}, persist: true}
+	ctx := context.Background()
+
+	if conns, _ := b.Connections(ctx, &pb.Empty{}); len(conns.Connections) != 1 || len(b.conns) != 0 {
+		t.Fatalf("connections = %v, opened %d", conns.Connections, len(b.conns))
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("status check created %d files", len(entries))
+	}
+
+	b.Connect(ctx, &pb.ChannelConnectRequest{ConnectionId: "acme"})
+	b.Connect(ctx, &pb.ChannelConnectRequest{ConnectionId: "globex"})
+	b.Disconnect(ctx, &pb.ChannelDisconnectRequest{ConnectionId: "acme"})
+	conns, _ := b.Connections(ctx, &pb.Empty{})
+	if len(conns.Connections) != 1 || conns.Connections[0].ConnectionId != "globex" {
+		t.Errorf("connections after disconnect = %v, want only globex", conns.Connections)
+	}
+	if _, err := b.conn("acme", false); !errors.Is(err, &Error{Code: CodeNotFound}) {
+		t.Errorf("conn(acme) err = %v, want not_found", err)
+	}
+}
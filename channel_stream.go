@@ -0,0 +1,262 @@
+package nebo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/neboloop/nebo-sdk-go/markdown"
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+)
+
+// ChannelStreamer is an optional extension for channels that can show a reply
+// while it is being written. SendStream receives the envelope of the reply
+// (with any initial text) and the text deltas that follow; deltas is closed
+// when the reply is complete, and ctx is cancelled if Nebo's stream breaks.
+// It returns the platform message ID. EditStreamer implements the common
+// post-then-edit approach.
+//
+// Channels without it receive the whole reply through Send once it is complete.
+type ChannelStreamer interface {
+	ChannelHandler
+	SendStream(ctx context.Context, env ChannelEnvelope, deltas <-chan string) (string, error)
+}
+
+func (b *channelBridge) SendStream(stream pb.ChannelService_SendStreamServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	start := first.GetStart()
+	if start == nil {
+		return stream.SendAndClose(sendResponse(nil, NewError(CodeInvalidArgument, "first stream chunk has no start")))
+	}
+	env := fromSendRequest(start)
+	env.Text += first.Delta
+
+	var ids []string
+	c, err := b.conn(start.ConnectionId, false)
+	if err == nil {
+		ids, err = c.idem.do(stream.Context(), sendKey(env), func() ([]string, error) {
+			if streamer, ok := c.handler.(ChannelStreamer); ok {
+				id, err := b.streamTo(stream, streamer, env)
+				if id == "" {
+					return nil, err
+				}
+				return []string{id}, err
+			}
+			return b.sendWhole(stream, c, env)
+		})
+	}
+	return stream.SendAndClose(sendResponse(ids, err))
+}
+
+// sendResponse reports the IDs of the parts sent and any error.
+func sendResponse(ids []string, err error) *pb.ChannelSendResponse {
+	resp := &pb.ChannelSendResponse{MessageIds: ids}
+	if len(ids) > 0 {
+		resp.MessageId = ids[0]
+	}
+	if err != nil {
+		resp.Error = err.Error()
+		resp.ErrorDetail = toProtoError(err)
+	}
+	return resp
+}
+
+// sendWhole collects every delta and sends the complete reply.
+func (b *channelBridge) sendWhole(stream pb.ChannelService_SendStreamServer, c *channelConn, env ChannelEnvelope) ([]string, error) {
+	var text strings.Builder
+	text.WriteString(env.Text)
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		text.WriteString(chunk.Delta)
+	}
+	env.Text = text.String()
+	return b.send(stream.Context(), c, env)
+}
+
+func (b *channelBridge) streamTo(stream pb.ChannelService_SendStreamServer, streamer ChannelStreamer, env ChannelEnvelope) (string, error) {
+	ctx, cancel := context.WithCancelCause(stream.Context())
+	defer cancel(nil)
+
+	deltas := make(chan string)
+	go func() {
+		defer close(deltas)
+		for {
+			chunk, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				cancel(err)
+				return
+			}
+			if chunk.Delta == "" {
+				continue
+			}
+			select {
+			case deltas <- chunk.Delta:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	id, err := streamer.SendStream(ctx, env, deltas)
+	if cause := context.Cause(ctx); err == nil && cause != nil && cause != context.Canceled {
+		err = cause
+	}
+	return id, err
+}
+
+// defaultEditInterval is the minimum gap between edits when EditStreamer.Interval is 0.
+const defaultEditInterval = time.Second
+
+// typingEvery is how often EditStreamer repeats Typing before the first post.
+// Platforms typically show a typing indicator for about five seconds.
+const typingEvery = 4 * time.Second
+
+// EditStreamer shows a streamed reply by posting it once and then editing it
+// as more text arrives, no more often than Interval. A rate-limited Error from
+// Send or Edit delays the next attempt by its RetryAfter instead of failing.
+//
+//	func (t *Telegram) SendStream(ctx context.Context, env nebo.ChannelEnvelope, deltas <-chan string) (string, error) {
+//		s := &nebo.EditStreamer{
+//			Send:     func(ctx context.Context, text string) (string, error) { return t.post(ctx, env.ChannelID, text) },
+//			Edit:     func(ctx context.Context, id, text string) error { return t.edit(ctx, env.ChannelID, id, text) },
+//			Typing:   func(ctx context.Context) error { return t.typing(ctx, env.ChannelID) },
+//			Interval: time.Second,
+//		}
+//		return s.Stream(ctx, env.Text, deltas)
+//	}
+type EditStreamer struct {
+	// Send posts the first text and returns the message ID.
+	Send func(ctx context.Context, text string) (string, error)
+	// Edit replaces the posted message's text with the full text so far.
+	Edit func(ctx context.Context, messageID, text string) error
+	// Typing, if set, shows a typing indicator until the first post.
+	Typing func(ctx context.Context) error
+	// Interval is the minimum time between Send and successive Edits.
+	Interval time.Duration
+	// Format, if set, converts the Markdown text to this dialect before each
+	// Send and Edit.
+	Format markdown.Dialect
+}
+
+// Stream posts initial plus deltas as they arrive and returns the message ID
+// once the final text has been written. It returns "" if there was no text.
+func (s *EditStreamer) Stream(ctx context.Context, initial string, deltas <-chan string) (string, error) {
+	interval := s.Interval
+	if interval <= 0 {
+		interval = defaultEditInterval
+	}
+
+	var typing <-chan time.Time
+	if s.Typing != nil {
+		s.Typing(ctx)
+		ticker := time.NewTicker(typingEvery)
+		defer ticker.Stop()
+		typing = ticker.C
+	}
+
+	var (
+		text  strings.Builder
+		id    string
+		shown string    // text as last posted or edited
+		next  time.Time // earliest time for the next post or edit
+		timer <-chan time.Time
+	)
+	text.WriteString(initial)
+
+	flush := func() error {
+		cur := text.String()
+		if cur == "" || cur == shown {
+			return nil
+		}
+		out := cur
+		if s.Format != "" {
+			out = markdown.Convert(cur, s.Format)
+		}
+		var err error
+		if id == "" {
+			id, err = s.Send(ctx, out)
+		} else {
+			err = s.Edit(ctx, id, out)
+		}
+		if err != nil {
+			var e *Error
+			if errors.As(err, &e) && e.Code == CodeRateLimited {
+				next = time.Now().Add(max(e.RetryAfter, interval))
+				return nil
+			}
+			return err
+		}
+		typing = nil
+		shown = cur
+		next = time.Now().Add(interval)
+		return nil
+	}
+	// schedule flushes now if allowed, and arms the timer if text is still
+	// waiting to be shown.
+	schedule := func() error {
+		if timer != nil {
+			return nil
+		}
+		if time.Until(next) <= 0 {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		if wait := time.Until(next); wait > 0 && text.String() != shown {
+			timer = time.After(wait)
+		}
+		return nil
+	}
+
+	if err := schedule(); err != nil {
+		return id, err
+	}
+	for {
+		select {
+		case d, ok := <-deltas:
+			if !ok {
+				// Write the final text, waiting out the interval as needed.
+				for text.String() != shown {
+					if wait := time.Until(next); wait > 0 {
+						select {
+						case <-time.After(wait):
+						case <-ctx.Done():
+							return id, ctx.Err()
+						}
+					}
+					if err := flush(); err != nil {
+						return id, err
+					}
+				}
+				return id, nil
+			}
+			text.WriteString(d)
+			if err := schedule(); err != nil {
+				return id, err
+			}
+		case <-timer:
+			timer = nil
+			if err := schedule(); err != nil {
+				return id, err
+			}
+		case <-typing:
+			s.Typing(ctx)
+		case <-ctx.Done():
+			return id, ctx.Err()
+		}
+	}
+}
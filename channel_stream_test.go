@@ -0,0 +1,183 @@
+package nebo
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+	"google.golang.org/grpc"
+)
+
+// fakeSendStream replays chunks to a SendStream handler.
+type fakeSendStream struct {
+	grpc.ServerStream
+	chunks []*pb.ChannelStreamChunk
+	resp   *pb.ChannelSendResponse
+}
+
+func (s *fakeSendStream) Context() context.Context { return context.Background() }
+func (s *fakeSendStream) Recv() (*pb.ChannelStreamChunk, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+	c := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return c, nil
+}
+func (s *fakeSendStream) SendAndClose(resp *pb.ChannelSendResponse) error {
+	s.resp = resp
+	return nil
+}
+
+// sendRecorder is a ChannelHandler that records Send calls.
+type sendRecorder struct {
+	chanChannel
+	sent []ChannelEnvelope
+}
+
+func (s *sendRecorder) Send(_ context.Context, env ChannelEnvelope) (string, error) {
+	s.sent = append(s.sent, env)
+	return "m1", nil
+}
+
+// streamRecorder is a ChannelStreamer that collects deltas.
+type streamRecorder struct {
+	sendRecorder
+	deltas []string
+}
+
+func (s *streamRecorder) SendStream(_ context.Context, env ChannelEnvelope, deltas <-chan string) (string, error) {
+	s.deltas = append(s.deltas, env.Text)
+	for d := range deltas {
+		s.deltas = append(s.deltas, d)
+	}
+	return "streamed", nil
+}
+
+func streamChunks() []*pb.ChannelStreamChunk {
+	return []*pb.ChannelStreamChunk{
+		{Start: &pb.ChannelSendRequest{ChannelId: "c1", ReplyTo: "q"}, Delta: "Hel"},
+		{Delta: "lo, "},
+		{Delta: "world"},
+	}
+}
+
+func TestChannelBridgeSendStreamFallsBackToSend(t *testing.T) {
+	h := &sendRecorder{}
+	b := &channelBridge{handler: h, env: &AppEnv{}}
+	stream := &fakeSendStream{chunks: streamChunks()}
+
+	if err := b.SendStream(stream); err != nil {
+		t.Fatalf("SendStream: %v", err)
+	}
+	if len(h.sent) != 1 || h.sent[0].Text != "Hello, world" || h.sent[0].ReplyTo != "q" {
+		t.Errorf("sent = %+v", h.sent)
+	}
+	if stream.resp.MessageId != "m1" {
+		t.Errorf("MessageId = %q", stream.resp.MessageId)
+	}
+}
+
+func TestChannelBridgeSendStreamToStreamer(t *testing.T) {
+	h := &streamRecorder{}
+	b := &channelBridge{handler: h, env: &AppEnv{}}
+	stream := &fakeSendStream{chunks: streamChunks()}
+
+	if err := b.SendStream(stream); err != nil {
+		t.Fatalf("SendStream: %v", err)
+	}
+	if got := strings.Join(h.deltas, "|"); got != "Hel|lo, |world" {
+		t.Errorf("deltas = %q", got)
+	}
+	if len(h.sent) != 0 || stream.resp.MessageId != "streamed" {
+		t.Errorf("sent = %v, resp = %+v", h.sent, stream.resp)
+	}
+}
+
+func TestChannelBridgeSendStreamWithoutStart(t *testing.T) {
+	h := &sendRecorder{}
+	b := &channelBridge{handler: h, env: &AppEnv{}}
+	stream := &fakeSendStream{chunks: []*pb.ChannelStreamChunk{{Delta: "Hel"}, {Delta: "lo"}}}
+
+	if err := b.SendStream(stream); err != nil {
+		t.Fatalf("SendStream: %v", err)
+	}
+	if stream.resp.ErrorDetail.GetCode() != "invalid_argument" || len(h.sent) != 0 {
+		t.Errorf("resp = %+v, sent = %v; want invalid_argument and nothing sent", stream.resp, h.sent)
+	}
+}
+
+func TestEditStreamerThrottles(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	s := &EditStreamer{
+		Send: func(_ context.Context, text string) (string, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, "send "+text)
+			return "m1", nil
+		},
+		Edit: func(_ context.Context, id, text string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, "edit "+text)
+			return nil
+		},
+		Interval: 30 * time.Millisecond,
+	}
+
+	deltas := make(chan string)
+	go func() {
+		for _, d := range []string{"a", "b", "c", "d"} {
+			deltas <- d
+		}
+		close(deltas)
+	}()
+
+	id, err := s.Stream(context.Background(), "", deltas)
+	if err != nil || id != "m1" {
+		t.Fatalf("Stream = %q, %v", id, err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	// The first delta posts immediately; the rest arrive within one interval
+	// and collapse into a single edit.
+	if len(calls) != 2 || calls[0] != "send a" || calls[1] != "edit abcd" {
+		t.Errorf("calls = %v", calls)
+	}
+}
+
+func TestEditStreamerRateLimited(t *testing.T) {
+	var edits []string
+	limited := true
+	s := &EditStreamer{
+		Send: func(context.Context, string) (string, error) { return "m1", nil },
+		Edit: func(_ context.Context, _, text string) error {
+			if limited {
+				limited = false
+				return NewError(CodeRateLimited, "slow down").WithRetryAfter(20 * time.Millisecond)
+			}
+			edits = append(edits, text)
+			return nil
+		},
+		Interval: time.Millisecond,
+	}
+
+	deltas := make(chan string, 2)
+	deltas <- "b"
+	close(deltas)
+	start := time.Now()
+	if _, err := s.Stream(context.Background(), "a", deltas); err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	if len(edits) != 1 || edits[0] != "ab" {
+		t.Errorf("edits = %v", edits)
+	}
+	if time.Since(start) < 20*time.Millisecond {
+		t.Error("retry did not wait for RetryAfter")
+	}
+}
@@ -0,0 +1,175 @@
+package nebo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/neboloop/nebo-sdk-go/markdown"
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+)
+
+// editingChannel records Edit, Delete and React calls.
+type editingChannel struct {
+	chanChannel
+	calls []string
+}
+
+func (c *editingChannel) Edit(_ context.Context, env ChannelEnvelope) error {
+	c.calls = append(c.calls, "edit "+env.MessageID+" "+env.Text)
+	return nil
+}
+
+func (c *editingChannel) Delete(_ context.Context, _, messageID string) error {
+	c.calls = append(c.calls, "delete "+messageID)
+	return nil
+}
+
+func (c *editingChannel) React(_ context.Context, _, messageID, emoji string, remove bool) error {
+	op := "react "
+	if remove {
+		op = "unreact "
+	}
+	c.calls = append(c.calls, op+messageID+" "+emoji)
+	return nil
+}
+
+func TestChannelBridgeEditDeleteReact(t *testing.T) {
+	h := &editingChannel{}
+	b := &channelBridge{handler: h, env: &AppEnv{}}
+	ctx := context.Background()
+
+	b.Edit(ctx, &pb.ChannelEditRequest{ChannelId: "c", MessageId: "m1", Text: "fixed"})
+	b.Delete(ctx, &pb.ChannelDeleteRequest{ChannelId: "c", MessageId: "m2"})
+	b.React(ctx, &pb.ChannelReactRequest{ChannelId: "c", MessageId: "m3", Emoji: "👍"})
+	b.React(ctx, &pb.ChannelReactRequest{ChannelId: "c", MessageId: "m3", Emoji: "👍", Remove: true})
+
+	want := []string{"edit m1 fixed", "delete m2", "react m3 👍", "unreact m3 👍"}
+	if len(h.calls) != len(want) {
+		t.Fatalf("calls = %v, want %v", h.calls, want)
+	}
+	for i := range want {
+		if h.calls[i] != want[i] {
+			t.Errorf("call %d = %q, want %q", i, h.calls[i], want[i])
+		}
+	}
+}
+
+func TestChannelBridgeOptionalOpsUnimplemented(t *testing.T) {
+	b := &channelBridge{handler: &chanChannel{}, env: &AppEnv{}}
+	ctx := context.Background()
+
+	edit, _ := b.Edit(ctx, &pb.ChannelEditRequest{MessageId: "m"})
+	del, _ := b.Delete(ctx, &pb.ChannelDeleteRequest{MessageId: "m"})
+	react, _ := b.React(ctx, &pb.ChannelReactRequest{MessageId: "m", Emoji: "x"})
+	for name, detail := range map[string]*pb.ErrorResponse{
+		"Edit":   edit.ErrorDetail,
+		"Delete": del.ErrorDetail,
+		"React":  react.ErrorDetail,
+	} {
+		if detail.GetCode() != "unimplemented" {
+			t.Errorf("%s ErrorDetail = %+v, want unimplemented", name, detail)
+		}
+	}
+}
+
+func TestToInboundMessageReaction(t *testing.T) {
+	msg := toInboundMessage(ChannelEnvelope{
+		Kind:            EventReaction,
+		MessageID:       "m1",
+		Reaction:        "🎉",
+		ReactionRemoved: true,
+	})
+	if msg.Kind != "reaction" || msg.Reaction != "🎉" || !msg.ReactionRemoved || msg.MessageId != "m1" {
+		t.Errorf("inbound = %+v", msg)
+	}
+}
+
+// indicatingChannel records SetTyping and MarkRead calls.
+type indicatingChannel struct {
+	chanChannel
+	calls []string
+}
+
+func (c *indicatingChannel) SetTyping(_ context.Context, channelID string, typing bool) error {
+	if typing {
+		c.calls = append(c.calls, "typing "+channelID)
+	} else {
+		c.calls = append(c.calls, "idle "+channelID)
+	}
+	return nil
+}
+
+func (c *indicatingChannel) MarkRead(_ context.Context, channelID, messageID string) error {
+	c.calls = append(c.calls, "read "+channelID+" "+messageID)
+	return nil
+}
+
+func TestChannelBridgeTypingAndMarkRead(t *testing.T) {
+	h := &indicatingChannel{}
+	b := &channelBridge{handler: h, env: &AppEnv{}}
+	ctx := context.Background()
+
+	b.SetTyping(ctx, &pb.ChannelTypingRequest{ChannelId: "c", Typing: true})
+	b.MarkRead(ctx, &pb.ChannelMarkReadRequest{ChannelId: "c", MessageId: "m9"})
+	b.SetTyping(ctx, &pb.ChannelTypingRequest{ChannelId: "c"})
+
+	want := "typing c|read c m9|idle c"
+	if got := strings.Join(h.calls, "|"); got != want {
+		t.Errorf("calls = %q, want %q", got, want)
+	}
+
+	plain := &channelBridge{handler: &chanChannel{}, env: &AppEnv{}}
+	resp, _ := plain.SetTyping(ctx, &pb.ChannelTypingRequest{ChannelId: "c", Typing: true})
+	if resp.ErrorDetail.GetCode() != "unimplemented" {
+		t.Errorf("SetTyping without ChannelIndicator = %+v", resp)
+	}
+}
+
+func TestToInboundMessageReceiptAndPresence(t *testing.T) {
+	receipt := toInboundMessage(ChannelEnvelope{
+		Kind:      EventReceipt,
+		MessageID: "m1",
+		Receipt:   &Receipt{Status: ReceiptRead, At: "2026-01-01T00:00:00Z"},
+	})
+	if receipt.Kind != "receipt" || receipt.Receipt.GetStatus() != "read" || receipt.Presence != nil {
+		t.Errorf("receipt = %+v", receipt)
+	}
+
+	presence := toInboundMessage(ChannelEnvelope{
+		Kind:     EventPresence,
+		UserID:   "u1",
+		Presence: &Presence{Status: PresenceOffline, LastSeen: "2026-01-01T00:00:00Z"},
+	})
+	if presence.Kind != "presence" || presence.Presence.GetStatus() != "offline" || presence.UserId != "u1" {
+		t.Errorf("presence = %+v", presence)
+	}
+}
+
+// slackChannel is an editingChannel that wants Slack mrkdwn.
+type slackChannel struct {
+	editingChannel
+	sent []string
+}
+
+func (c *slackChannel) TextFormat() markdown.Dialect { return markdown.Slack }
+func (c *slackChannel) Send(_ context.Context, env ChannelEnvelope) (string, error) {
+	c.sent = append(c.sent, env.Text)
+	return "m1", nil
+}
+
+func TestChannelBridgeFormatsText(t *testing.T) {
+	h := &slackChannel{}
+	b := &channelBridge{handler: h, env: &AppEnv{}}
+	ctx := context.Background()
+
+	b.Send(ctx, &pb.ChannelSendRequest{ChannelId: "c", Text: "**done** see [PR](https://x.io/1)"})
+	b.Edit(ctx, &pb.ChannelEditRequest{ChannelId: "c", MessageId: "m1", Text: "~~done~~"})
+
+	if len(h.sent) != 1 || h.sent[0] != "*done* see <https://x.io/1|PR>" {
+		t.Errorf("sent = %q", h.sent)
+	}
+	if len(h.calls) != 1 || h.calls[0] != "edit m1 ~done~" {
+		t.Errorf("edits = %q", h.calls)
+	}
+}
@@ -63,14 +63,14 @@ func (b *commBridge) Version(_ context.Context, _ *pb.Empty) (*pb.CommVersionRes
 
 func (b *commBridge) Connect(ctx context.Context, req *pb.CommConnectRequest) (*pb.CommConnectResponse, error) {
 	if err := b.handler.Connect(ctx, req.Config); err != nil {
-		return &pb.CommConnectResponse{Error: err.Error()}, nil
+		return &pb.CommConnectResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
 	}
 	return &pb.CommConnectResponse{}, nil
 }
 
 func (b *commBridge) Disconnect(ctx context.Context, _ *pb.Empty) (*pb.CommDisconnectResponse, error) {
 	if err := b.handler.Disconnect(ctx); err != nil {
-		return &pb.CommDisconnectResponse{Error: err.Error()}, nil
+		return &pb.CommDisconnectResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
 	}
 	return &pb.CommDisconnectResponse{}, nil
 }
@@ -82,35 +82,35 @@ func (b *commBridge) IsConnected(_ context.Context, _ *pb.Empty) (*pb.CommIsConn
 func (b *commBridge) Send(ctx context.Context, req *pb.CommSendRequest) (*pb.CommSendResponse, error) {
 	msg := fromProtoCommMsg(req.Message)
 	if err := b.handler.Send(ctx, msg); err != nil {
-		return &pb.CommSendResponse{Error: err.Error()}, nil
+		return &pb.CommSendResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
 	}
 	return &pb.CommSendResponse{}, nil
 }
 
 func (b *commBridge) Subscribe(ctx context.Context, req *pb.CommSubscribeRequest) (*pb.CommSubscribeResponse, error) {
 	if err := b.handler.Subscribe(ctx, req.Topic); err != nil {
-		return &pb.CommSubscribeResponse{Error: err.Error()}, nil
+		return &pb.CommSubscribeResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
 	}
 	return &pb.CommSubscribeResponse{}, nil
 }
 
 func (b *commBridge) Unsubscribe(ctx context.Context, req *pb.CommUnsubscribeRequest) (*pb.CommUnsubscribeResponse, error) {
 	if err := b.handler.Unsubscribe(ctx, req.Topic); err != nil {
-		return &pb.CommUnsubscribeResponse{Error: err.Error()}, nil
+		return &pb.CommUnsubscribeResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
 	}
 	return &pb.CommUnsubscribeResponse{}, nil
 }
 
 func (b *commBridge) Register(ctx context.Context, req *pb.CommRegisterRequest) (*pb.CommRegisterResponse, error) {
 	if err := b.handler.Register(ctx, req.AgentId, req.Capabilities); err != nil {
-		return &pb.CommRegisterResponse{Error: err.Error()}, nil
+		return &pb.CommRegisterResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
 	}
 	return &pb.CommRegisterResponse{}, nil
 }
 
 func (b *commBridge) Deregister(ctx context.Context, _ *pb.Empty) (*pb.CommDeregisterResponse, error) {
 	if err := b.handler.Deregister(ctx); err != nil {
-		return &pb.CommDeregisterResponse{Error: err.Error()}, nil
+		return &pb.CommDeregisterResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
 	}
 	return &pb.CommDeregisterResponse{}, nil
 }
@@ -0,0 +1,319 @@
+package nebo
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// CommLink is one live connection to a comm network, as used by CommBase.
+// Its methods act on the network directly; CommBase decides when to call them.
+type CommLink interface {
+	Send(ctx context.Context, msg CommMessage) error
+	Subscribe(ctx context.Context, topic string) error
+	Unsubscribe(ctx context.Context, topic string) error
+	Register(ctx context.Context, agentID string, capabilities []string) error
+	Deregister(ctx context.Context) error
+}
+
+// CommRunFunc runs one session with the comm network. It should dial, call
+// s.Connected with the link once it is up, pass inbound messages to s.Deliver,
+// and block until ctx is cancelled (return nil) or the link drops (return the
+// cause). Returning an Error with CodeUnauthenticated or CodeInvalidArgument
+// stops reconnection.
+type CommRunFunc func(ctx context.Context, config map[string]string, s *CommSession) error
+
+// CommBase implements every CommHandler method except Name and Version for
+// comm apps. It remembers the registered agent and subscribed topics, replays
+// them on each new session, drops inbound messages on topics nobody
+// subscribed to, and reconnects with backoff like ChannelBase, whose
+// ChannelOptions it accepts. Embed a *CommBase:
+//
+//	type Loop struct{ *nebo.CommBase }
+//
+//	l := &Loop{}
+//	l.CommBase = nebo.NewCommBase(l.run, nebo.WithReconnectBackoff(time.Second, time.Minute))
+//
+// Subscribe and Register succeed while disconnected; they take effect when
+// the next session connects.
+type CommBase struct {
+	base *ChannelBase // session loop and state
+	run  CommRunFunc
+
+	inbound chan CommMessage
+
+	// opMu serialises calls on the link, so a replay and a live Subscribe
+	// cannot interleave.
+	opMu sync.Mutex
+
+	mu           sync.Mutex
+	link         CommLink // nil between sessions
+	agentID      string
+	capabilities []string
+	topics       []string
+	replies      map[string]chan CommMessage // pending Requests by correlation ID
+}
+
+// NewCommBase creates a CommBase that runs sessions with run.
+func NewCommBase(run CommRunFunc, opts ...ChannelOption) *CommBase {
+	b := &CommBase{run: run, inbound: make(chan CommMessage)}
+	b.base = NewChannelBase(b.session, opts...)
+	return b
+}
+
+// Connect starts the session loop and waits for the first session to come up,
+// as ChannelBase.Connect does.
+func (b *CommBase) Connect(ctx context.Context, config map[string]string) error {
+	return b.base.Connect(ctx, config)
+}
+
+// Disconnect stops the current session and waits for it to exit, or for ctx.
+func (b *CommBase) Disconnect(ctx context.Context) error {
+	return b.base.Disconnect(ctx)
+}
+
+// State returns the link's state and the error behind it, if any.
+func (b *CommBase) State() (ChannelState, error) {
+	return b.base.State()
+}
+
+func (b *CommBase) session(ctx context.Context, config map[string]string, cs *ChannelSession) error {
+	s := &CommSession{base: b, ch: cs}
+	err := b.run(ctx, config, s)
+	b.mu.Lock()
+	if s.link != nil && b.link == s.link {
+		b.link = nil
+	}
+	b.mu.Unlock()
+	return err
+}
+
+// IsConnected reports whether a session is up.
+func (b *CommBase) IsConnected() bool {
+	state, _ := b.State()
+	return state == ChannelConnected
+}
+
+// Send sends msg on the current link. While disconnected it returns
+// CodeUnavailable.
+func (b *CommBase) Send(ctx context.Context, msg CommMessage) error {
+	b.mu.Lock()
+	link := b.link
+	b.mu.Unlock()
+	if link == nil {
+		return Errorf(CodeUnavailable, "comm not connected")
+	}
+	return link.Send(ctx, msg)
+}
+
+// Subscribe adds topic, which may be a pattern (see MatchTopic), to the
+// subscriptions and subscribes on the current link, if any.
+func (b *CommBase) Subscribe(ctx context.Context, topic string) error {
+	b.opMu.Lock()
+	defer b.opMu.Unlock()
+	b.mu.Lock()
+	if slices.Contains(b.topics, topic) {
+		b.mu.Unlock()
+		return nil
+	}
+	link := b.link
+	b.mu.Unlock()
+	if link != nil {
+		if err := link.Subscribe(ctx, topic); err != nil {
+			return err
+		}
+	}
+	b.mu.Lock()
+	b.topics = append(b.topics, topic)
+	b.mu.Unlock()
+	return nil
+}
+
+// Unsubscribe removes topic from the subscriptions and unsubscribes on the
+// current link, if any.
+func (b *CommBase) Unsubscribe(ctx context.Context, topic string) error {
+	b.opMu.Lock()
+	defer b.opMu.Unlock()
+	b.mu.Lock()
+	b.topics = slices.DeleteFunc(b.topics, func(t string) bool { return t == topic })
+	link := b.link
+	b.mu.Unlock()
+	if link != nil {
+		return link.Unsubscribe(ctx, topic)
+	}
+	return nil
+}
+
+// Register records the agent's identity and registers it on the current
+// link, if any.
+func (b *CommBase) Register(ctx context.Context, agentID string, capabilities []string) error {
+	b.opMu.Lock()
+	defer b.opMu.Unlock()
+	b.mu.Lock()
+	link := b.link
+	b.mu.Unlock()
+	if link != nil {
+		if err := link.Register(ctx, agentID, capabilities); err != nil {
+			return err
+		}
+	}
+	b.mu.Lock()
+	b.agentID, b.capabilities = agentID, slices.Clone(capabilities)
+	b.mu.Unlock()
+	return nil
+}
+
+// Deregister forgets the agent's identity and deregisters it on the current
+// link, if any.
+func (b *CommBase) Deregister(ctx context.Context) error {
+	b.opMu.Lock()
+	defer b.opMu.Unlock()
+	b.mu.Lock()
+	registered := b.agentID != ""
+	b.agentID, b.capabilities = "", nil
+	link := b.link
+	b.mu.Unlock()
+	if link != nil && registered {
+		return link.Deregister(ctx)
+	}
+	return nil
+}
+
+// AgentID returns the registered agent ID, or "" if not registered.
+func (b *CommBase) AgentID() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.agentID
+}
+
+// Topics returns the subscribed topics in subscription order.
+func (b *CommBase) Topics() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return slices.Clone(b.topics)
+}
+
+// Receive returns inbound messages from every session until ctx is done.
+func (b *CommBase) Receive(ctx context.Context) (<-chan CommMessage, error) {
+	out := make(chan CommMessage)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case msg := <-b.inbound:
+				select {
+				case out <- msg:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// wants reports whether msg passes the subscriptions: messages without a
+// topic (direct messages) always do, others only if a subscription matches.
+func (b *CommBase) wants(msg CommMessage) bool {
+	if msg.Topic == "" {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, pattern := range b.topics {
+		if MatchTopic(pattern, msg.Topic) {
+			return true
+		}
+	}
+	return false
+}
+
+// CommSession is the handle a CommRunFunc uses to report on its session.
+type CommSession struct {
+	base *CommBase
+	ch   *ChannelSession
+	link CommLink
+}
+
+// Connected replays the registration and subscriptions on link, then marks
+// the session as up and routes CommBase's calls to link. Calling it again with
+// the same link after Degraded only marks the session as up. If the replay fails,
+// the run function should return the error so the session is retried.
+func (s *CommSession) Connected(ctx context.Context, link CommLink) error {
+	b := s.base
+	if s.link == link {
+		s.ch.Connected() // recovered from Degraded
+		return nil
+	}
+	b.opMu.Lock()
+	defer b.opMu.Unlock()
+	b.mu.Lock()
+	agentID, caps, topics := b.agentID, slices.Clone(b.capabilities), slices.Clone(b.topics)
+	b.mu.Unlock()
+
+	if agentID != "" {
+		if err := link.Register(ctx, agentID, caps); err != nil {
+			return err
+		}
+	}
+	for _, topic := range topics {
+		if err := link.Subscribe(ctx, topic); err != nil {
+			return err
+		}
+	}
+	b.mu.Lock()
+	b.link = link
+	s.link = link
+	b.mu.Unlock()
+	s.ch.Connected()
+	return nil
+}
+
+// Degraded reports that the session is up but impaired. Call Connected again
+// when it recovers.
+func (s *CommSession) Degraded(err error) {
+	s.ch.Degraded(err)
+}
+
+// Deliver passes an inbound message to Nebo if it is direct or on a
+// subscribed topic, blocking until it is taken or the session ends. Replies
+// to a pending Request go to the requester instead. It reports whether the
+// message was passed on.
+func (s *CommSession) Deliver(msg CommMessage) bool {
+	if s.base.answer(msg) {
+		return true
+	}
+	if !s.base.wants(msg) {
+		return false
+	}
+	select {
+	case s.base.inbound <- msg:
+		return true
+	case <-s.ch.ctx.Done():
+		return false
+	}
+}
+
+// MatchTopic reports whether topic matches pattern. Topics are dot-separated;
+// in a pattern, "*" matches exactly one segment and a final ">" matches one or
+// more remaining segments:
+//
+//	MatchTopic("tasks.*.done", "tasks.billing.done") // true
+//	MatchTopic("tasks.>", "tasks.billing.done")      // true
+//	MatchTopic("tasks.>", "tasks")                   // false
+func MatchTopic(pattern, topic string) bool {
+	ps := strings.Split(pattern, ".")
+	ts := strings.Split(topic, ".")
+	for i, p := range ps {
+		if p == ">" && i == len(ps)-1 {
+			return len(ts) > i
+		}
+		if i >= len(ts) || (p != "*" && p != ts[i]) {
+			return false
+		}
+	}
+	return len(ps) == len(ts)
+}
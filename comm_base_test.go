@@ -0,0 +1,186 @@
+package nebo
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingLink is a CommLink that records the calls made on it.
+type recordingLink struct {
+	mu    sync.Mutex
+	calls []string
+	sent  []CommMessage
+}
+
+func (l *recordingLink) record(call string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = append(l.calls, call)
+	return nil
+}
+
+func (l *recordingLink) Send(_ context.Context, msg CommMessage) error {
+	l.mu.Lock()
+	l.sent = append(l.sent, msg)
+	l.mu.Unlock()
+	return l.record("send " + msg.Content)
+}
+func (l *recordingLink) Subscribe(_ context.Context, topic string) error {
+	return l.record("sub " + topic)
+}
+func (l *recordingLink) Unsubscribe(_ context.Context, topic string) error {
+	return l.record("unsub " + topic)
+}
+func (l *recordingLink) Register(_ context.Context, id string, caps []string) error {
+	return l.record("reg " + id + " " + strings.Join(caps, ","))
+}
+func (l *recordingLink) Deregister(context.Context) error { return l.record("dereg") }
+
+func (l *recordingLink) Calls() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return strings.Join(l.calls, "; ")
+}
+
+// flakyComm runs comm sessions controlled by the test.
+type flakyComm struct {
+	*CommBase
+	sessions chan *CommSession
+	links    chan *recordingLink
+	drop     chan error
+}
+
+func newFlakyComm() *flakyComm {
+	c := &flakyComm{sessions: make(chan *CommSession, 10), links: make(chan *recordingLink, 10), drop: make(chan error)}
+	c.CommBase = NewCommBase(c.run, WithReconnectBackoff(time.Millisecond, 5*time.Millisecond))
+	return c
+}
+
+func (c *flakyComm) run(ctx context.Context, _ map[string]string, s *CommSession) error {
+	link := &recordingLink{}
+	if err := s.Connected(ctx, link); err != nil {
+		return err
+	}
+	c.links <- link
+	c.sessions <- s
+	select {
+	case err := <-c.drop:
+		return err
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+func (c *flakyComm) Name() string    { return "flaky" }
+func (c *flakyComm) Version() string { return "1" }
+
+var _ CommHandler = (*flakyComm)(nil)
+
+func TestCommBaseReplaysAfterReconnect(t *testing.T) {
+	c := newFlakyComm()
+	ctx := context.Background()
+
+	// Before connecting, calls are only recorded.
+	c.Register(ctx, "agent-1", []string{"billing"})
+	c.Subscribe(ctx, "tasks.>")
+	if err := c.Send(ctx, CommMessage{Content: "x"}); !errors.Is(err, &Error{Code: CodeUnavailable}) {
+		t.Errorf("Send while disconnected = %v, want unavailable", err)
+	}
+
+	if err := c.Connect(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Disconnect(ctx)
+	first := <-c.links
+	<-c.sessions
+	if got, want := first.Calls(), "reg agent-1 billing; sub tasks.>"; got != want {
+		t.Errorf("first session calls = %q, want %q", got, want)
+	}
+	if !c.IsConnected() {
+		t.Error("IsConnected = false after Connect")
+	}
+
+	// Live changes go to the current link.
+	c.Subscribe(ctx, "alerts.*")
+	c.Unsubscribe(ctx, "tasks.>")
+	c.Send(ctx, CommMessage{Content: "hi"})
+	if got, want := first.Calls(), "reg agent-1 billing; sub tasks.>; sub alerts.*; unsub tasks.>; send hi"; got != want {
+		t.Errorf("first session calls = %q, want %q", got, want)
+	}
+
+	// The link drops; the new session gets the current subscriptions.
+	c.drop <- errors.New("reset")
+	second := <-c.links
+	<-c.sessions
+	if got, want := second.Calls(), "reg agent-1 billing; sub alerts.*"; got != want {
+		t.Errorf("second session calls = %q, want %q", got, want)
+	}
+	if got := c.Topics(); !slices.Equal(got, []string{"alerts.*"}) {
+		t.Errorf("Topics = %v", got)
+	}
+
+	c.Deregister(ctx)
+	if c.AgentID() != "" || !strings.HasSuffix(second.Calls(), "dereg") {
+		t.Errorf("after Deregister: agent %q, calls %q", c.AgentID(), second.Calls())
+	}
+}
+
+func TestCommBaseFiltersByTopic(t *testing.T) {
+	c := newFlakyComm()
+	ctx := context.Background()
+	c.Subscribe(ctx, "tasks.*")
+	if err := c.Connect(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Disconnect(ctx)
+	<-c.links
+	s := <-c.sessions
+
+	rctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	in, _ := c.Receive(rctx)
+
+	go func() {
+		s.Deliver(CommMessage{Topic: "alerts.cpu", Content: "dropped"})
+		s.Deliver(CommMessage{Topic: "tasks.billing", Content: "subscribed"})
+		s.Deliver(CommMessage{To: "agent-1", Content: "direct"})
+	}()
+	for _, want := range []string{"subscribed", "direct"} {
+		select {
+		case msg := <-in:
+			if msg.Content != want {
+				t.Errorf("received %q, want %q", msg.Content, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("no message received")
+		}
+	}
+}
+
+func TestMatchTopic(t *testing.T) {
+	tests := []struct {
+		pattern, topic string
+		want           bool
+	}{
+		{"tasks", "tasks", true},
+		{"tasks", "tasks.billing", false},
+		{"tasks.*", "tasks.billing", true},
+		{"tasks.*", "tasks.billing.done", false},
+		{"tasks.*.done", "tasks.billing.done", true},
+		{"tasks.*.done", "tasks.billing.failed", false},
+		{"tasks.>", "tasks.billing.done", true},
+		{"tasks.>", "tasks", false},
+		{">", "anything.at.all", true},
+		{"tasks.>.done", "tasks.x.done", false},
+	}
+	for _, tt := range tests {
+		if got := MatchTopic(tt.pattern, tt.topic); got != tt.want {
+			t.Errorf("MatchTopic(%q, %q) = %v, want %v", tt.pattern, tt.topic, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package nebo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"maps"
+	"sync"
+	"time"
+)
+
+// Metadata keys used by the request/reply and task helpers.
+const (
+	MetaCorrelationID = "correlation_id" // Set on a request; echoed as MetaInReplyTo
+	MetaInReplyTo     = "in_reply_to"    // The correlation ID a reply answers
+	MetaTaskID        = "task_id"
+	MetaTaskState     = "task_state"
+	MetaTaskError     = "task_error" // Why a task failed
+)
+
+// DefaultRequestTimeout bounds CommBase.Request when ctx has no deadline.
+const DefaultRequestTimeout = 30 * time.Second
+
+// CorrelationID returns the correlation ID of a request, or "".
+func (m CommMessage) CorrelationID() string { return m.Metadata[MetaCorrelationID] }
+
+// InReplyTo returns the correlation ID this message answers, or "".
+func (m CommMessage) InReplyTo() string { return m.Metadata[MetaInReplyTo] }
+
+// withMeta returns m with its Metadata copied and kv set.
+func (m CommMessage) withMeta(kv ...string) CommMessage {
+	meta := maps.Clone(m.Metadata)
+	if meta == nil {
+		meta = make(map[string]string, len(kv)/2)
+	}
+	for i := 0; i+1 < len(kv); i += 2 {
+		meta[kv[i]] = kv[i+1]
+	}
+	m.Metadata = meta
+	return m
+}
+
+// NewReply returns a reply to req: addressed to its sender, in the same
+// conversation, with the same Type, and carrying req's correlation ID in
+// MetaInReplyTo.
+func NewReply(req CommMessage, content string) CommMessage {
+	reply := CommMessage{
+		To:             req.From,
+		ConversationID: req.ConversationID,
+		Type:           req.Type,
+		Content:        content,
+	}
+	if id := req.CorrelationID(); id != "" {
+		reply = reply.withMeta(MetaInReplyTo, id)
+	}
+	return reply
+}
+
+// Request sends msg with a new correlation ID and waits for the reply made
+// with NewReply, which is returned to the caller instead of being passed to
+// Receive. Without a deadline on ctx, it gives up after
+// DefaultRequestTimeout; a timeout returns CodeUnavailable wrapping
+// context.DeadlineExceeded.
+func (b *CommBase) Request(ctx context.Context, msg CommMessage) (CommMessage, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultRequestTimeout)
+		defer cancel()
+	}
+	id := newID()
+	msg = msg.withMeta(MetaCorrelationID, id)
+
+	reply := make(chan CommMessage, 1)
+	b.mu.Lock()
+	if b.replies == nil {
+		b.replies = make(map[string]chan CommMessage)
+	}
+	b.replies[id] = reply
+	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		delete(b.replies, id)
+		b.mu.Unlock()
+	}()
+
+	if err := b.Send(ctx, msg); err != nil {
+		return CommMessage{}, err
+	}
+	select {
+	case r := <-reply:
+		return r, nil
+	case <-ctx.Done():
+		return CommMessage{}, Errorf(CodeUnavailable, "no reply to comm request %s: %w", id, ctx.Err())
+	}
+}
+
+// answer hands msg to a waiting Request and reports whether one took it.
+func (b *CommBase) answer(msg CommMessage) bool {
+	id := msg.InReplyTo()
+	if id == "" {
+		return false
+	}
+	b.mu.Lock()
+	reply, ok := b.replies[id]
+	delete(b.replies, id)
+	b.mu.Unlock()
+	if ok {
+		reply <- msg
+	}
+	return ok
+}
+
+// TaskState is the lifecycle state of a delegated task.
+type TaskState string
+
+const (
+	TaskOffered    TaskState = "offered"     // Proposed to an agent, not yet taken
+	TaskAccepted   TaskState = "accepted"    // Taken on, not yet started
+	TaskInProgress TaskState = "in_progress" // Being worked on; may repeat with progress
+	TaskDone       TaskState = "done"
+	TaskFailed     TaskState = "failed" // Also used to decline an offer
+)
+
+// Terminal reports whether no further state can follow s.
+func (s TaskState) Terminal() bool { return s == TaskDone || s == TaskFailed }
+
+// CanBecome reports whether a task in state s may move to next.
+func (s TaskState) CanBecome(next TaskState) bool {
+	switch s {
+	case TaskOffered:
+		return next == TaskAccepted || next == TaskFailed
+	case TaskAccepted, TaskInProgress:
+		return next == TaskInProgress || next == TaskDone || next == TaskFailed
+	default:
+		return false
+	}
+}
+
+// Task is the task carried by a CommMessage's Metadata.
+type Task struct {
+	ID       string
+	State    TaskState
+	Assignee string // The agent that last updated the task, once accepted
+	Error    string // For TaskFailed
+}
+
+// TaskOf returns the task a message carries and reports whether it carries one.
+func TaskOf(m CommMessage) (Task, bool) {
+	id := m.Metadata[MetaTaskID]
+	if id == "" {
+		return Task{}, false
+	}
+	t := Task{ID: id, State: TaskState(m.Metadata[MetaTaskState]), Error: m.Metadata[MetaTaskError]}
+	if t.State != TaskOffered {
+		t.Assignee = m.From
+	}
+	return t, true
+}
+
+// OfferTask returns a "task" message offering a new task to the agent to.
+func OfferTask(to, content string) CommMessage {
+	m := CommMessage{To: to, Type: "task", Content: content}
+	return m.withMeta(MetaTaskID, newID(), MetaTaskState, string(TaskOffered))
+}
+
+// UpdateTask returns a message reporting that the task offered in offer moved
+// to state, addressed to the offer's sender. For TaskFailed, content is also
+// recorded as the task's error.
+func UpdateTask(offer CommMessage, state TaskState, content string) CommMessage {
+	t, _ := TaskOf(offer)
+	m := CommMessage{To: offer.From, ConversationID: offer.ConversationID, Type: "task", Content: content}
+	m = m.withMeta(MetaTaskID, t.ID, MetaTaskState, string(state))
+	if state == TaskFailed {
+		m = m.withMeta(MetaTaskError, content)
+	}
+	return m
+}
+
+// TaskTracker follows the state of delegated tasks from the messages an agent
+// sends and receives.
+//
+//	tasks := nebo.NewTaskTracker()
+//	offer := nebo.OfferTask("billing-agent", "Reconcile March invoices")
+//	tasks.Observe(offer)
+//	comm.Send(ctx, offer)
+//	// for each received message: tasks.Observe(msg)
+//	task, _ := nebo.TaskOf(offer)
+//	task, err := tasks.Wait(ctx, task.ID)
+type TaskTracker struct {
+	mu      sync.Mutex
+	tasks   map[string]Task
+	changed chan struct{} // closed and replaced on every update
+}
+
+// NewTaskTracker creates an empty TaskTracker.
+func NewTaskTracker() *TaskTracker {
+	return &TaskTracker{tasks: make(map[string]Task), changed: make(chan struct{})}
+}
+
+// Observe records the task state msg carries. Messages without a task are
+// ignored. A state that cannot follow the task's current one returns
+// CodeInvalidArgument and leaves the task unchanged; updates for unknown
+// tasks are recorded as they come.
+func (tr *TaskTracker) Observe(msg CommMessage) error {
+	t, ok := TaskOf(msg)
+	if !ok {
+		return nil
+	}
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	if cur, ok := tr.tasks[t.ID]; ok {
+		if !cur.State.CanBecome(t.State) {
+			return Errorf(CodeInvalidArgument, "task %s cannot go from %s to %s", t.ID, cur.State, t.State)
+		}
+		if t.Assignee == "" {
+			t.Assignee = cur.Assignee
+		}
+	}
+	tr.tasks[t.ID] = t
+	close(tr.changed)
+	tr.changed = make(chan struct{})
+	return nil
+}
+
+// Task returns a tracked task.
+func (tr *TaskTracker) Task(id string) (Task, bool) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	t, ok := tr.tasks[id]
+	return t, ok
+}
+
+// Wait blocks until task id is done or failed, or ctx is done.
+func (tr *TaskTracker) Wait(ctx context.Context, id string) (Task, error) {
+	for {
+		tr.mu.Lock()
+		t, ok := tr.tasks[id]
+		changed := tr.changed
+		tr.mu.Unlock()
+		if ok && t.State.Terminal() {
+			return t, nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return t, ctx.Err()
+		}
+	}
+}
+
+// newID returns a random 128-bit ID in hex, for attachment, correlation and
+// task IDs.
+func newID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// Only a broken system random source fails; a guessable ID is worse.
+		panic("nebo: reading random bytes: " + err.Error())
+	}
+	return hex.EncodeToString(b[:])
+}
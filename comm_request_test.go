@@ -0,0 +1,157 @@
+package nebo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCommBaseRequest(t *testing.T) {
+	c := newFlakyComm()
+	ctx := context.Background()
+	if err := c.Connect(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Disconnect(ctx)
+	link := <-c.links
+	s := <-c.sessions
+
+	rctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	in, _ := c.Receive(rctx)
+
+	type result struct {
+		msg CommMessage
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		msg, err := c.Request(ctx, CommMessage{To: "billing", From: "planner", Type: "command", Content: "total?"})
+		done <- result{msg, err}
+	}()
+
+	var req CommMessage
+	deadline := time.Now().Add(time.Second)
+	for req.CorrelationID() == "" && time.Now().Before(deadline) {
+		link.mu.Lock()
+		if len(link.sent) > 0 {
+			req = link.sent[0]
+		}
+		link.mu.Unlock()
+		time.Sleep(time.Millisecond)
+	}
+	if req.CorrelationID() == "" {
+		t.Fatalf("request = %+v, want a correlation ID", req)
+	}
+
+	// An unrelated message still goes to Receive; the reply goes to Request.
+	go func() {
+		s.Deliver(CommMessage{Content: "unrelated"})
+		s.Deliver(NewReply(req, "42"))
+	}()
+	if msg := <-in; msg.Content != "unrelated" {
+		t.Errorf("received %q, want unrelated", msg.Content)
+	}
+	r := <-done
+	if r.err != nil || r.msg.Content != "42" || r.msg.InReplyTo() != req.CorrelationID() {
+		t.Errorf("Request = %+v, %v", r.msg, r.err)
+	}
+
+	reply := NewReply(req, "")
+	if reply.To != "planner" || reply.Type != "command" {
+		t.Errorf("NewReply = %+v", reply)
+	}
+}
+
+func TestCommBaseRequestTimeout(t *testing.T) {
+	c := newFlakyComm()
+	ctx := context.Background()
+	if err := c.Connect(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Disconnect(ctx)
+	<-c.links
+	<-c.sessions
+
+	tctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err := c.Request(tctx, CommMessage{To: "nobody"})
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, &Error{Code: CodeUnavailable}) {
+		t.Errorf("Request = %v, want unavailable deadline exceeded", err)
+	}
+}
+
+func TestTaskLifecycle(t *testing.T) {
+	tasks := NewTaskTracker()
+	offer := OfferTask("worker", "reconcile invoices")
+	offer.From = "planner"
+	task, ok := TaskOf(offer)
+	if !ok || task.State != TaskOffered || offer.Type != "task" {
+		t.Fatalf("offer = %+v", offer)
+	}
+	if err := tasks.Observe(offer); err != nil {
+		t.Fatal(err)
+	}
+
+	waited := make(chan Task, 1)
+	go func() {
+		done, _ := tasks.Wait(context.Background(), task.ID)
+		waited <- done
+	}()
+
+	for _, state := range []TaskState{TaskAccepted, TaskInProgress, TaskInProgress} {
+		update := UpdateTask(offer, state, "")
+		update.From = "worker"
+		if update.To != "planner" {
+			t.Errorf("update To = %q, want planner", update.To)
+		}
+		if err := tasks.Observe(update); err != nil {
+			t.Errorf("%s: %v", state, err)
+		}
+	}
+	if got, _ := tasks.Task(task.ID); got.State != TaskInProgress || got.Assignee != "worker" {
+		t.Errorf("task = %+v", got)
+	}
+
+	failed := UpdateTask(offer, TaskFailed, "ledger locked")
+	failed.From = "worker"
+	tasks.Observe(failed)
+	select {
+	case done := <-waited:
+		if done.State != TaskFailed || done.Error != "ledger locked" {
+			t.Errorf("Wait = %+v", done)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Wait did not return")
+	}
+
+	// Terminal states accept nothing further.
+	if err := tasks.Observe(UpdateTask(offer, TaskDone, "")); !errors.Is(err, &Error{Code: CodeInvalidArgument}) {
+		t.Errorf("Observe after failure = %v, want invalid_argument", err)
+	}
+	if err := tasks.Observe(CommMessage{Content: "not a task"}); err != nil {
+		t.Errorf("Observe non-task = %v", err)
+	}
+}
+
+func TestTaskStateTransitions(t *testing.T) {
+	tests := []struct {
+		from, to TaskState
+		want     bool
+	}{
+		{TaskOffered, TaskAccepted, true},
+		{TaskOffered, TaskFailed, true},
+		{TaskOffered, TaskDone, false},
+		{TaskAccepted, TaskInProgress, true},
+		{TaskAccepted, TaskDone, true},
+		{TaskInProgress, TaskInProgress, true},
+		{TaskInProgress, TaskAccepted, false},
+		{TaskDone, TaskFailed, false},
+	}
+	for _, tt := range tests {
+		if got := tt.from.CanBecome(tt.to); got != tt.want {
+			t.Errorf("%s.CanBecome(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package nebo
+
+import (
+	"regexp"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+)
+
+// ConversationType says who a conversation is with.
+type ConversationType string
+
+const (
+	ConversationDirect  ConversationType = "direct"  // One-to-one with the bot
+	ConversationGroup   ConversationType = "group"   // Small group chat where everyone can post
+	ConversationChannel ConversationType = "channel" // Named channel in a workspace or server, or a broadcast channel
+)
+
+// Participant is a member of a conversation.
+type Participant struct {
+	UserID string
+	Name   string
+	IsBot  bool
+}
+
+// Mention is a reference to a user inside a message's Text. Offset and
+// Length count Unicode code points, not bytes.
+type Mention struct {
+	UserID string
+	Name   string
+	Offset int
+	Length int
+}
+
+// ConversationKey identifies the conversation env belongs to: its channel,
+// and its thread within the channel if it has one. It is stable across
+// messages, so it can key per-conversation state.
+func (env ChannelEnvelope) ConversationKey() string {
+	if env.ThreadID == "" {
+		return env.ChannelID
+	}
+	return env.ChannelID + "/" + env.ThreadID
+}
+
+// MentionsUser reports whether env mentions userID, such as the bot's own
+// platform user ID.
+func (env ChannelEnvelope) MentionsUser(userID string) bool {
+	for _, m := range env.Mentions {
+		if m.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func fromProtoMentions(mentions []*pb.Mention) []Mention {
+	var out []Mention
+	for _, m := range mentions {
+		out = append(out, Mention{UserID: m.UserId, Name: m.Name, Offset: int(m.Offset), Length: int(m.Length)})
+	}
+	return out
+}
+
+func toProtoMentions(mentions []Mention) []*pb.Mention {
+	var out []*pb.Mention
+	for _, m := range mentions {
+		out = append(out, &pb.Mention{UserId: m.UserID, Name: m.Name, Offset: int32(m.Offset), Length: int32(m.Length)})
+	}
+	return out
+}
+
+// TelegramConversation maps a Telegram chat type ("private", "group",
+// "supergroup", "channel") to a ConversationType. In forum supergroups, a
+// message's ThreadID is its message_thread_id.
+func TelegramConversation(chatType string) ConversationType {
+	switch chatType {
+	case "private":
+		return ConversationDirect
+	case "group", "supergroup":
+		return ConversationGroup
+	case "channel":
+		return ConversationChannel
+	}
+	return ""
+}
+
+// TelegramEntity is a Telegram Bot API MessageEntity, with the fields needed
+// for mentions. Messages can be unmarshalled into it directly.
+type TelegramEntity struct {
+	Type   string        `json:"type"`
+	Offset int           `json:"offset"`
+	Length int           `json:"length"`
+	User   *TelegramUser `json:"user,omitempty"`
+}
+
+// TelegramUser is the part of a Telegram Bot API User that mentions need.
+type TelegramUser struct {
+	ID        int64  `json:"id"`
+	FirstName string `json:"first_name"`
+	Username  string `json:"username"`
+}
+
+// TelegramMentions returns the "mention" (@username) and "text_mention"
+// entities of a Telegram message, converting Telegram's UTF-16 offsets. An
+// @username mention has no user ID, so its UserID is the username.
+func TelegramMentions(text string, entities []TelegramEntity) []Mention {
+	units := utf16.Encode([]rune(text))
+	var out []Mention
+	for _, e := range entities {
+		if e.Offset < 0 || e.Length <= 0 || e.Offset+e.Length > len(units) {
+			continue
+		}
+		m := Mention{
+			Offset: len(utf16.Decode(units[:e.Offset])),
+			Length: len(utf16.Decode(units[e.Offset : e.Offset+e.Length])),
+		}
+		name := string(utf16.Decode(units[e.Offset : e.Offset+e.Length]))
+		switch {
+		case e.Type == "mention":
+			m.UserID, m.Name = name[1:], name
+		case e.Type == "text_mention" && e.User != nil:
+			m.UserID, m.Name = strconv.FormatInt(e.User.ID, 10), name
+		default:
+			continue
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+// SlackConversation maps a Slack channel_type ("im", "mpim", "channel",
+// "group") to a ConversationType. Slack's "group" is a private channel. A
+// Slack message's ThreadID is its thread_ts.
+func SlackConversation(channelType string) ConversationType {
+	switch channelType {
+	case "im":
+		return ConversationDirect
+	case "mpim":
+		return ConversationGroup
+	case "channel", "group":
+		return ConversationChannel
+	}
+	return ""
+}
+
+var slackMention = regexp.MustCompile(`<@([UW][A-Z0-9]+)(?:\|([^>]*))?>`)
+
+// SlackMentions finds <@U123> and <@U123|name> user references in Slack text.
+func SlackMentions(text string) []Mention {
+	return findMentions(text, slackMention)
+}
+
+// DiscordConversation maps a Discord channel type to a ConversationType:
+// DMs are direct, group DMs are groups and guild channels, including threads
+// and forum posts, are channels.
+func DiscordConversation(channelType int) ConversationType {
+	switch channelType {
+	case 1: // DM
+		return ConversationDirect
+	case 3: // GROUP_DM
+		return ConversationGroup
+	case 0, 2, 5, 10, 11, 12, 13, 15, 16:
+		return ConversationChannel
+	}
+	return ""
+}
+
+// DiscordThread splits a Discord channel into ChannelID and ThreadID. Messages
+// in threads and forum posts (types 10, 11 and 12) arrive on the thread's own
+// channel, so the thread's parent becomes the channel and the thread its
+// ThreadID.
+func DiscordThread(channelID, parentID string, channelType int) (channel, thread string) {
+	switch channelType {
+	case 10, 11, 12:
+		if parentID != "" {
+			return parentID, channelID
+		}
+	}
+	return channelID, ""
+}
+
+var discordMention = regexp.MustCompile(`<@!?([0-9]+)>`)
+
+// DiscordMentions finds <@123> and <@!123> user references in Discord text.
+func DiscordMentions(text string) []Mention {
+	return findMentions(text, discordMention)
+}
+
+// findMentions returns a Mention for each match of re, whose first group is
+// the user ID and optional second group the name.
+func findMentions(text string, re *regexp.Regexp) []Mention {
+	var out []Mention
+	for _, loc := range re.FindAllStringSubmatchIndex(text, -1) {
+		m := Mention{
+			UserID: text[loc[2]:loc[3]],
+			Offset: utf8.RuneCountInString(text[:loc[0]]),
+			Length: utf8.RuneCountInString(text[loc[0]:loc[1]]),
+		}
+		if len(loc) > 4 && loc[4] >= 0 {
+			m.Name = text[loc[4]:loc[5]]
+		}
+		out = append(out, m)
+	}
+	return out
+}
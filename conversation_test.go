@@ -0,0 +1,98 @@
+package nebo
+
+import (
+	"testing"
+
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+)
+
+func TestTelegramMentions(t *testing.T) {
+	// 😀 is two UTF-16 units but one code point.
+	text := "😀 @alice and Bob"
+	got := TelegramMentions(text, []TelegramEntity{
+		{Type: "mention", Offset: 3, Length: 6},
+		{Type: "text_mention", Offset: 14, Length: 3, User: &TelegramUser{ID: 42, FirstName: "Bob"}},
+		{Type: "bold", Offset: 0, Length: 2},
+		{Type: "mention", Offset: 40, Length: 3},
+	})
+	want := []Mention{
+		{UserID: "alice", Name: "@alice", Offset: 2, Length: 6},
+		{UserID: "42", Name: "Bob", Offset: 13, Length: 3},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("mentions = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("mention %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSlackAndDiscordMentions(t *testing.T) {
+	slack := SlackMentions("héllo <@U123> and <@W9|bob>!")
+	if len(slack) != 2 || slack[0] != (Mention{UserID: "U123", Offset: 6, Length: 7}) ||
+		slack[1] != (Mention{UserID: "W9", Name: "bob", Offset: 18, Length: 9}) {
+		t.Errorf("SlackMentions = %+v", slack)
+	}
+	discord := DiscordMentions("<@!1> hi <@22> <#3>")
+	if len(discord) != 2 || discord[0].UserID != "1" || discord[1] != (Mention{UserID: "22", Offset: 9, Length: 5}) {
+		t.Errorf("DiscordMentions = %+v", discord)
+	}
+}
+
+func TestConversationTypes(t *testing.T) {
+	tests := []struct {
+		got, want ConversationType
+	}{
+		{TelegramConversation("private"), ConversationDirect},
+		{TelegramConversation("supergroup"), ConversationGroup},
+		{TelegramConversation("channel"), ConversationChannel},
+		{SlackConversation("im"), ConversationDirect},
+		{SlackConversation("mpim"), ConversationGroup},
+		{SlackConversation("group"), ConversationChannel},
+		{DiscordConversation(1), ConversationDirect},
+		{DiscordConversation(3), ConversationGroup},
+		{DiscordConversation(11), ConversationChannel},
+		{DiscordConversation(99), ""},
+	}
+	for i, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("case %d = %q, want %q", i, tt.got, tt.want)
+		}
+	}
+
+	if ch, th := DiscordThread("t1", "c1", 11); ch != "c1" || th != "t1" {
+		t.Errorf("DiscordThread(thread) = %s, %s", ch, th)
+	}
+	if ch, th := DiscordThread("c1", "cat", 0); ch != "c1" || th != "" {
+		t.Errorf("DiscordThread(text) = %s, %s", ch, th)
+	}
+}
+
+func TestConversationFields(t *testing.T) {
+	env := ChannelEnvelope{
+		ChannelID:        "c1",
+		ThreadID:         "t1",
+		ConversationType: ConversationGroup,
+		Participants:     []Participant{{UserID: "u1", Name: "Ann"}, {UserID: "b1", Name: "Nebo", IsBot: true}},
+		Mentions:         []Mention{{UserID: "b1", Name: "@nebo", Offset: 0, Length: 5}},
+	}
+	if env.ConversationKey() != "c1/t1" || (ChannelEnvelope{ChannelID: "c1"}).ConversationKey() != "c1" {
+		t.Errorf("ConversationKey = %q", env.ConversationKey())
+	}
+	if !env.MentionsUser("b1") || env.MentionsUser("u1") {
+		t.Error("MentionsUser wrong")
+	}
+
+	msg := toInboundMessage(env)
+	if msg.ThreadId != "t1" || msg.ConversationType != "group" || len(msg.Participants) != 2 ||
+		!msg.Participants[1].IsBot || msg.Mentions[0].UserId != "b1" || msg.Mentions[0].Length != 5 {
+		t.Errorf("inbound = %+v", msg)
+	}
+
+	out := fromSendRequest(&pb.ChannelSendRequest{ThreadId: "t2", Mentions: []*pb.Mention{{UserId: "u1", Offset: 3, Length: 4}}})
+	if out.ThreadID != "t2" || len(out.Mentions) != 1 || out.Mentions[0].Offset != 3 {
+		t.Errorf("outbound = %+v", out)
+	}
+}
@@ -0,0 +1,199 @@
+package nebo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+)
+
+// DeliveryTarget says where a task's output goes: a channel, a recipient, an
+// optional thread and format, and fallbacks tried in order if delivery fails.
+type DeliveryTarget = pb.DeliveryTarget
+
+// Delivery formats.
+const (
+	FormatText     = "text"
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// DeliveryBuilder constructs a DeliveryTarget.
+type DeliveryBuilder struct {
+	target *DeliveryTarget
+}
+
+// NewDelivery starts a DeliveryTarget for recipient to on channel.
+func NewDelivery(channel, to string) *DeliveryBuilder {
+	return &DeliveryBuilder{target: &DeliveryTarget{Channel: channel, To: to}}
+}
+
+// Thread delivers as a reply inside the given thread.
+func (d *DeliveryBuilder) Thread(threadID string) *DeliveryBuilder {
+	d.target.ThreadId = threadID
+	return d
+}
+
+// Format sets the message format: FormatText, FormatMarkdown or FormatHTML.
+func (d *DeliveryBuilder) Format(format string) *DeliveryBuilder {
+	d.target.Format = format
+	return d
+}
+
+// Fallback adds a target to try if delivery to this one fails.
+func (d *DeliveryBuilder) Fallback(target *DeliveryTarget) *DeliveryBuilder {
+	d.target.Fallbacks = append(d.target.Fallbacks, target)
+	return d
+}
+
+// Build validates and returns the target.
+func (d *DeliveryBuilder) Build() (*DeliveryTarget, error) {
+	if err := ValidateDeliveryTarget(d.target); err != nil {
+		return nil, err
+	}
+	return d.target, nil
+}
+
+// ValidateDeliveryTarget checks that t and its fallbacks have a channel and a
+// recipient and a known format. Errors have CodeInvalidArgument.
+func ValidateDeliveryTarget(t *DeliveryTarget) error {
+	if t == nil {
+		return NewError(CodeInvalidArgument, "delivery target is nil")
+	}
+	if t.Channel == "" {
+		return NewError(CodeInvalidArgument, "delivery target requires a channel")
+	}
+	if t.To == "" {
+		return Errorf(CodeInvalidArgument, "delivery target on %s requires a recipient", t.Channel)
+	}
+	switch t.Format {
+	case "", FormatText, FormatMarkdown, FormatHTML:
+	default:
+		return Errorf(CodeInvalidArgument, "delivery format must be text, markdown or html, got %q", t.Format)
+	}
+	for _, f := range t.Fallbacks {
+		if err := ValidateDeliveryTarget(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliverJSON is the legacy JSON form of a DeliveryTarget.
+type deliverJSON struct {
+	Channel   string        `json:"channel"`
+	To        string        `json:"to"`
+	ThreadID  string        `json:"thread_id,omitempty"`
+	Format    string        `json:"format,omitempty"`
+	Fallbacks []deliverJSON `json:"fallbacks,omitempty"`
+}
+
+func (d deliverJSON) target() *DeliveryTarget {
+	t := &DeliveryTarget{Channel: d.Channel, To: d.To, ThreadId: d.ThreadID, Format: d.Format}
+	for _, f := range d.Fallbacks {
+		t.Fallbacks = append(t.Fallbacks, f.target())
+	}
+	return t
+}
+
+func toDeliverJSON(t *DeliveryTarget) deliverJSON {
+	d := deliverJSON{Channel: t.Channel, To: t.To, ThreadID: t.ThreadId, Format: t.Format}
+	for _, f := range t.Fallbacks {
+		d.Fallbacks = append(d.Fallbacks, toDeliverJSON(f))
+	}
+	return d
+}
+
+// ParseDeliver parses the legacy deliver string: a JSON object such as
+// {"channel":"telegram","to":"123"} or an array of them. An empty string
+// yields no targets.
+func ParseDeliver(s string) ([]*DeliveryTarget, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	var list []deliverJSON
+	var err error
+	if strings.HasPrefix(s, "[") {
+		err = json.Unmarshal([]byte(s), &list)
+	} else {
+		var one deliverJSON
+		err = json.Unmarshal([]byte(s), &one)
+		list = []deliverJSON{one}
+	}
+	if err != nil {
+		return nil, Errorf(CodeInvalidArgument, "invalid deliver: %w", err)
+	}
+	targets := make([]*DeliveryTarget, len(list))
+	for i, d := range list {
+		targets[i] = d.target()
+		if err := ValidateDeliveryTarget(targets[i]); err != nil {
+			return nil, err
+		}
+	}
+	return targets, nil
+}
+
+// FormatDeliver encodes targets in the legacy deliver string form: the first
+// target as a JSON object. Hosts that predate the typed Delivery field
+// understand only that form, so they deliver to the first target rather than
+// nowhere; the full list travels in Delivery.
+func FormatDeliver(targets []*DeliveryTarget) string {
+	if len(targets) == 0 {
+		return ""
+	}
+	b, _ := json.Marshal(toDeliverJSON(targets[0]))
+	return string(b)
+}
+
+// TriggerDelivery returns where a fired schedule's output should go, preferring
+// the typed Delivery field and falling back to parsing Deliver.
+func TriggerDelivery(t *ScheduleTrigger) ([]*DeliveryTarget, error) {
+	if len(t.Delivery) > 0 {
+		return t.Delivery, nil
+	}
+	return ParseDeliver(t.Deliver)
+}
+
+// DeliverFunc sends a task's output to a single target.
+type DeliverFunc func(ctx context.Context, target *DeliveryTarget) error
+
+// FanOut delivers to every target concurrently. For each target, fallbacks are
+// tried in order until one succeeds. The returned error joins the failures of
+// targets where every attempt failed; it is nil if all targets were reached.
+func FanOut(ctx context.Context, targets []*DeliveryTarget, send DeliverFunc) error {
+	errs := make([]error, len(targets))
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = deliverWithFallbacks(ctx, t, send)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func deliverWithFallbacks(ctx context.Context, t *DeliveryTarget, send DeliverFunc) error {
+	err := send(ctx, t)
+	if err == nil {
+		return nil
+	}
+	err = fmt.Errorf("deliver to %s:%s: %w", t.Channel, t.To, err)
+	for _, f := range t.Fallbacks {
+		if ctx.Err() != nil {
+			break
+		}
+		ferr := deliverWithFallbacks(ctx, f, send)
+		if ferr == nil {
+			return nil
+		}
+		err = errors.Join(err, ferr)
+	}
+	return err
+}
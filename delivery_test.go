@@ -0,0 +1,119 @@
+package nebo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestParseDeliver(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string // channel:to
+	}{
+		{"", nil},
+		{`{"channel":"telegram","to":"123"}`, []string{"telegram:123"}},
+		{`[{"channel":"slack","to":"C1"},{"channel":"email","to":"a@b.c"}]`, []string{"slack:C1", "email:a@b.c"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseDeliver(tt.in)
+		if err != nil {
+			t.Errorf("ParseDeliver(%q): %v", tt.in, err)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("ParseDeliver(%q) = %d targets, want %d", tt.in, len(got), len(tt.want))
+			continue
+		}
+		for i, d := range got {
+			if d.Channel+":"+d.To != tt.want[i] {
+				t.Errorf("ParseDeliver(%q)[%d] = %s:%s, want %s", tt.in, i, d.Channel, d.To, tt.want[i])
+			}
+		}
+	}
+
+	for _, bad := range []string{"telegram:123", `{"channel":"telegram"}`, `{"to":"1"}`, `{"channel":"x","to":"1","format":"pdf"}`} {
+		if _, err := ParseDeliver(bad); !errors.Is(err, &Error{Code: CodeInvalidArgument}) {
+			t.Errorf("ParseDeliver(%q) err = %v, want invalid_argument", bad, err)
+		}
+	}
+}
+
+func TestFormatDeliverRoundTrip(t *testing.T) {
+	target, err := NewDelivery("telegram", "123").
+		Thread("42").
+		Format(FormatMarkdown).
+		Fallback(&DeliveryTarget{Channel: "email", To: "ops@example.com"}).
+		Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	s := FormatDeliver([]*DeliveryTarget{target})
+	want := `{"channel":"telegram","to":"123","thread_id":"42","format":"markdown","fallbacks":[{"channel":"email","to":"ops@example.com"}]}`
+	if s != want {
+		t.Errorf("FormatDeliver = %s, want %s", s, want)
+	}
+	back, err := ParseDeliver(s)
+	if err != nil || len(back) != 1 || back[0].ThreadId != "42" || back[0].Fallbacks[0].To != "ops@example.com" {
+		t.Errorf("round trip = %+v, %v", back, err)
+	}
+
+	// Older hosts read only a single object, so that is all that is written.
+	s = FormatDeliver([]*DeliveryTarget{{Channel: "slack", To: "C1"}, target})
+	if s != `{"channel":"slack","to":"C1"}` {
+		t.Errorf("FormatDeliver of two targets = %s, want the first as an object", s)
+	}
+
+	if _, err := NewDelivery("", "1").Build(); err == nil {
+		t.Error("Build without channel succeeded")
+	}
+}
+
+func TestTriggerDeliveryPrefersTyped(t *testing.T) {
+	trig := &ScheduleTrigger{
+		Deliver:  `{"channel":"legacy","to":"1"}`,
+		Delivery: []*DeliveryTarget{{Channel: "typed", To: "2"}},
+	}
+	got, _ := TriggerDelivery(trig)
+	if len(got) != 1 || got[0].Channel != "typed" {
+		t.Errorf("TriggerDelivery = %+v", got)
+	}
+	trig.Delivery = nil
+	got, _ = TriggerDelivery(trig)
+	if len(got) != 1 || got[0].Channel != "legacy" {
+		t.Errorf("TriggerDelivery legacy = %+v", got)
+	}
+}
+
+func TestFanOutFallbacks(t *testing.T) {
+	targets := []*DeliveryTarget{
+		{Channel: "telegram", To: "1", Fallbacks: []*DeliveryTarget{{Channel: "email", To: "a"}}},
+		{Channel: "slack", To: "C1"},
+		{Channel: "discord", To: "D1", Fallbacks: []*DeliveryTarget{{Channel: "sms", To: "5"}}},
+	}
+	down := map[string]bool{"telegram": true, "discord": true, "sms": true}
+
+	var mu sync.Mutex
+	var sent []string
+	err := FanOut(context.Background(), targets, func(_ context.Context, t *DeliveryTarget) error {
+		if down[t.Channel] {
+			return errors.New("unavailable")
+		}
+		mu.Lock()
+		sent = append(sent, t.Channel)
+		mu.Unlock()
+		return nil
+	})
+
+	if len(sent) != 2 {
+		t.Errorf("sent = %v, want email and slack", sent)
+	}
+	if err == nil {
+		t.Fatal("FanOut err = nil, want discord failure")
+	}
+	if msg := err.Error(); msg != "deliver to discord:D1: unavailable\ndeliver to sms:5: unavailable" {
+		t.Errorf("err = %q", msg)
+	}
+}
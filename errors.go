@@ -1,6 +1,14 @@
 package nebo
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
 
 var (
 	// ErrNoSockPath is returned when NEBO_APP_SOCK is not set.
@@ -9,3 +17,157 @@ var (
 	// ErrNoHandlers is returned when Run() is called with no registered handlers.
 	ErrNoHandlers = errors.New("no capability handlers registered — register at least one handler before calling Run()")
 )
+
+// ErrorCode classifies an Error so Nebo can decide how to react (retry, re-auth, give up).
+type ErrorCode string
+
+const (
+	CodeNotFound        ErrorCode = "not_found"
+	CodeInvalidArgument ErrorCode = "invalid_argument"
+	CodeUnauthenticated ErrorCode = "unauthenticated"
+	CodeRateLimited     ErrorCode = "rate_limited"
+	CodeUnavailable     ErrorCode = "unavailable"
+	CodeInternal        ErrorCode = "internal"
+	CodeUnimplemented   ErrorCode = "unimplemented"
+)
+
+// Error is a typed error that handlers can return to give Nebo more than a string.
+// Any handler method may return an *Error (or wrap one); the SDK maps it into the
+// response's ErrorResponse. Plain errors are reported with CodeInternal.
+//
+//	return "", nebo.Errorf(nebo.CodeNotFound, "chat %s not found", id)
+type Error struct {
+	Code       ErrorCode
+	Message    string
+	Retryable  bool
+	RetryAfter time.Duration     // Suggested delay before retrying (0 = caller decides)
+	Details    map[string]string // Free-form structured context
+	Err        error             // Underlying cause, if any
+}
+
+// NewError creates an Error with the given code and message.
+// Rate-limited and unavailable errors are marked retryable by default.
+func NewError(code ErrorCode, message string) *Error {
+	return &Error{
+		Code:      code,
+		Message:   message,
+		Retryable: code == CodeRateLimited || code == CodeUnavailable,
+	}
+}
+
+// Errorf creates an Error with a formatted message. A %w verb wraps the cause.
+func Errorf(code ErrorCode, format string, args ...any) *Error {
+	wrapped := fmt.Errorf(format, args...)
+	e := NewError(code, wrapped.Error())
+	// Keep the fmt error itself as the cause so that every %w stays reachable.
+	switch wrapped.(type) {
+	case interface{ Unwrap() error }, interface{ Unwrap() []error }:
+		e.Err = wrapped
+	}
+	return e
+}
+
+// WrapError wraps err with a code. The message is taken from err.
+func WrapError(code ErrorCode, err error) *Error {
+	e := NewError(code, err.Error())
+	e.Err = err
+	return e
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return string(e.Code)
+	}
+	return e.Message
+}
+
+// Unwrap returns the underlying cause.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is an *Error with the same code.
+// This lets callers match on a sentinel: errors.Is(err, &nebo.Error{Code: nebo.CodeNotFound}).
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return t.Code == e.Code
+}
+
+// WithRetryAfter marks the error retryable after d and returns it.
+func (e *Error) WithRetryAfter(d time.Duration) *Error {
+	e.Retryable = true
+	e.RetryAfter = d
+	return e
+}
+
+// WithDetail adds a key/value detail and returns the error.
+func (e *Error) WithDetail(key, value string) *Error {
+	if e.Details == nil {
+		e.Details = make(map[string]string)
+	}
+	e.Details[key] = value
+	return e
+}
+
+// GRPCStatus maps the error to a gRPC status. Streaming RPCs that return an
+// *Error surface the matching status code to Nebo.
+func (e *Error) GRPCStatus() *status.Status {
+	return status.New(e.Code.grpcCode(), e.Error())
+}
+
+func (c ErrorCode) grpcCode() codes.Code {
+	switch c {
+	case CodeNotFound:
+		return codes.NotFound
+	case CodeInvalidArgument:
+		return codes.InvalidArgument
+	case CodeUnauthenticated:
+		return codes.Unauthenticated
+	case CodeRateLimited:
+		return codes.ResourceExhausted
+	case CodeUnavailable:
+		return codes.Unavailable
+	case CodeUnimplemented:
+		return codes.Unimplemented
+	default:
+		return codes.Internal
+	}
+}
+
+// AsError converts any error to an *Error. Errors that are not (and do not wrap)
+// an *Error become CodeInternal. Returns nil for a nil error.
+func AsError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return WrapError(CodeInternal, err)
+}
+
+// IsRetryable reports whether err is an *Error marked retryable.
+func IsRetryable(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.Retryable
+}
+
+// toProtoError converts err into the wire ErrorResponse. Returns nil for a nil error.
+func toProtoError(err error) *pb.ErrorResponse {
+	if err == nil {
+		return nil
+	}
+	e := AsError(err)
+	return &pb.ErrorResponse{
+		Message:      err.Error(),
+		Code:         string(e.Code),
+		Retryable:    e.Retryable,
+		RetryAfterMs: e.RetryAfter.Milliseconds(),
+		Details:      e.Details,
+	}
+}
@@ -0,0 +1,130 @@
+package nebo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorAsThroughWrapping(t *testing.T) {
+	err := fmt.Errorf("send: %w", NewError(CodeNotFound, "chat 42 not found"))
+
+	var e *Error
+	if !errors.As(err, &e) {
+		t.Fatal("errors.As failed to find *Error")
+	}
+	if e.Code != CodeNotFound {
+		t.Errorf("Code = %q, want not_found", e.Code)
+	}
+	if !errors.Is(err, &Error{Code: CodeNotFound}) {
+		t.Error("errors.Is should match on code")
+	}
+	if errors.Is(err, &Error{Code: CodeInternal}) {
+		t.Error("errors.Is should not match a different code")
+	}
+}
+
+func TestErrorfWrapsCause(t *testing.T) {
+	e := Errorf(CodeUnavailable, "upstream: %w", io.ErrUnexpectedEOF)
+
+	if e.Message != "upstream: unexpected EOF" {
+		t.Errorf("Message = %q", e.Message)
+	}
+	if !errors.Is(e, io.ErrUnexpectedEOF) {
+		t.Error("expected cause to be unwrappable")
+	}
+	if !e.Retryable {
+		t.Error("unavailable errors should be retryable by default")
+	}
+
+	multi := Errorf(CodeInternal, "%w and %w", io.EOF, io.ErrClosedPipe)
+	if !errors.Is(multi, io.EOF) || !errors.Is(multi, io.ErrClosedPipe) {
+		t.Error("expected every %w cause to be unwrappable")
+	}
+	if Errorf(CodeInternal, "no cause").Err != nil {
+		t.Error("Err should be nil without %w")
+	}
+}
+
+func TestToProtoError(t *testing.T) {
+	if toProtoError(nil) != nil {
+		t.Error("nil error should map to nil")
+	}
+
+	plain := toProtoError(errors.New("boom"))
+	if plain.Code != "internal" || plain.Message != "boom" || plain.Retryable {
+		t.Errorf("plain error = %+v", plain)
+	}
+
+	rich := toProtoError(NewError(CodeRateLimited, "slow down").
+		WithRetryAfter(1500*time.Millisecond).
+		WithDetail("scope", "chat"))
+	if rich.Code != "rate_limited" || !rich.Retryable || rich.RetryAfterMs != 1500 {
+		t.Errorf("rich error = %+v", rich)
+	}
+	if rich.Details["scope"] != "chat" {
+		t.Errorf("Details = %v", rich.Details)
+	}
+}
+
+func TestErrorGRPCStatus(t *testing.T) {
+	err := fmt.Errorf("receive: %w", NewError(CodeUnauthenticated, "token expired"))
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatal("status.FromError failed")
+	}
+	if st.Code() != codes.Unauthenticated {
+		t.Errorf("code = %v, want Unauthenticated", st.Code())
+	}
+}
+
+type failingTool struct{ err error }
+
+func (f *failingTool) Name() string            { return "fail" }
+func (f *failingTool) Description() string     { return "" }
+func (f *failingTool) Schema() json.RawMessage { return nil }
+func (f *failingTool) Execute(context.Context, json.RawMessage) (string, error) {
+	return "", f.err
+}
+
+func TestToolBridgeErrorDetail(t *testing.T) {
+	b := &toolBridge{handler: &failingTool{err: NewError(CodeInvalidArgument, "missing field a")}, env: &AppEnv{}}
+
+	resp, err := b.Execute(context.Background(), &pb.ExecuteRequest{})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if !resp.IsError || resp.Content != "missing field a" {
+		t.Errorf("resp = %+v", resp)
+	}
+	if resp.ErrorDetail.GetCode() != "invalid_argument" {
+		t.Errorf("ErrorDetail.Code = %q, want invalid_argument", resp.ErrorDetail.GetCode())
+	}
+}
+
+type failingGateway struct{ err error }
+
+func (f *failingGateway) Stream(context.Context, *GatewayRequest) (<-chan GatewayEvent, error) {
+	return nil, f.err
+}
+func (f *failingGateway) Cancel(context.Context, string) error { return f.err }
+
+func TestGatewayBridgeCancelErrorDetail(t *testing.T) {
+	b := &gatewayBridge{handler: &failingGateway{err: NewError(CodeNotFound, "request r1 not found")}, env: &AppEnv{}}
+
+	resp, err := b.Cancel(context.Background(), &pb.CancelRequest{RequestId: "r1"})
+	if err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if resp.Cancelled || resp.Error != "request r1 not found" || resp.ErrorDetail.GetCode() != "not_found" {
+		t.Errorf("resp = %+v", resp)
+	}
+}
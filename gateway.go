@@ -119,7 +119,7 @@ func (b *gatewayBridge) Stream(req *pb.GatewayRequest, stream pb.GatewayService_
 
 func (b *gatewayBridge) Cancel(ctx context.Context, req *pb.CancelRequest) (*pb.CancelResponse, error) {
 	if err := b.handler.Cancel(ctx, req.RequestId); err != nil {
-		return &pb.CancelResponse{Cancelled: false}, nil
+		return &pb.CancelResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
 	}
 	return &pb.CancelResponse{Cancelled: true}, nil
 }
@@ -0,0 +1,323 @@
+package nebo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// defaultIdempotencyTTL is how long send results and inbound message IDs are
+// remembered when WithPersistentIdempotency is used without WithIdempotency.
+const defaultIdempotencyTTL = 24 * time.Hour
+
+// WithIdempotency remembers, for ttl, the platform IDs returned for each
+// outbound MessageID and the IDs of inbound messages. A Send that Nebo retries
+// with the same MessageID returns the original platform IDs instead of posting
+// again; a concurrent retry waits for the first attempt. Failed sends are not
+// remembered, so they can be retried. Inbound messages (Kind EventMessage)
+// whose ChannelID and MessageID were already delivered are dropped, which
+// absorbs platform redeliveries.
+func WithIdempotency(ttl time.Duration) ChannelRegisterOption {
+	return func(b *channelBridge) { b.idempotencyTTL = ttl }
+}
+
+// WithPersistentIdempotency keeps the idempotency cache in the app's DataDir so
+// retries are recognised across app restarts. It enables WithIdempotency with
+// a 24 hour TTL if no TTL was given.
+func WithPersistentIdempotency() ChannelRegisterOption {
+	return func(b *channelBridge) { b.persistIdempotency = true }
+}
+
+// openIdempotency creates a connection's idempotency cache, or returns nil
+// when idempotency is off.
+func (b *channelBridge) openIdempotency(connectionID string) *idempotencyCache {
+	ttl := b.idempotencyTTL
+	if ttl <= 0 {
+		if !b.persistIdempotency {
+			return nil
+		}
+		ttl = defaultIdempotencyTTL
+	}
+	if b.persistIdempotency && b.env.DataDir != "" {
+		c, err := openIdempotencyCache(b.dataPath("idempotency", connectionID), ttl)
+		if err == nil {
+			return c
+		}
+		fmt.Fprintf(os.Stderr, "[%s] idempotency cache: %v; falling back to memory\n", b.env.Name, err)
+	}
+	return newIdempotencyCache(ttl)
+}
+
+// sendKey is the idempotency key of an outbound message, or "" if it has no
+// MessageID.
+func sendKey(env ChannelEnvelope) string {
+	if env.MessageID == "" {
+		return ""
+	}
+	return "send:" + env.MessageID
+}
+
+// inboundKey is the idempotency key of an inbound message, or "" if it is not
+// a message or has no MessageID.
+func inboundKey(env ChannelEnvelope) string {
+	if env.MessageID == "" || (env.Kind != "" && env.Kind != EventMessage) {
+		return ""
+	}
+	return "in:" + env.ChannelID + "/" + env.MessageID
+}
+
+// idempotencyCache remembers keys, with the platform IDs they produced, for a
+// while. A nil cache remembers nothing.
+type idempotencyCache struct {
+	ttl time.Duration
+
+	mu       sync.Mutex
+	entries  map[string]idempotencyRecord
+	inflight map[string]chan struct{} // closed when the first attempt ends
+
+	path    string
+	log     *os.File // nil when not persisted
+	records int      // lines in log
+}
+
+// idempotencyRecord is a remembered key and one line of a persisted cache.
+type idempotencyRecord struct {
+	Key     string    `json:"key"`
+	IDs     []string  `json:"ids,omitempty"`
+	Expires time.Time `json:"expires"`
+}
+
+func newIdempotencyCache(ttl time.Duration) *idempotencyCache {
+	return &idempotencyCache{
+		ttl:      ttl,
+		entries:  make(map[string]idempotencyRecord),
+		inflight: make(map[string]chan struct{}),
+	}
+}
+
+// openIdempotencyCache loads a persisted cache from path, creating it if needed.
+func openIdempotencyCache(path string, ttl time.Duration) (*idempotencyCache, error) {
+	c := newIdempotencyCache(ttl)
+	c.path = path
+	if data, err := os.ReadFile(path); err == nil {
+		c.replay(data)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	f, err := os.OpenFile(path+".tmp", os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	c.log = f
+	if err := c.rewrite(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *idempotencyCache) replay(data []byte) {
+	now := time.Now()
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(nil, 1<<20)
+	for sc.Scan() {
+		var rec idempotencyRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			// A torn final line from a crash mid-write; nothing after it.
+			break
+		}
+		if now.Before(rec.Expires) {
+			c.entries[rec.Key] = rec
+		} else {
+			delete(c.entries, rec.Key) // expired or released
+		}
+	}
+}
+
+// rewrite writes the live entries to the freshly created tmp log and renames
+// it over path. The caller holds mu or has exclusive access.
+func (c *idempotencyCache) rewrite() error {
+	if err := c.log.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := c.log.Seek(0, 0); err != nil {
+		return err
+	}
+	c.records = 0
+	for _, rec := range c.entries {
+		if err := c.append(rec); err != nil {
+			return err
+		}
+	}
+	if err := c.log.Sync(); err != nil {
+		return err
+	}
+	return os.Rename(c.path+".tmp", c.path)
+}
+
+func (c *idempotencyCache) append(rec idempotencyRecord) error {
+	if c.log == nil {
+		return nil
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	c.records++
+	_, err = c.log.Write(append(line, '\n'))
+	return err
+}
+
+// prune drops expired entries once the cache has grown, and compacts the log
+// once most of its records are stale. The caller holds mu.
+func (c *idempotencyCache) prune() {
+	if len(c.entries) < 1024 && c.records < 1024 {
+		return
+	}
+	now := time.Now()
+	for k, rec := range c.entries {
+		if !now.Before(rec.Expires) {
+			delete(c.entries, k)
+		}
+	}
+	if c.log == nil || c.records < 1024 || c.records < 4*len(c.entries) {
+		return
+	}
+	f, err := os.OpenFile(c.path+".tmp", os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return
+	}
+	old := c.log
+	c.log = f
+	if err := c.rewrite(); err != nil {
+		f.Close()
+		c.log = old
+		return
+	}
+	old.Close()
+}
+
+// lookup returns the IDs remembered for key. The caller holds mu.
+func (c *idempotencyCache) lookup(key string) ([]string, bool) {
+	rec, ok := c.entries[key]
+	if !ok || !time.Now().Before(rec.Expires) {
+		return nil, false
+	}
+	return rec.IDs, true
+}
+
+// store remembers key with ids. The caller holds mu.
+func (c *idempotencyCache) store(key string, ids []string) {
+	c.prune()
+	rec := idempotencyRecord{Key: key, IDs: ids, Expires: time.Now().Add(c.ttl)}
+	c.entries[key] = rec
+	c.append(rec)
+}
+
+// has reports whether key is remembered.
+func (c *idempotencyCache) has(key string) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.lookup(key)
+	return ok
+}
+
+// put remembers key with ids.
+func (c *idempotencyCache) put(key string, ids []string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.store(key, ids)
+}
+
+// claim remembers key and reports whether it was new.
+func (c *idempotencyCache) claim(key string) bool {
+	if c == nil || key == "" {
+		return true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.lookup(key); ok {
+		return false
+	}
+	c.store(key, nil)
+	return true
+}
+
+// release forgets a key claimed for work that then failed, so a retry is
+// handled again.
+func (c *idempotencyCache) release(key string) {
+	if c == nil || key == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+	c.append(idempotencyRecord{Key: key})
+}
+
+// do returns the IDs remembered for key, or runs fn and remembers its IDs if
+// it succeeds. While fn runs, calls with the same key wait for it. An empty
+// key always runs fn.
+func (c *idempotencyCache) do(ctx context.Context, key string, fn func() ([]string, error)) ([]string, error) {
+	if c == nil || key == "" {
+		return fn()
+	}
+	for {
+		c.mu.Lock()
+		if ids, ok := c.lookup(key); ok {
+			c.mu.Unlock()
+			return ids, nil
+		}
+		wait, busy := c.inflight[key]
+		if !busy {
+			break // still locked
+		}
+		c.mu.Unlock()
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	done := make(chan struct{})
+	c.inflight[key] = done
+	c.mu.Unlock()
+
+	// Release waiters even if fn panics; they then run fn themselves.
+	defer func() {
+		c.mu.Lock()
+		delete(c.inflight, key)
+		close(done)
+		c.mu.Unlock()
+	}()
+	ids, err := fn()
+	if err == nil {
+		c.mu.Lock()
+		c.store(key, ids)
+		c.mu.Unlock()
+	}
+	return ids, err
+}
+
+func (c *idempotencyCache) close() error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.log == nil {
+		return nil
+	}
+	return c.log.Close()
+}
@@ -0,0 +1,156 @@
+package nebo
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+)
+
+func TestChannelBridgeIdempotentSend(t *testing.T) {
+	h := &limitedChannel{limit: 20, failAt: 1}
+	b := &channelBridge{handler: h, env: &AppEnv{}, idempotencyTTL: time.Minute}
+	ctx := context.Background()
+	req := &pb.ChannelSendRequest{ChannelId: "c", MessageId: "u1", Text: "first paragraph\n\nsecond paragraph"}
+
+	// A failed send is not remembered.
+	if resp, _ := b.Send(ctx, req); resp.Error == "" {
+		t.Fatalf("resp = %+v, want error", resp)
+	}
+	h.failAt = 0
+	resp, _ := b.Send(ctx, req)
+	if resp.Error != "" || strings.Join(resp.MessageIds, ",") != "id1,id2" {
+		t.Fatalf("resp = %+v", resp)
+	}
+
+	// Nebo retries after a timeout: the original IDs come back.
+	retry, _ := b.Send(ctx, req)
+	if strings.Join(retry.MessageIds, ",") != "id1,id2" || retry.MessageId != "id1" {
+		t.Errorf("retry = %+v, want id1,id2", retry)
+	}
+	if len(h.sent) != 2 {
+		t.Errorf("sent %d parts, want 2", len(h.sent))
+	}
+
+	// Messages without an ID are always sent.
+	b.Send(ctx, &pb.ChannelSendRequest{ChannelId: "c", Text: "x"})
+	b.Send(ctx, &pb.ChannelSendRequest{ChannelId: "c", Text: "x"})
+	if len(h.sent) != 4 {
+		t.Errorf("sent %d parts, want 4", len(h.sent))
+	}
+}
+
+// gatedChannel blocks Send until release is closed.
+type gatedChannel struct {
+	chanChannel
+	release chan struct{}
+	mu      sync.Mutex
+	calls   int
+}
+
+func (c *gatedChannel) Send(context.Context, ChannelEnvelope) (string, error) {
+	c.mu.Lock()
+	c.calls++
+	c.mu.Unlock()
+	<-c.release
+	return "p1", nil
+}
+
+func TestChannelBridgeIdempotentConcurrentSend(t *testing.T) {
+	h := &gatedChannel{release: make(chan struct{})}
+	b := &channelBridge{handler: h, env: &AppEnv{}, idempotencyTTL: time.Minute}
+	req := &pb.ChannelSendRequest{ChannelId: "c", MessageId: "u1", Text: "hi"}
+
+	resps := make(chan *pb.ChannelSendResponse, 2)
+	for range 2 {
+		go func() {
+			resp, _ := b.Send(context.Background(), req)
+			resps <- resp
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(h.release)
+	for range 2 {
+		if resp := <-resps; resp.MessageId != "p1" {
+			t.Errorf("resp = %+v, want p1", resp)
+		}
+	}
+	if h.calls != 1 {
+		t.Errorf("Send called %d times, want 1", h.calls)
+	}
+}
+
+func TestChannelBridgeDropsRedeliveredInbound(t *testing.T) {
+	h := &chanChannel{in: make(chan ChannelEnvelope)}
+	b := &channelBridge{handler: h, env: &AppEnv{}, idempotencyTTL: time.Minute}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := &fakeReceiveStream{ctx: ctx, sent: make(chan *pb.InboundMessage, 10)}
+	go b.Receive(&pb.ChannelReceiveRequest{}, s)
+
+	h.in <- ChannelEnvelope{ChannelID: "c", MessageID: "1", Text: "hi"}
+	h.in <- ChannelEnvelope{ChannelID: "c", MessageID: "1", Text: "hi"}
+	h.in <- ChannelEnvelope{ChannelID: "d", MessageID: "1", Text: "other chat"}
+	h.in <- ChannelEnvelope{ChannelID: "c", MessageID: "1", Kind: EventEdit, Text: "hi!"}
+
+	for _, want := range []string{"hi", "other chat", "hi!"} {
+		if got := receiveOne(t, s.sent); got.Text != want {
+			t.Errorf("received %q, want %q", got.Text, want)
+		}
+	}
+}
+
+func TestIdempotencyCachePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "idempotency.log")
+	c, err := openIdempotencyCache(path, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.put("send:u1", []string{"p1", "p2"})
+	c.claim("in:c/1")
+	c.close()
+
+	c, err = openIdempotencyCache(path, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.close()
+	ids, _ := c.do(context.Background(), "send:u1", func() ([]string, error) {
+		t.Error("send ran again after restart")
+		return nil, nil
+	})
+	if strings.Join(ids, ",") != "p1,p2" {
+		t.Errorf("ids = %v, want [p1 p2]", ids)
+	}
+	if c.claim("in:c/1") {
+		t.Error("inbound ID forgotten after restart")
+	}
+}
+
+func TestIdempotencyCacheExpires(t *testing.T) {
+	c := newIdempotencyCache(time.Millisecond)
+	c.put("k", nil)
+	time.Sleep(5 * time.Millisecond)
+	if c.has("k") {
+		t.Error("expired key still remembered")
+	}
+}
+
+func TestIdempotencyCacheDoPanics(t *testing.T) {
+	c := newIdempotencyCache(time.Minute)
+	func() {
+		defer func() { recover() }()
+		c.do(context.Background(), "k", func() ([]string, error) { panic("boom") })
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	ids, err := c.do(ctx, "k", func() ([]string, error) { return []string{"m1"}, nil })
+	if err != nil || len(ids) != 1 {
+		t.Errorf("do after panic = %v, %v; want [m1]", ids, err)
+	}
+}
@@ -0,0 +1,264 @@
+package nebo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// defaultInboundBuffer is how many unacknowledged inbound messages are kept
+// per channel before the oldest are dropped.
+const defaultInboundBuffer = 1000
+
+// ChannelRegisterOption configures how RegisterChannel bridges a ChannelHandler.
+type ChannelRegisterOption func(*channelBridge)
+
+// WithInboundBuffer sets how many unacknowledged inbound messages are kept
+// while Nebo is not attached. When full, the oldest are dropped. Defaults to 1000.
+func WithInboundBuffer(size int) ChannelRegisterOption {
+	return func(b *channelBridge) { b.bufferSize = size }
+}
+
+// WithPersistentInbound keeps buffered inbound messages in the app's DataDir
+// so they survive an app restart.
+func WithPersistentInbound() ChannelRegisterOption {
+	return func(b *channelBridge) { b.persist = true }
+}
+
+// inboundBuffer holds inbound messages until Nebo acknowledges them.
+// Messages are numbered in arrival order; the number is their delivery ID.
+type inboundBuffer struct {
+	mu      sync.Mutex
+	limit   int
+	seq     uint64
+	pending []*pb.InboundMessage
+	ready   chan struct{} // closed and replaced whenever pending grows
+
+	path    string
+	log     *os.File // nil when not persisted
+	records int      // lines in log
+}
+
+func newInboundBuffer(limit int) *inboundBuffer {
+	if limit <= 0 {
+		limit = defaultInboundBuffer
+	}
+	return &inboundBuffer{limit: limit, ready: make(chan struct{})}
+}
+
+// inboundRecord is one line of a persisted inbound buffer.
+type inboundRecord struct {
+	Op      string          `json:"op"` // "push" or "ack"
+	ID      string          `json:"id,omitempty"`
+	Message json.RawMessage `json:"message,omitempty"`
+}
+
+// openInboundBuffer loads a persisted buffer from path, creating it if needed.
+func openInboundBuffer(path string, limit int) (*inboundBuffer, error) {
+	b := newInboundBuffer(limit)
+	b.path = path
+	if data, err := os.ReadFile(path); err == nil {
+		if err := b.replay(data); err != nil {
+			return nil, fmt.Errorf("inbound buffer %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	f, err := os.OpenFile(path+".tmp", os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	b.log = f
+	if err := b.rewrite(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return b, nil
+}
+
+func (b *inboundBuffer) replay(data []byte) error {
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(nil, 64<<20)
+	for sc.Scan() {
+		var rec inboundRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			// A torn final line from a crash mid-write; nothing after it.
+			break
+		}
+		switch rec.Op {
+		case "push":
+			msg := &pb.InboundMessage{}
+			if err := protojson.Unmarshal(rec.Message, msg); err != nil {
+				return err
+			}
+			if n, err := strconv.ParseUint(msg.DeliveryId, 10, 64); err == nil && n > b.seq {
+				b.seq = n
+			}
+			b.pending = append(b.pending, msg)
+		case "ack":
+			b.remove(rec.ID)
+		}
+	}
+	if over := len(b.pending) - b.limit; over > 0 {
+		b.pending = b.pending[over:]
+	}
+	return sc.Err()
+}
+
+// rewrite writes the pending messages to the freshly created tmp log and
+// renames it over path. The caller holds mu or has exclusive access.
+func (b *inboundBuffer) rewrite() error {
+	if err := b.log.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := b.log.Seek(0, 0); err != nil {
+		return err
+	}
+	b.records = 0
+	for _, msg := range b.pending {
+		if err := b.append(inboundRecord{Op: "push", Message: mustProtoJSON(msg)}); err != nil {
+			return err
+		}
+	}
+	if err := b.log.Sync(); err != nil {
+		return err
+	}
+	return os.Rename(b.path+".tmp", b.path)
+}
+
+func (b *inboundBuffer) append(rec inboundRecord) error {
+	if b.log == nil {
+		return nil
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	b.records++
+	_, err = b.log.Write(append(line, '\n'))
+	return err
+}
+
+// write appends recs to the log and syncs it, so a record is on disk
+// before the operation that produced it returns.
+func (b *inboundBuffer) write(recs ...inboundRecord) error {
+	if b.log == nil || len(recs) == 0 {
+		return nil
+	}
+	for _, rec := range recs {
+		if err := b.append(rec); err != nil {
+			return err
+		}
+	}
+	return b.log.Sync()
+}
+
+// compact rewrites the log once most of its records are stale.
+func (b *inboundBuffer) compact() {
+	if b.log == nil || b.records < 1024 || b.records < 4*len(b.pending) {
+		return
+	}
+	f, err := os.OpenFile(b.path+".tmp", os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return
+	}
+	old := b.log
+	b.log = f
+	if err := b.rewrite(); err != nil {
+		f.Close()
+		b.log = old
+		return
+	}
+	old.Close()
+}
+
+func mustProtoJSON(msg *pb.InboundMessage) json.RawMessage {
+	data, _ := protojson.Marshal(msg)
+	return data
+}
+
+// push adds msg, assigning its delivery ID, and drops the oldest message if
+// the buffer is full. The message is kept in memory even if persisting it
+// fails; the error is returned so the caller can report it.
+func (b *inboundBuffer) push(msg *pb.InboundMessage) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.seq++
+	msg.DeliveryId = strconv.FormatUint(b.seq, 10)
+	var recs []inboundRecord
+	if len(b.pending) >= b.limit {
+		recs = append(recs, inboundRecord{Op: "ack", ID: b.pending[0].DeliveryId})
+		b.pending = b.pending[1:]
+	}
+	b.pending = append(b.pending, msg)
+	recs = append(recs, inboundRecord{Op: "push", Message: mustProtoJSON(msg)})
+	close(b.ready)
+	b.ready = make(chan struct{})
+	return b.write(recs...)
+}
+
+// next blocks until a message with a delivery ID above after is pending and
+// returns it, or returns nil when ctx is done.
+func (b *inboundBuffer) next(ctx context.Context, after uint64) *pb.InboundMessage {
+	for {
+		b.mu.Lock()
+		for _, msg := range b.pending {
+			if n, _ := strconv.ParseUint(msg.DeliveryId, 10, 64); n > after {
+				b.mu.Unlock()
+				return msg
+			}
+		}
+		ready := b.ready
+		b.mu.Unlock()
+
+		select {
+		case <-ready:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// ack removes acknowledged messages. Unknown IDs are ignored.
+func (b *inboundBuffer) ack(ids ...string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var recs []inboundRecord
+	for _, id := range ids {
+		if b.remove(id) {
+			recs = append(recs, inboundRecord{Op: "ack", ID: id})
+		}
+	}
+	if err := b.write(recs...); err != nil {
+		return err
+	}
+	b.compact()
+	return nil
+}
+
+func (b *inboundBuffer) remove(id string) bool {
+	for i, msg := range b.pending {
+		if msg.DeliveryId == id {
+			b.pending = append(b.pending[:i], b.pending[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (b *inboundBuffer) close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.log == nil {
+		return nil
+	}
+	return b.log.Close()
+}
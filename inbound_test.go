@@ -0,0 +1,181 @@
+package nebo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+	"google.golang.org/grpc"
+)
+
+// fakeReceiveStream records messages sent on a Receive stream.
+type fakeReceiveStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *pb.InboundMessage
+}
+
+func (s *fakeReceiveStream) Context() context.Context { return s.ctx }
+func (s *fakeReceiveStream) Send(m *pb.InboundMessage) error {
+	s.sent <- m
+	return nil
+}
+
+// chanChannel is a ChannelHandler whose inbound messages come from in.
+type chanChannel struct {
+	in chan ChannelEnvelope
+}
+
+func (c *chanChannel) ID() string                                       { return "chan" }
+func (c *chanChannel) Connect(context.Context, map[string]string) error { return nil }
+func (c *chanChannel) Disconnect(context.Context) error                 { return nil }
+func (c *chanChannel) Send(context.Context, ChannelEnvelope) (string, error) {
+	return "", nil
+}
+func (c *chanChannel) Receive(context.Context) (<-chan ChannelEnvelope, error) { return c.in, nil }
+
+func receiveOne(t *testing.T, sent <-chan *pb.InboundMessage) *pb.InboundMessage {
+	t.Helper()
+	select {
+	case m := <-sent:
+		return m
+	case <-time.After(time.Second):
+		t.Fatal("no message on stream")
+		return nil
+	}
+}
+
+func TestChannelBridgeRedeliversUnacked(t *testing.T) {
+	h := &chanChannel{in: make(chan ChannelEnvelope)}
+	b := &channelBridge{handler: h, env: &AppEnv{}}
+
+	// First stream: receive two messages, ack only the first, then break.
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	s1 := &fakeReceiveStream{ctx: ctx1, sent: make(chan *pb.InboundMessage, 10)}
+	done := make(chan error)
+	go func() { done <- b.Receive(&pb.ChannelReceiveRequest{Ack: true}, s1) }()
+
+	h.in <- ChannelEnvelope{Text: "one"}
+	h.in <- ChannelEnvelope{Text: "two"}
+	first := receiveOne(t, s1.sent)
+	receiveOne(t, s1.sent)
+	b.Ack(context.Background(), &pb.ChannelAckRequest{DeliveryIds: []string{first.DeliveryId}})
+	cancel1()
+	<-done
+
+	// Arrives while Nebo is detached.
+	h.in <- ChannelEnvelope{Text: "three"}
+
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+	s2 := &fakeReceiveStream{ctx: ctx2, sent: make(chan *pb.InboundMessage, 10)}
+	go b.Receive(&pb.ChannelReceiveRequest{Ack: true}, s2)
+
+	for _, want := range []string{"two", "three"} {
+		if got := receiveOne(t, s2.sent); got.Text != want {
+			t.Errorf("redelivered %q, want %q", got.Text, want)
+		}
+	}
+}
+
+func TestChannelBridgeWithoutAckDropsSent(t *testing.T) {
+	h := &chanChannel{in: make(chan ChannelEnvelope)}
+	b := &channelBridge{handler: h, env: &AppEnv{}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &fakeReceiveStream{ctx: ctx, sent: make(chan *pb.InboundMessage, 10)}
+	done := make(chan error)
+	go func() { done <- b.Receive(&pb.ChannelReceiveRequest{}, s) }()
+	h.in <- ChannelEnvelope{Text: "one"}
+	receiveOne(t, s.sent)
+	cancel()
+	<-done
+
+	c, _ := b.conn("", false)
+	c.inbound.mu.Lock()
+	defer c.inbound.mu.Unlock()
+	if len(c.inbound.pending) != 0 {
+		t.Errorf("pending = %d, want 0 for a stream without acks", len(c.inbound.pending))
+	}
+}
+
+func TestInboundBufferBounded(t *testing.T) {
+	buf := newInboundBuffer(2)
+	for _, text := range []string{"a", "b", "c"} {
+		buf.push(&pb.InboundMessage{Text: text})
+	}
+	ctx := context.Background()
+	if m := buf.next(ctx, 0); m.Text != "b" || m.DeliveryId != "2" {
+		t.Errorf("oldest = %s/%s, want b/2", m.Text, m.DeliveryId)
+	}
+}
+
+func TestInboundBufferPersisted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inbound.log")
+	buf, err := openInboundBuffer(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, text := range []string{"a", "b", "c"} {
+		buf.push(&pb.InboundMessage{Text: text})
+	}
+	buf.ack("1", "3")
+	buf.close()
+
+	buf, err = openInboundBuffer(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer buf.close()
+	if len(buf.pending) != 1 || buf.pending[0].Text != "b" {
+		t.Fatalf("reopened pending = %v", buf.pending)
+	}
+	buf.push(&pb.InboundMessage{Text: "d"})
+	if id := buf.pending[1].DeliveryId; id != "4" {
+		t.Errorf("delivery id after reopen = %s, want 4", id)
+	}
+}
+
+func TestInboundBufferWriteErrors(t *testing.T) {
+	buf, err := openInboundBuffer(filepath.Join(t.TempDir(), "inbound.log"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := buf.push(&pb.InboundMessage{Text: "a"}); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+	buf.close()
+	if err := buf.push(&pb.InboundMessage{Text: "b"}); err == nil {
+		t.Error("push after close succeeded, want error")
+	}
+	if err := buf.ack("1"); err == nil {
+		t.Error("ack after close succeeded, want error")
+	}
+	if len(buf.pending) != 1 || buf.pending[0].Text != "b" {
+		t.Errorf("pending = %v, want b kept in memory", buf.pending)
+	}
+}
+
+// escapingChannel has an ID that would leave DataDir if used as a path.
+type escapingChannel struct{ chanChannel }
+
+func (escapingChannel) ID() string { return "../../evil/chan" }
+
+func TestInboundBufferPathStaysInDataDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+	if err := os.Mkdir(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	b := &channelBridge{handler: &escapingChannel{}, env: &AppEnv{DataDir: dir}, persist: true}
+	c, err := b.conn("", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.inbound.close()
+	if got := filepath.Dir(c.inbound.path); got != dir {
+		t.Errorf("buffer in %s, want %s", got, dir)
+	}
+}
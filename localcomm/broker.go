@@ -0,0 +1,280 @@
+// Package localcomm provides a ready-made nebo.CommHandler backed by a local
+// broker, so agent collaboration can be developed and tested without a real
+// network.
+//
+// A Broker routes messages by topic, by agent ID and by capability. Agents in
+// one process join it directly; apps in separate processes join over a Unix
+// socket served with ListenAndServe:
+//
+//	broker := localcomm.NewBroker()
+//	go broker.ListenAndServe("/tmp/nebo-comm.sock")
+//
+//	app.RegisterComm(localcomm.New(nil)) // Connect config: {"socket": "/tmp/nebo-comm.sock"}
+package localcomm
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	nebo "github.com/neboloop/nebo-sdk-go"
+)
+
+// CapabilityPrefix addresses a message to every agent registered with a
+// capability: To "cap:billing" reaches all agents offering "billing".
+const CapabilityPrefix = "cap:"
+
+// connBuffer is how many undelivered messages a connection holds. Messages to
+// a full connection are refused.
+const connBuffer = 256
+
+// Agent is a registered agent.
+type Agent struct {
+	ID           string
+	Capabilities []string
+}
+
+// Broker routes CommMessages between the connections that joined it.
+type Broker struct {
+	mu     sync.Mutex
+	conns  map[*Conn]struct{}
+	closed bool
+}
+
+// NewBroker creates an empty Broker.
+func NewBroker() *Broker {
+	return &Broker{conns: make(map[*Conn]struct{})}
+}
+
+// Connect joins the broker in-process.
+func (b *Broker) Connect() *Conn {
+	c := &Conn{
+		broker: b,
+		in:     make(chan nebo.CommMessage, connBuffer),
+		done:   make(chan struct{}),
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		c.closeLocked(errBrokerClosed)
+		return c
+	}
+	b.conns[c] = struct{}{}
+	return c
+}
+
+// Agents returns the registered agents in ID order.
+func (b *Broker) Agents() []Agent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var agents []Agent
+	for c := range b.conns {
+		if c.agentID != "" {
+			agents = append(agents, Agent{ID: c.agentID, Capabilities: slices.Clone(c.capabilities)})
+		}
+	}
+	sort.Slice(agents, func(i, j int) bool { return agents[i].ID < agents[j].ID })
+	return agents
+}
+
+// Close disconnects every connection. Later Connects return closed connections.
+func (b *Broker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for c := range b.conns {
+		c.closeLocked(errBrokerClosed)
+	}
+	return nil
+}
+
+var errBrokerClosed = nebo.NewError(nebo.CodeUnavailable, "broker closed")
+
+// route delivers msg from sender. The caller holds mu.
+func (b *Broker) route(sender *Conn, msg nebo.CommMessage) error {
+	if msg.ID == "" {
+		msg.ID = newMessageID()
+	}
+	if msg.From == "" {
+		msg.From = sender.agentID
+	}
+	if msg.ConversationID == "" {
+		msg.ConversationID = msg.ID
+	}
+	if msg.Timestamp == 0 {
+		msg.Timestamp = time.Now().Unix()
+	}
+
+	switch {
+	case strings.HasPrefix(msg.To, CapabilityPrefix):
+		capability := strings.TrimPrefix(msg.To, CapabilityPrefix)
+		found := false
+		for c := range b.conns {
+			if c != sender && slices.Contains(c.capabilities, capability) {
+				found = true
+				c.push(msg)
+			}
+		}
+		if !found {
+			return nebo.Errorf(nebo.CodeNotFound, "no agent offers %q", capability)
+		}
+	case msg.To != "":
+		for c := range b.conns {
+			if c.agentID == msg.To {
+				if !c.push(msg) {
+					return nebo.Errorf(nebo.CodeUnavailable, "agent %q is not reading its messages", msg.To)
+				}
+				return nil
+			}
+		}
+		return nebo.Errorf(nebo.CodeNotFound, "agent %q not found", msg.To)
+	case msg.Topic != "":
+		for c := range b.conns {
+			if c != sender && c.subscribed(msg.Topic) {
+				c.push(msg)
+			}
+		}
+	default:
+		return nebo.NewError(nebo.CodeInvalidArgument, "message has neither To nor Topic")
+	}
+	return nil
+}
+
+func newMessageID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// Conn is an in-process connection to a Broker. It implements nebo.CommLink.
+type Conn struct {
+	broker *Broker
+	in     chan nebo.CommMessage
+	done   chan struct{}
+
+	// Guarded by broker.mu.
+	agentID      string
+	capabilities []string
+	topics       []string
+	err          error
+}
+
+var _ nebo.CommLink = (*Conn)(nil)
+
+// Send routes msg. To may be an agent ID or CapabilityPrefix plus a
+// capability; without To, msg goes to every other connection subscribed to
+// its Topic. Empty ID, From (the registered agent), ConversationID (the
+// message's ID) and Timestamp (Unix seconds) are filled in. Sending to an
+// unknown agent or capability returns CodeNotFound.
+func (c *Conn) Send(_ context.Context, msg nebo.CommMessage) error {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	return c.broker.route(c, msg)
+}
+
+// Subscribe receives messages on topics matching pattern; see nebo.MatchTopic.
+func (c *Conn) Subscribe(_ context.Context, pattern string) error {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+	if !slices.Contains(c.topics, pattern) {
+		c.topics = append(c.topics, pattern)
+	}
+	return c.err
+}
+
+// Unsubscribe stops receiving messages on pattern.
+func (c *Conn) Unsubscribe(_ context.Context, pattern string) error {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+	c.topics = slices.DeleteFunc(c.topics, func(t string) bool { return t == pattern })
+	return c.err
+}
+
+// Register names this connection's agent, which then receives messages sent
+// to its ID or capabilities. An ID registered by another connection returns
+// CodeInvalidArgument.
+func (c *Conn) Register(_ context.Context, agentID string, capabilities []string) error {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	for other := range c.broker.conns {
+		if other != c && other.agentID == agentID {
+			return nebo.Errorf(nebo.CodeInvalidArgument, "agent %q is already registered", agentID)
+		}
+	}
+	c.agentID, c.capabilities = agentID, slices.Clone(capabilities)
+	return nil
+}
+
+// Deregister removes this connection's agent.
+func (c *Conn) Deregister(context.Context) error {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+	c.agentID, c.capabilities = "", nil
+	return c.err
+}
+
+// Messages returns the messages routed to this connection.
+func (c *Conn) Messages() <-chan nebo.CommMessage { return c.in }
+
+// Done is closed when the connection is closed.
+func (c *Conn) Done() <-chan struct{} { return c.done }
+
+// Err returns why the connection closed, or nil if it is open or was closed
+// with Close.
+func (c *Conn) Err() error {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+	if c.err == errConnClosed {
+		return nil
+	}
+	return c.err
+}
+
+// Close leaves the broker.
+func (c *Conn) Close() error {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+	c.closeLocked(errConnClosed)
+	return nil
+}
+
+var errConnClosed = nebo.NewError(nebo.CodeUnavailable, "connection closed")
+
+func (c *Conn) closeLocked(err error) {
+	if c.err != nil {
+		return
+	}
+	c.err = err
+	delete(c.broker.conns, c)
+	close(c.done)
+}
+
+// push queues msg without blocking and reports whether there was room.
+func (c *Conn) push(msg nebo.CommMessage) bool {
+	select {
+	case c.in <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *Conn) subscribed(topic string) bool {
+	for _, pattern := range c.topics {
+		if nebo.MatchTopic(pattern, topic) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,277 @@
+package localcomm
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	nebo "github.com/neboloop/nebo-sdk-go"
+)
+
+func recv(t *testing.T, ch <-chan nebo.CommMessage) nebo.CommMessage {
+	t.Helper()
+	select {
+	case msg := <-ch:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("no message received")
+		return nebo.CommMessage{}
+	}
+}
+
+func expectNone(t *testing.T, ch <-chan nebo.CommMessage) {
+	t.Helper()
+	select {
+	case msg := <-ch:
+		t.Errorf("unexpected message %+v", msg)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+// testRouting exercises a pair of links joined to the same broker.
+func testRouting(t *testing.T, alice, bob link) {
+	ctx := context.Background()
+	if err := alice.Register(ctx, "alice", []string{"planning"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := bob.Register(ctx, "bob", []string{"billing", "search"}); err != nil {
+		t.Fatal(err)
+	}
+	bob.Subscribe(ctx, "tasks.*")
+
+	// Direct: From, ID and ConversationID are filled in.
+	if err := alice.Send(ctx, nebo.CommMessage{To: "bob", Content: "hi"}); err != nil {
+		t.Fatal(err)
+	}
+	msg := recv(t, bob.Messages())
+	if msg.From != "alice" || msg.Content != "hi" || msg.ID == "" || msg.ConversationID != msg.ID || msg.Timestamp == 0 {
+		t.Errorf("direct message = %+v", msg)
+	}
+
+	// Replies keep the conversation.
+	alice.Send(ctx, nebo.CommMessage{To: "bob", ConversationID: "conv-1", Content: "again"})
+	if msg := recv(t, bob.Messages()); msg.ConversationID != "conv-1" {
+		t.Errorf("ConversationID = %q, want conv-1", msg.ConversationID)
+	}
+
+	// Topics reach subscribers other than the sender.
+	alice.Send(ctx, nebo.CommMessage{Topic: "tasks.billing", Content: "topic"})
+	if msg := recv(t, bob.Messages()); msg.Content != "topic" {
+		t.Errorf("topic message = %+v", msg)
+	}
+	alice.Send(ctx, nebo.CommMessage{Topic: "alerts.cpu", Content: "unsubscribed"})
+	expectNone(t, bob.Messages())
+
+	// Capabilities.
+	alice.Send(ctx, nebo.CommMessage{To: CapabilityPrefix + "billing", Content: "cap"})
+	if msg := recv(t, bob.Messages()); msg.Content != "cap" {
+		t.Errorf("capability message = %+v", msg)
+	}
+
+	tests := []struct {
+		name string
+		msg  nebo.CommMessage
+		code nebo.ErrorCode
+	}{
+		{"unknown agent", nebo.CommMessage{To: "carol"}, nebo.CodeNotFound},
+		{"unknown capability", nebo.CommMessage{To: CapabilityPrefix + "legal"}, nebo.CodeNotFound},
+		{"no address", nebo.CommMessage{Content: "?"}, nebo.CodeInvalidArgument},
+	}
+	for _, tt := range tests {
+		if err := alice.Send(ctx, tt.msg); !errors.Is(err, &nebo.Error{Code: tt.code}) {
+			t.Errorf("%s: Send = %v, want %s", tt.name, err, tt.code)
+		}
+	}
+	if err := bob.Register(ctx, "alice", nil); !errors.Is(err, &nebo.Error{Code: nebo.CodeInvalidArgument}) {
+		t.Errorf("duplicate Register = %v, want invalid_argument", err)
+	}
+}
+
+func TestBrokerInProcess(t *testing.T) {
+	b := NewBroker()
+	defer b.Close()
+	alice, bob := b.Connect(), b.Connect()
+	testRouting(t, alice, bob)
+
+	agents := b.Agents()
+	if len(agents) != 2 || agents[0].ID != "alice" || agents[1].ID != "bob" {
+		t.Errorf("Agents = %+v", agents)
+	}
+	bob.Close()
+	if agents := b.Agents(); len(agents) != 1 {
+		t.Errorf("Agents after Close = %+v", agents)
+	}
+}
+
+func TestBrokerSocket(t *testing.T) {
+	b := NewBroker()
+	defer b.Close()
+	path := filepath.Join(t.TempDir(), "comm.sock")
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go b.Serve(l)
+
+	ctx := context.Background()
+	alice, err := Dial(ctx, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer alice.Close()
+	bob, err := Dial(ctx, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bob.Close()
+	testRouting(t, alice, bob)
+
+	// The broker going away ends the connection.
+	b.Close()
+	select {
+	case <-alice.Done():
+	case <-time.After(time.Second):
+		t.Fatal("client not closed after broker shutdown")
+	}
+	if alice.Err() == nil {
+		t.Error("Err = nil after broker shutdown")
+	}
+}
+
+func TestListenAndServeKeepsOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(file, []byte("keep"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewBroker().ListenAndServe(file); err == nil {
+		t.Error("ListenAndServe over a regular file succeeded")
+	}
+	if data, _ := os.ReadFile(file); string(data) != "keep" {
+		t.Errorf("regular file = %q, want it untouched", data)
+	}
+
+	live := filepath.Join(dir, "live.sock")
+	l, err := net.Listen("unix", live)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	if err := NewBroker().ListenAndServe(live); err == nil {
+		t.Error("ListenAndServe over a live socket succeeded")
+	}
+
+	stale := filepath.Join(dir, "stale.sock")
+	sl, err := net.Listen("unix", stale)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sl.(*net.UnixListener).SetUnlinkOnClose(false)
+	sl.Close()
+	if err := removeStaleSocket(stale); err != nil {
+		t.Errorf("removeStaleSocket: %v", err)
+	}
+	if _, err := os.Lstat(stale); !os.IsNotExist(err) {
+		t.Errorf("stale socket still present: %v", err)
+	}
+}
+
+func TestClientDropsWhenFull(t *testing.T) {
+	b := NewBroker()
+	defer b.Close()
+	path := filepath.Join(t.TempDir(), "comm.sock")
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go b.Serve(l)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	c, err := Dial(ctx, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if err := c.Subscribe(ctx, "news"); err != nil {
+		t.Fatal(err)
+	}
+	sender := b.Connect()
+	defer sender.Close()
+	for range connBuffer + 10 {
+		if err := sender.Send(ctx, nebo.CommMessage{Topic: "news"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Nothing reads Messages, yet replies still arrive.
+	deadline := time.Now().Add(2 * time.Second)
+	for len(c.Messages()) < connBuffer && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if err := c.Unsubscribe(ctx, "news"); err != nil {
+		t.Errorf("Unsubscribe with a full buffer: %v", err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	b := NewBroker()
+	defer b.Close()
+	path := filepath.Join(t.TempDir(), "comm.sock")
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go b.Serve(l)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// One agent in-process, one over the socket.
+	planner := New(b)
+	worker := New(nil)
+	worker.Subscribe(ctx, "tasks.>") // before connecting: replayed on Connect
+	if err := planner.Connect(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	defer planner.Disconnect(ctx)
+	if err := worker.Connect(ctx, map[string]string{"socket": path}); err != nil {
+		t.Fatal(err)
+	}
+	defer worker.Disconnect(ctx)
+	if err := worker.Register(ctx, "worker", []string{"billing"}); err != nil {
+		t.Fatal(err)
+	}
+
+	in, _ := worker.Receive(ctx)
+	planner.Send(ctx, nebo.CommMessage{Topic: "tasks.new", Content: "topic"})
+	planner.Send(ctx, nebo.CommMessage{To: "worker", Content: "direct"})
+	for _, want := range []string{"topic", "direct"} {
+		if msg := recv(t, in); msg.Content != want {
+			t.Errorf("received %q, want %q", msg.Content, want)
+		}
+	}
+
+	// Request/reply across the broker.
+	planner.Register(ctx, "planner", nil) // replies are addressed to the sender
+	go func() {
+		req := <-in
+		worker.Send(ctx, nebo.NewReply(req, "pong"))
+	}()
+	rctx, rcancel := context.WithTimeout(ctx, time.Second)
+	defer rcancel()
+	reply, err := planner.Request(rctx, nebo.CommMessage{To: "worker", Content: "ping"})
+	if err != nil || reply.Content != "pong" || reply.From != "worker" {
+		t.Errorf("Request = %+v, %v", reply, err)
+	}
+
+	if err := New(nil).Connect(ctx, nil); !errors.Is(err, &nebo.Error{Code: nebo.CodeInvalidArgument}) {
+		t.Errorf("Connect without broker = %v, want invalid_argument", err)
+	}
+}
@@ -0,0 +1,72 @@
+package localcomm
+
+import (
+	"context"
+
+	nebo "github.com/neboloop/nebo-sdk-go"
+)
+
+// link is what Handler needs from a Conn or Client.
+type link interface {
+	nebo.CommLink
+	Messages() <-chan nebo.CommMessage
+	Done() <-chan struct{}
+	Err() error
+	Close() error
+}
+
+// Handler is a nebo.CommHandler that joins a local Broker. If the Connect
+// config has a "socket" entry it dials the broker at that path; otherwise it
+// joins the in-process broker given to New. Subscriptions and registration
+// survive broker restarts; see nebo.CommBase.
+type Handler struct {
+	*nebo.CommBase
+	broker *Broker
+}
+
+var _ nebo.CommHandler = (*Handler)(nil)
+
+// New creates a Handler for broker, which may be nil when the config names a
+// socket. opts tune reconnection as for nebo.ChannelBase.
+func New(broker *Broker, opts ...nebo.ChannelOption) *Handler {
+	h := &Handler{broker: broker}
+	h.CommBase = nebo.NewCommBase(h.run, opts...)
+	return h
+}
+
+// Name returns "local".
+func (h *Handler) Name() string { return "local" }
+
+// Version returns the handler's version.
+func (h *Handler) Version() string { return "1.0.0" }
+
+func (h *Handler) open(ctx context.Context, config map[string]string) (link, error) {
+	if path := config["socket"]; path != "" {
+		return Dial(ctx, path)
+	}
+	if h.broker == nil {
+		return nil, nebo.NewError(nebo.CodeInvalidArgument, `no broker: set "socket" in the config or pass a Broker to New`)
+	}
+	return h.broker.Connect(), nil
+}
+
+func (h *Handler) run(ctx context.Context, config map[string]string, s *nebo.CommSession) error {
+	l, err := h.open(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+	if err := s.Connected(ctx, l); err != nil {
+		return err
+	}
+	for {
+		select {
+		case msg := <-l.Messages():
+			s.Deliver(msg)
+		case <-l.Done():
+			return l.Err()
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,317 @@
+package localcomm
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+
+	nebo "github.com/neboloop/nebo-sdk-go"
+)
+
+// frame is one line of the socket protocol. Clients send requests with a
+// sequence number and get a "reply" with the same number; the broker pushes
+// routed messages as "message" frames.
+type frame struct {
+	Op           string            `json:"op"` // "send", "subscribe", "unsubscribe", "register", "deregister", "reply", "message"
+	Seq          uint64            `json:"seq,omitempty"`
+	Topic        string            `json:"topic,omitempty"`
+	AgentID      string            `json:"agent_id,omitempty"`
+	Capabilities []string          `json:"capabilities,omitempty"`
+	Message      *nebo.CommMessage `json:"message,omitempty"`
+	Error        string            `json:"error,omitempty"`
+	Code         nebo.ErrorCode    `json:"code,omitempty"`
+}
+
+// ListenAndServe serves the broker on a Unix socket at path, removing a stale
+// socket file first. It fails if path is some other file or another broker is
+// listening on it, and otherwise returns when the listener fails.
+func (b *Broker) ListenAndServe(path string) error {
+	if err := removeStaleSocket(path); err != nil {
+		return err
+	}
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", path, err)
+	}
+	return b.Serve(l)
+}
+
+// removeStaleSocket removes path if it is a socket nobody is listening on.
+func removeStaleSocket(path string) error {
+	fi, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fi.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("listen on %s: file exists and is not a socket", path)
+	}
+	if nc, err := net.Dial("unix", path); err == nil {
+		nc.Close()
+		return fmt.Errorf("listen on %s: already in use", path)
+	}
+	return os.Remove(path)
+}
+
+// Serve accepts connections on l until it is closed.
+func (b *Broker) Serve(l net.Listener) error {
+	for {
+		nc, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go b.serveConn(nc)
+	}
+}
+
+// serveConn proxies one socket client onto an in-process Conn.
+func (b *Broker) serveConn(nc net.Conn) {
+	c := b.Connect()
+	defer c.Close()
+	defer nc.Close()
+
+	var wmu sync.Mutex
+	enc := json.NewEncoder(nc)
+	write := func(f frame) error {
+		wmu.Lock()
+		defer wmu.Unlock()
+		return enc.Encode(f)
+	}
+
+	go func() {
+		for {
+			select {
+			case msg := <-c.Messages():
+				if err := write(frame{Op: "message", Message: &msg}); err != nil {
+					nc.Close()
+					return
+				}
+			case <-c.Done():
+				nc.Close()
+				return
+			}
+		}
+	}()
+
+	ctx := context.Background()
+	dec := json.NewDecoder(bufio.NewReader(nc))
+	for {
+		var f frame
+		if err := dec.Decode(&f); err != nil {
+			return
+		}
+		var err error
+		switch f.Op {
+		case "send":
+			if f.Message == nil {
+				err = nebo.NewError(nebo.CodeInvalidArgument, "send without a message")
+				break
+			}
+			err = c.Send(ctx, *f.Message)
+		case "subscribe":
+			err = c.Subscribe(ctx, f.Topic)
+		case "unsubscribe":
+			err = c.Unsubscribe(ctx, f.Topic)
+		case "register":
+			err = c.Register(ctx, f.AgentID, f.Capabilities)
+		case "deregister":
+			err = c.Deregister(ctx)
+		default:
+			err = nebo.Errorf(nebo.CodeInvalidArgument, "unknown op %q", f.Op)
+		}
+		reply := frame{Op: "reply", Seq: f.Seq}
+		if err != nil {
+			reply.Error = err.Error()
+			reply.Code = nebo.CodeInternal
+			var e *nebo.Error
+			if errors.As(err, &e) {
+				reply.Code = e.Code
+			}
+		}
+		if write(reply) != nil {
+			return
+		}
+	}
+}
+
+// Client is a connection to a Broker over its Unix socket. It implements
+// nebo.CommLink with the same semantics as Conn.
+type Client struct {
+	nc  net.Conn
+	wmu sync.Mutex
+	enc *json.Encoder
+	in  chan nebo.CommMessage
+
+	mu      sync.Mutex
+	seq     uint64
+	pending map[uint64]chan frame
+	done    chan struct{}
+	err     error
+}
+
+var _ nebo.CommLink = (*Client)(nil)
+
+// Dial connects to a broker served on the Unix socket at path.
+func Dial(ctx context.Context, path string) (*Client, error) {
+	var d net.Dialer
+	nc, err := d.DialContext(ctx, "unix", path)
+	if err != nil {
+		return nil, nebo.Errorf(nebo.CodeUnavailable, "dial comm broker: %w", err)
+	}
+	c := &Client{
+		nc:      nc,
+		enc:     json.NewEncoder(nc),
+		in:      make(chan nebo.CommMessage, connBuffer),
+		pending: make(map[uint64]chan frame),
+		done:    make(chan struct{}),
+	}
+	go c.read()
+	return c, nil
+}
+
+func (c *Client) read() {
+	dec := json.NewDecoder(bufio.NewReader(c.nc))
+	for {
+		var f frame
+		if err := dec.Decode(&f); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) {
+				err = nil
+			}
+			c.close(err)
+			return
+		}
+		switch f.Op {
+		case "message":
+			// Like Conn, drop messages when the reader falls behind rather
+			// than stall the replies queued behind them.
+			if f.Message != nil {
+				select {
+				case c.in <- *f.Message:
+				default:
+				}
+			}
+		case "reply":
+			c.mu.Lock()
+			reply := c.pending[f.Seq]
+			delete(c.pending, f.Seq)
+			c.mu.Unlock()
+			if reply != nil {
+				reply <- f
+			}
+		}
+	}
+}
+
+// call sends a request and waits for its reply.
+func (c *Client) call(ctx context.Context, f frame) error {
+	reply := make(chan frame, 1)
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return c.err
+	}
+	c.seq++
+	f.Seq = c.seq
+	c.pending[f.Seq] = reply
+	c.mu.Unlock()
+
+	c.wmu.Lock()
+	err := c.enc.Encode(f)
+	c.wmu.Unlock()
+	if err != nil {
+		c.close(err)
+		return nebo.WrapError(nebo.CodeUnavailable, err)
+	}
+
+	select {
+	case r := <-reply:
+		if r.Error != "" {
+			return nebo.NewError(r.Code, r.Error)
+		}
+		return nil
+	case <-c.done:
+		return c.closedErr()
+	case <-ctx.Done():
+		c.mu.Lock()
+		delete(c.pending, f.Seq)
+		c.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Send routes msg through the broker; see Conn.Send.
+func (c *Client) Send(ctx context.Context, msg nebo.CommMessage) error {
+	return c.call(ctx, frame{Op: "send", Message: &msg})
+}
+
+// Subscribe receives messages on topics matching pattern.
+func (c *Client) Subscribe(ctx context.Context, pattern string) error {
+	return c.call(ctx, frame{Op: "subscribe", Topic: pattern})
+}
+
+// Unsubscribe stops receiving messages on pattern.
+func (c *Client) Unsubscribe(ctx context.Context, pattern string) error {
+	return c.call(ctx, frame{Op: "unsubscribe", Topic: pattern})
+}
+
+// Register names this connection's agent; see Conn.Register.
+func (c *Client) Register(ctx context.Context, agentID string, capabilities []string) error {
+	return c.call(ctx, frame{Op: "register", AgentID: agentID, Capabilities: capabilities})
+}
+
+// Deregister removes this connection's agent.
+func (c *Client) Deregister(ctx context.Context) error {
+	return c.call(ctx, frame{Op: "deregister"})
+}
+
+// Messages returns the messages routed to this connection. Messages that
+// arrive while it is full are dropped.
+func (c *Client) Messages() <-chan nebo.CommMessage { return c.in }
+
+// Done is closed when the connection is closed or drops.
+func (c *Client) Done() <-chan struct{} { return c.done }
+
+// Err returns why the connection dropped, or nil if it is open or was closed
+// with Close.
+func (c *Client) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err == errConnClosed {
+		return nil
+	}
+	return c.err
+}
+
+// Close disconnects from the broker.
+func (c *Client) Close() error {
+	c.close(errConnClosed)
+	return nil
+}
+
+func (c *Client) close(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return
+	}
+	if err == nil {
+		err = errBrokerClosed
+	}
+	c.err = err
+	close(c.done)
+	c.nc.Close()
+}
+
+func (c *Client) closedErr() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
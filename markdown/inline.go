@@ -0,0 +1,211 @@
+package markdown
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// parseInline parses the spans in one line of text.
+func parseInline(s string) []*Node {
+	var out []*Node
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			out = append(out, &Node{Kind: Text, Text: text.String()})
+			text.Reset()
+		}
+	}
+	emit := func(n *Node) {
+		flush()
+		out = append(out, n)
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && isPunct(s[i+1]):
+			text.WriteByte(s[i+1])
+			i += 2
+			continue
+		case c == '`':
+			if n, end, ok := parseCode(s, i); ok {
+				emit(n)
+				i = end
+				continue
+			}
+		case c == '[':
+			if n, end, ok := parseLink(s, i); ok {
+				emit(n)
+				i = end
+				continue
+			}
+		case c == '<':
+			if end := strings.IndexByte(s[i:], '>'); end > 0 {
+				url := s[i+1 : i+end]
+				if isURL(url) {
+					emit(&Node{Kind: Link, URL: url, Children: []*Node{{Kind: Text, Text: url}}})
+					i += end + 1
+					continue
+				}
+			}
+		case c == '*' || c == '_' || c == '~':
+			if n, end, ok := parseDelimited(s, i); ok {
+				emit(n)
+				i = end
+				continue
+			}
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		text.WriteString(s[i : i+size])
+		i += size
+	}
+	flush()
+	return out
+}
+
+func isPunct(c byte) bool {
+	return c < utf8.RuneSelf && unicode.IsPunct(rune(c)) || strings.IndexByte("$+<=>^`|~", c) >= 0
+}
+
+func isURL(s string) bool {
+	return (strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "mailto:")) &&
+		!strings.ContainsAny(s, " <>")
+}
+
+// parseCode parses a code span opened by the backticks at s[i].
+func parseCode(s string, i int) (*Node, int, bool) {
+	n := 0
+	for i+n < len(s) && s[i+n] == '`' {
+		n++
+	}
+	ticks := s[i : i+n]
+	for j := i + n; j < len(s); {
+		k := strings.Index(s[j:], ticks)
+		if k < 0 {
+			break
+		}
+		k += j
+		m := 0
+		for k+m < len(s) && s[k+m] == '`' {
+			m++
+		}
+		if m != n {
+			j = k + m
+			continue
+		}
+		code := s[i+n : k]
+		if len(code) >= 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+			code = code[1 : len(code)-1]
+		}
+		return &Node{Kind: Code, Text: code}, k + n, true
+	}
+	return nil, 0, false
+}
+
+// parseLink parses [text](url) starting at s[i].
+func parseLink(s string, i int) (*Node, int, bool) {
+	depth := 0
+	close := -1
+	for j := i; j < len(s) && close < 0; j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '`':
+			if _, end, ok := parseCode(s, j); ok {
+				j = end - 1
+			}
+		case '[':
+			depth++
+		case ']':
+			if depth--; depth == 0 {
+				close = j
+			}
+		}
+	}
+	if close < 0 || close+1 >= len(s) || s[close+1] != '(' {
+		return nil, 0, false
+	}
+	depth = 0
+	for j := close + 1; j < len(s); j++ {
+		switch s[j] {
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				url := strings.TrimSpace(s[close+2 : j])
+				if url == "" || strings.ContainsRune(url, ' ') {
+					return nil, 0, false
+				}
+				return &Node{Kind: Link, URL: url, Children: parseInline(s[i+1 : close])}, j + 1, true
+			}
+		case ' ':
+			return nil, 0, false
+		}
+	}
+	return nil, 0, false
+}
+
+// parseDelimited parses strong (** or __), emphasis (* or _) or strikethrough
+// (~~) opened at s[i].
+func parseDelimited(s string, i int) (*Node, int, bool) {
+	c := s[i]
+	width, kind := 1, Emphasis
+	if i+1 < len(s) && s[i+1] == c {
+		width, kind = 2, Strong
+	}
+	if c == '~' {
+		if width != 2 {
+			return nil, 0, false
+		}
+		kind = Strike
+	}
+	delim := s[i : i+width]
+	start := i + width
+	if start >= len(s) || s[start] == ' ' {
+		return nil, 0, false
+	}
+	// An underscore inside a word, as in snake_case, is not a delimiter.
+	if c == '_' && i > 0 && isWordByte(s[i-1]) {
+		return nil, 0, false
+	}
+	for j := start; j < len(s); j++ {
+		switch {
+		case s[j] == '\\':
+			j++
+			continue
+		case s[j] == '`':
+			if _, end, ok := parseCode(s, j); ok {
+				j = end - 1
+			}
+			continue
+		case !strings.HasPrefix(s[j:], delim):
+			continue
+		}
+		run := 0
+		for j+run < len(s) && s[j+run] == c {
+			run++
+		}
+		// A single delimiter skips over doubled ones, which belong to a
+		// nested span.
+		if width == 1 && run == 2 {
+			j++
+			continue
+		}
+		if j == start || s[j-1] == ' ' {
+			j += run - 1
+			continue
+		}
+		// In a longer run such as ***, the span closes at its end.
+		j += run - width
+		if c == '_' && j+width < len(s) && isWordByte(s[j+width]) {
+			continue
+		}
+		return &Node{Kind: kind, Children: parseInline(s[start:j])}, j + width, true
+	}
+	return nil, 0, false
+}
+
+func isWordByte(c byte) bool {
+	return c >= utf8.RuneSelf || c == '_' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
@@ -0,0 +1,256 @@
+// Package markdown converts the Markdown that Nebo agents write into the rich
+// text dialect of a chat platform.
+//
+// Parse reads the subset of Markdown that renders sensibly in chat: headings,
+// paragraphs, fenced code, quotes, lists and rules, with strong, emphasis,
+// strikethrough, inline code and links inside them. Render writes the tree for
+// a Dialect with that platform's escaping, and Split does the same while
+// keeping every part under a length limit:
+//
+//	parts := markdown.Split(markdown.Parse(reply), markdown.Telegram, 4096)
+package markdown
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Kind is the type of a Node.
+type Kind int
+
+const (
+	Document  Kind = iota // Children are blocks
+	Paragraph             // Children are inline nodes
+	Heading               // Level is 1-6; Children are inline nodes
+	CodeBlock             // Text is the code; Lang is the info string
+	Quote                 // Children are blocks
+	List                  // Ordered and Start; Children are Items
+	Item                  // Level is the nesting depth; Children are inline nodes
+	Rule                  // Horizontal rule
+
+	Text      // Text
+	Strong    // Children are inline nodes
+	Emphasis  // Children are inline nodes
+	Strike    // Children are inline nodes
+	Code      // Text
+	Link      // URL; Children are inline nodes
+	LineBreak // A newline inside a paragraph, heading or item
+)
+
+// Node is an element of a parsed document.
+type Node struct {
+	Kind     Kind
+	Text     string
+	URL      string
+	Lang     string
+	Level    int
+	Ordered  bool
+	Start    int // first number of an ordered List, or an ordered Item's own number
+	Children []*Node
+}
+
+// Parse parses src into a Document. It never fails: anything it does not
+// recognise is kept as text.
+func Parse(src string) *Node {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	return &Node{Kind: Document, Children: parseBlocks(strings.Split(src, "\n"))}
+}
+
+func parseBlocks(lines []string) []*Node {
+	var blocks []*Node
+	for i := 0; i < len(lines); {
+		trimmed := strings.TrimSpace(lines[i])
+		switch {
+		case trimmed == "":
+			i++
+		case fence(trimmed) != "":
+			var n *Node
+			n, i = parseCodeBlock(lines, i)
+			blocks = append(blocks, n)
+		case headingLevel(trimmed) > 0:
+			level := headingLevel(trimmed)
+			text := strings.TrimRight(strings.TrimSpace(trimmed[level:]), "#")
+			blocks = append(blocks, &Node{Kind: Heading, Level: level, Children: parseInline(strings.TrimSpace(text))})
+			i++
+		case isRule(trimmed):
+			blocks = append(blocks, &Node{Kind: Rule})
+			i++
+		case strings.HasPrefix(trimmed, ">"):
+			var inner []string
+			for ; i < len(lines); i++ {
+				t := strings.TrimSpace(lines[i])
+				if !strings.HasPrefix(t, ">") {
+					break
+				}
+				t = strings.TrimPrefix(t, ">")
+				inner = append(inner, strings.TrimPrefix(t, " "))
+			}
+			blocks = append(blocks, &Node{Kind: Quote, Children: parseBlocks(inner)})
+		default:
+			if _, ok := parseListMarker(lines[i]); ok {
+				var n *Node
+				n, i = parseList(lines, i)
+				blocks = append(blocks, n)
+				continue
+			}
+			start := i
+			for i++; i < len(lines) && !startsBlock(lines[i]); i++ {
+			}
+			blocks = append(blocks, &Node{Kind: Paragraph, Children: parseLines(lines[start:i])})
+		}
+	}
+	return blocks
+}
+
+// startsBlock reports whether line ends a paragraph or list item.
+func startsBlock(line string) bool {
+	t := strings.TrimSpace(line)
+	if t == "" || fence(t) != "" || headingLevel(t) > 0 || isRule(t) || strings.HasPrefix(t, ">") {
+		return true
+	}
+	_, ok := parseListMarker(line)
+	return ok
+}
+
+// fence returns the code fence that line opens, or "".
+func fence(line string) string {
+	for _, f := range []string{"```", "~~~"} {
+		if strings.HasPrefix(line, f) {
+			return f
+		}
+	}
+	return ""
+}
+
+func parseCodeBlock(lines []string, i int) (*Node, int) {
+	open := strings.TrimSpace(lines[i])
+	f := fence(open)
+	lang := strings.TrimSpace(strings.TrimLeft(open, f[:1]))
+	var body []string
+	for i++; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), f) {
+			i++
+			break
+		}
+		body = append(body, lines[i])
+	}
+	return &Node{Kind: CodeBlock, Lang: lang, Text: strings.Join(body, "\n")}, i
+}
+
+func headingLevel(line string) int {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(line) && line[level] != ' ') {
+		return 0
+	}
+	return level
+}
+
+func isRule(line string) bool {
+	s := strings.ReplaceAll(line, " ", "")
+	if len(s) < 3 {
+		return false
+	}
+	c := s[0]
+	return (c == '-' || c == '*' || c == '_') && strings.Count(s, string(c)) == len(s)
+}
+
+// listMarker is a parsed list item marker.
+type listMarker struct {
+	indent  int
+	ordered bool
+	number  int
+	rest    string
+}
+
+func parseListMarker(line string) (listMarker, bool) {
+	var m listMarker
+	i := 0
+	for ; i < len(line); i++ {
+		if line[i] == ' ' {
+			m.indent++
+		} else if line[i] == '\t' {
+			m.indent += 4
+		} else {
+			break
+		}
+	}
+	s := line[i:]
+	switch {
+	case len(s) >= 2 && strings.ContainsRune("-*+", rune(s[0])) && s[1] == ' ':
+		m.rest = s[2:]
+	default:
+		j := 0
+		for j < len(s) && j < 9 && s[j] >= '0' && s[j] <= '9' {
+			j++
+		}
+		if j == 0 || j+1 >= len(s) || (s[j] != '.' && s[j] != ')') || s[j+1] != ' ' {
+			return m, false
+		}
+		m.ordered = true
+		m.number, _ = strconv.Atoi(s[:j])
+		m.rest = s[j+2:]
+	}
+	m.rest = strings.TrimSpace(m.rest)
+	return m, true
+}
+
+// parseList reads consecutive items, and the lines continuing them, into a
+// List. Nested items keep their depth in Level; ordered items are numbered
+// in sequence from the first number at each depth.
+func parseList(lines []string, i int) (*Node, int) {
+	first, _ := parseListMarker(lines[i])
+	list := &Node{Kind: List, Ordered: first.ordered, Start: first.number}
+	var indents []int // indent of each open depth
+	next := map[int]int{}
+	for i < len(lines) {
+		m, ok := parseListMarker(lines[i])
+		if !ok {
+			break
+		}
+		for len(indents) > 0 && m.indent < indents[len(indents)-1] {
+			indents = indents[:len(indents)-1]
+		}
+		if len(indents) == 0 || m.indent > indents[len(indents)-1] {
+			indents = append(indents, m.indent)
+		}
+		level := len(indents) - 1
+		if level == 0 && m.ordered != list.Ordered {
+			break
+		}
+		for l := range next {
+			if l > level {
+				delete(next, l)
+			}
+		}
+		item := &Node{Kind: Item, Level: level, Ordered: m.ordered}
+		if m.ordered {
+			if _, ok := next[level]; !ok {
+				next[level] = m.number
+			}
+			item.Start = next[level]
+			next[level]++
+		}
+		body := []string{m.rest}
+		for i++; i < len(lines) && !startsBlock(lines[i]); i++ {
+			body = append(body, strings.TrimSpace(lines[i]))
+		}
+		item.Children = parseLines(body)
+		list.Children = append(list.Children, item)
+	}
+	return list, i
+}
+
+// parseLines parses each line's inline content, joining lines with LineBreak.
+func parseLines(lines []string) []*Node {
+	var out []*Node
+	for i, line := range lines {
+		if i > 0 {
+			out = append(out, &Node{Kind: LineBreak})
+		}
+		out = append(out, parseInline(strings.TrimSpace(line))...)
+	}
+	return out
+}
@@ -0,0 +1,98 @@
+package markdown
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// dump writes n as a compact S-expression for comparison.
+func dump(n *Node) string {
+	var b strings.Builder
+	var walk func(n *Node)
+	walk = func(n *Node) {
+		switch n.Kind {
+		case Text:
+			fmt.Fprintf(&b, "%q", n.Text)
+			return
+		case Code:
+			fmt.Fprintf(&b, "(code %q)", n.Text)
+			return
+		case CodeBlock:
+			fmt.Fprintf(&b, "(pre %s %q)", n.Lang, n.Text)
+			return
+		case LineBreak:
+			b.WriteString("br")
+			return
+		case Rule:
+			b.WriteString("hr")
+			return
+		}
+		b.WriteString("(" + [...]string{"doc", "p", "h", "", "quote", "list", "item", "", "", "strong", "em", "s", "", "link"}[n.Kind])
+		switch n.Kind {
+		case Heading:
+			fmt.Fprintf(&b, "%d", n.Level)
+		case Item:
+			fmt.Fprintf(&b, "%d", n.Level)
+			if n.Ordered {
+				fmt.Fprintf(&b, "#%d", n.Start)
+			}
+		case Link:
+			b.WriteString(" " + n.URL)
+		}
+		for _, c := range n.Children {
+			b.WriteString(" ")
+			walk(c)
+		}
+		b.WriteString(")")
+	}
+	walk(n)
+	return b.String()
+}
+
+func TestParseBlocks(t *testing.T) {
+	tests := []struct {
+		src, want string
+	}{
+		{"hello\nworld", `(doc (p "hello" br "world"))`},
+		{"one\n\ntwo", `(doc (p "one") (p "two"))`},
+		{"## Title ##\ntext", `(doc (h2 "Title") (p "text"))`},
+		{"#hashtag", `(doc (p "#hashtag"))`},
+		{"```go\nx := 1\n\ny := 2\n```\nafter", `(doc (pre go "x := 1\n\ny := 2") (p "after"))`},
+		{"```\nunclosed", `(doc (pre  "unclosed"))`},
+		{"> a\n> b\n\nc", `(doc (quote (p "a" br "b")) (p "c"))`},
+		{"- a\n- b\n  - c\n- d", `(doc (list (item0 "a") (item0 "b") (item1 "c") (item0 "d")))`},
+		{"3. a\n7. b\n   1. x\n   2. y\n8. c", `(doc (list (item0#3 "a") (item0#4 "b") (item1#1 "x") (item1#2 "y") (item0#5 "c")))`},
+		{"- a\n  continued", `(doc (list (item0 "a" br "continued")))`},
+		{"para\n- item", `(doc (p "para") (list (item0 "item")))`},
+		{"---\n* * *", `(doc hr hr)`},
+	}
+	for _, tt := range tests {
+		if got := dump(Parse(tt.src)); got != tt.want {
+			t.Errorf("Parse(%q) =\n%s\nwant\n%s", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestParseInline(t *testing.T) {
+	tests := []struct {
+		src, want string
+	}{
+		{"**b** *i* _i_ __b__ ~~s~~", `(doc (p (strong "b") " " (em "i") " " (em "i") " " (strong "b") " " (s "s")))`},
+		{"***both***", `(doc (p (strong (em "both"))))`},
+		{"*a **b** c*", `(doc (p (em "a " (strong "b") " c")))`},
+		{"snake_case_name", `(doc (p "snake_case_name"))`},
+		{"2 * 3 * 4", `(doc (p "2 * 3 * 4"))`},
+		{"`a*b*c` and `` a`b ``", `(doc (p (code "a*b*c") " and " (code "a` + "`" + `b")))`},
+		{`\*not em\*`, `(doc (p "*not em*"))`},
+		{"[the *docs*](https://x.io/a_(b))", `(doc (p (link https://x.io/a_(b) "the " (em "docs"))))`},
+		{"<https://x.io>", `(doc (p (link https://x.io "https://x.io")))`},
+		{"[not a link] (x)", `(doc (p "[not a link] (x)"))`},
+		{"**unclosed", `(doc (p "**unclosed"))`},
+	}
+	for _, tt := range tests {
+		if got := dump(Parse(tt.src)); got != tt.want {
+			t.Errorf("Parse(%q) =\n%s\nwant\n%s", tt.src, got, tt.want)
+		}
+	}
+}
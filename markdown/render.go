@@ -0,0 +1,366 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+)
+
+// Dialect is a platform's rich text syntax.
+type Dialect string
+
+const (
+	Markdown Dialect = "markdown" // Markdown as Parse reads it
+	Plain    Dialect = "plain"    // No markup; link URLs follow their text
+	Telegram Dialect = "telegram" // Telegram MarkdownV2
+	Slack    Dialect = "slack"    // Slack mrkdwn
+	Discord  Dialect = "discord"  // Discord Markdown
+	HTML     Dialect = "html"     // HTML, as for email
+)
+
+// markup describes how one dialect writes each kind of node.
+type markup struct {
+	escape    func(string) string // plain text
+	code      func(string) string // inline code, unescaped
+	pre       func(lang, code string) string
+	strong    [2]string // opens and closes Strong
+	emphasis  [2]string
+	strike    [2]string
+	link      func(text, url string) string
+	lineBreak string
+	heading   func(level int, text string) string
+	paragraph func(text string) string
+	quote     func(inner string) string
+	list      func(m *markup, n *Node) string
+	rule      string
+	sep       string // between blocks
+
+	// boldHeadings means headings are written as strong text, so Strong
+	// inside them is not marked again.
+	boldHeadings bool
+}
+
+var dialects = map[Dialect]*markup{
+	Plain: {
+		escape:    identity,
+		code:      identity,
+		pre:       func(_, code string) string { return code },
+		link:      plainLink,
+		lineBreak: "\n",
+		heading:   func(_ int, text string) string { return text },
+		paragraph: identity,
+		quote:     prefixLines("> "),
+		list:      textList("• ", ". "),
+		rule:      "———",
+		sep:       "\n\n",
+	},
+	Markdown: {
+		escape:    escapeMarkdown,
+		code:      backtickCode,
+		pre:       fencedCode,
+		strong:    [2]string{"**", "**"},
+		emphasis:  [2]string{"*", "*"},
+		strike:    [2]string{"~~", "~~"},
+		link:      markdownLink,
+		lineBreak: "\n",
+		heading: func(level int, text string) string {
+			return strings.Repeat("#", level) + " " + text
+		},
+		paragraph: identity,
+		quote:     prefixLines("> "),
+		list:      textList("- ", ". "),
+		rule:      "---",
+		sep:       "\n\n",
+	},
+	Telegram: {
+		escape:   escapeWith(`\_*[]()~` + "`" + `>#+-=|{}.!`),
+		code:     func(s string) string { return "`" + escapeWith("`\\")(s) + "`" },
+		pre:      func(lang, code string) string { return "```" + lang + "\n" + escapeWith("`\\")(code) + "\n```" },
+		strong:   [2]string{"*", "*"},
+		emphasis: [2]string{"_", "_"},
+		strike:   [2]string{"~", "~"},
+		link: func(text, url string) string {
+			return "[" + text + "](" + escapeWith(`)\`)(url) + ")"
+		},
+		lineBreak:    "\n",
+		heading:      func(_ int, text string) string { return "*" + text + "*" },
+		paragraph:    identity,
+		quote:        prefixLines(">"),
+		list:         textList("• ", "\\. "),
+		rule:         "———",
+		sep:          "\n\n",
+		boldHeadings: true,
+	},
+	Slack: {
+		escape:       escapeSlack,
+		code:         func(s string) string { return "`" + escapeSlack(s) + "`" },
+		pre:          func(_, code string) string { return "```\n" + escapeSlack(code) + "\n```" },
+		strong:       [2]string{"*", "*"},
+		emphasis:     [2]string{"_", "_"},
+		strike:       [2]string{"~", "~"},
+		link:         func(text, url string) string { return "<" + escapeSlack(url) + "|" + text + ">" },
+		lineBreak:    "\n",
+		heading:      func(_ int, text string) string { return "*" + text + "*" },
+		paragraph:    identity,
+		quote:        prefixLines("> "),
+		list:         textList("• ", ". "),
+		rule:         "———",
+		sep:          "\n\n",
+		boldHeadings: true,
+	},
+	Discord: {
+		escape:    escapeWith(`\*_~` + "`" + `|>#[]()-`),
+		code:      backtickCode,
+		pre:       fencedCode,
+		strong:    [2]string{"**", "**"},
+		emphasis:  [2]string{"*", "*"},
+		strike:    [2]string{"~~", "~~"},
+		link:      markdownLink,
+		lineBreak: "\n",
+		heading: func(level int, text string) string {
+			if level > 3 {
+				return "**" + text + "**"
+			}
+			return strings.Repeat("#", level) + " " + text
+		},
+		paragraph: identity,
+		quote:     prefixLines("> "),
+		list:      textList("- ", ". "),
+		rule:      "———",
+		sep:       "\n\n",
+	},
+	HTML: {
+		escape: html.EscapeString,
+		code:   func(s string) string { return "<code>" + html.EscapeString(s) + "</code>" },
+		pre: func(lang, code string) string {
+			if lang != "" {
+				return `<pre><code class="language-` + html.EscapeString(lang) + `">` + html.EscapeString(code) + "</code></pre>"
+			}
+			return "<pre><code>" + html.EscapeString(code) + "</code></pre>"
+		},
+		strong:   [2]string{"<strong>", "</strong>"},
+		emphasis: [2]string{"<em>", "</em>"},
+		strike:   [2]string{"<s>", "</s>"},
+		link: func(text, url string) string {
+			return `<a href="` + html.EscapeString(url) + `">` + text + "</a>"
+		},
+		lineBreak: "<br>\n",
+		heading: func(level int, text string) string {
+			return fmt.Sprintf("<h%d>%s</h%d>", level, text, level)
+		},
+		paragraph: func(text string) string { return "<p>" + text + "</p>" },
+		quote:     func(inner string) string { return "<blockquote>\n" + inner + "\n</blockquote>" },
+		list:      htmlList,
+		rule:      "<hr>",
+		sep:       "\n",
+	},
+}
+
+func lookup(d Dialect) *markup {
+	if m, ok := dialects[d]; ok {
+		return m
+	}
+	return dialects[Plain]
+}
+
+// Render writes n, usually a Document, in dialect d. Unknown dialects render
+// as Plain.
+func Render(n *Node, d Dialect) string {
+	return lookup(d).block(n)
+}
+
+// Convert parses src and renders it in dialect d.
+func Convert(src string, d Dialect) string {
+	return Render(Parse(src), d)
+}
+
+// Escape makes s appear literally in dialect d.
+func Escape(s string, d Dialect) string {
+	return lookup(d).escape(s)
+}
+
+func (m *markup) blocks(nodes []*Node) string {
+	out := make([]string, len(nodes))
+	for i, n := range nodes {
+		out[i] = m.block(n)
+	}
+	return strings.Join(out, m.sep)
+}
+
+func (m *markup) block(n *Node) string {
+	switch n.Kind {
+	case Document:
+		return m.blocks(n.Children)
+	case Paragraph:
+		return m.paragraph(m.inline(n.Children, false))
+	case Heading:
+		return m.heading(n.Level, m.inline(n.Children, m.boldHeadings))
+	case CodeBlock:
+		return m.pre(n.Lang, n.Text)
+	case Quote:
+		return m.quote(m.blocks(n.Children))
+	case List:
+		return m.list(m, n)
+	case Item:
+		return m.list(m, &Node{Kind: List, Children: []*Node{n}})
+	case Rule:
+		return m.rule
+	default:
+		return m.inline([]*Node{n}, false)
+	}
+}
+
+// inline writes inline nodes. Inside bold text, Strong is not marked again.
+func (m *markup) inline(nodes []*Node, bold bool) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		switch n.Kind {
+		case Text:
+			b.WriteString(m.escape(n.Text))
+		case Code:
+			b.WriteString(m.code(n.Text))
+		case LineBreak:
+			b.WriteString(m.lineBreak)
+		case Strong:
+			if bold {
+				b.WriteString(m.inline(n.Children, true))
+			} else {
+				b.WriteString(m.strong[0] + m.inline(n.Children, true) + m.strong[1])
+			}
+		case Emphasis:
+			b.WriteString(m.emphasis[0] + m.inline(n.Children, bold) + m.emphasis[1])
+		case Strike:
+			b.WriteString(m.strike[0] + m.inline(n.Children, bold) + m.strike[1])
+		case Link:
+			b.WriteString(m.link(m.inline(n.Children, bold), n.URL))
+		default:
+			b.WriteString(m.block(n))
+		}
+	}
+	return b.String()
+}
+
+func identity(s string) string { return s }
+
+func backtickCode(s string) string {
+	if strings.Contains(s, "`") {
+		return "`` " + s + " ``"
+	}
+	return "`" + s + "`"
+}
+
+// fencedCode writes a fenced code block, breaking up any fence inside the
+// code with a zero-width space.
+func fencedCode(lang, code string) string {
+	return "```" + lang + "\n" + strings.ReplaceAll(code, "```", "`\u200b``") + "\n```"
+}
+
+func markdownLink(text, url string) string { return "[" + text + "](" + url + ")" }
+
+func escapeWith(chars string) func(string) string {
+	return func(s string) string {
+		var b strings.Builder
+		for _, r := range s {
+			if strings.ContainsRune(chars, r) {
+				b.WriteByte('\\')
+			}
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+}
+
+// escapeMarkdown escapes Markdown syntax, leaving underscores inside words
+// (as in snake_case) alone since they cannot start emphasis.
+func escapeMarkdown(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '_' && i > 0 && i+1 < len(s) && isWordByte(s[i-1]) && isWordByte(s[i+1]):
+		case strings.IndexByte("\\*_~`[]<", c) >= 0:
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func escapeSlack(s string) string { return slackEscaper.Replace(s) }
+
+func plainLink(text, url string) string {
+	if text == url || strings.TrimPrefix(url, "mailto:") == text {
+		return text
+	}
+	return text + " (" + url + ")"
+}
+
+func prefixLines(prefix string) func(string) string {
+	return func(s string) string {
+		lines := strings.Split(s, "\n")
+		for i, l := range lines {
+			lines[i] = prefix + l
+		}
+		return strings.Join(lines, "\n")
+	}
+}
+
+// textList writes one item per line, indented two spaces per level, with
+// bullet before unordered items and the number and numberSuffix before
+// ordered ones.
+func textList(bullet, numberSuffix string) func(m *markup, n *Node) string {
+	return func(m *markup, n *Node) string {
+		lines := make([]string, len(n.Children))
+		for i, item := range n.Children {
+			marker := bullet
+			if item.Ordered {
+				marker = strconv.Itoa(item.Start) + numberSuffix
+			}
+			lines[i] = strings.Repeat("  ", item.Level) + marker + m.inline(item.Children, false)
+		}
+		return strings.Join(lines, "\n")
+	}
+}
+
+// htmlList nests items by Level in <ul> and <ol> elements.
+func htmlList(m *markup, n *Node) string {
+	var b strings.Builder
+	var open []string // closing tag per open level
+	openList := func(item *Node) {
+		if item.Ordered {
+			if item.Start != 1 {
+				fmt.Fprintf(&b, `<ol start="%d">`, item.Start)
+			} else {
+				b.WriteString("<ol>")
+			}
+			open = append(open, "</ol>")
+		} else {
+			b.WriteString("<ul>")
+			open = append(open, "</ul>")
+		}
+		b.WriteString("\n")
+	}
+	for i, item := range n.Children {
+		switch {
+		case i == 0:
+			openList(item)
+		case item.Level >= len(open):
+			openList(item)
+		default:
+			b.WriteString("</li>\n")
+			for len(open) > item.Level+1 {
+				b.WriteString(open[len(open)-1] + "</li>\n")
+				open = open[:len(open)-1]
+			}
+		}
+		b.WriteString("<li>" + m.inline(item.Children, false))
+	}
+	for len(open) > 0 {
+		b.WriteString("</li>\n" + open[len(open)-1])
+		open = open[:len(open)-1]
+	}
+	return b.String()
+}
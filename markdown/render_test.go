@@ -0,0 +1,102 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	src := "# Hi **there**\n\nSee *this*, ~~that~~ and `x_y` at [docs](https://x.io/a_(b)).\nv1.2 costs 5-10 <USD> & more!\n\n- one\n  1. sub\n\n> quote\n\n```go\nif a < b {}\n```"
+	tests := []struct {
+		d    Dialect
+		want string
+	}{
+		{Markdown, "# Hi **there**\n\n" +
+			"See *this*, ~~that~~ and `x_y` at [docs](https://x.io/a_(b)).\nv1.2 costs 5-10 \\<USD> & more!\n\n" +
+			"- one\n  1. sub\n\n> quote\n\n```go\nif a < b {}\n```"},
+		{Plain, "Hi there\n\n" +
+			"See this, that and x_y at docs (https://x.io/a_(b)).\nv1.2 costs 5-10 <USD> & more!\n\n" +
+			"• one\n  1. sub\n\n> quote\n\nif a < b {}"},
+		{Telegram, "*Hi there*\n\n" +
+			"See _this_, ~that~ and `x_y` at [docs](https://x.io/a_(b\\))\\.\nv1\\.2 costs 5\\-10 <USD\\> & more\\!\n\n" +
+			"• one\n  1\\. sub\n\n>quote\n\n```go\nif a < b {}\n```"},
+		{Slack, "*Hi there*\n\n" +
+			"See _this_, ~that~ and `x_y` at <https://x.io/a_(b)|docs>.\nv1.2 costs 5-10 &lt;USD&gt; &amp; more!\n\n" +
+			"• one\n  1. sub\n\n> quote\n\n```\nif a &lt; b {}\n```"},
+		{Discord, "# Hi **there**\n\n" +
+			"See *this*, ~~that~~ and `x_y` at [docs](https://x.io/a_(b)).\nv1.2 costs 5\\-10 <USD\\> & more!\n\n" +
+			"- one\n  1. sub\n\n> quote\n\n```go\nif a < b {}\n```"},
+		{HTML, "<h1>Hi <strong>there</strong></h1>\n" +
+			`<p>See <em>this</em>, <s>that</s> and <code>x_y</code> at <a href="https://x.io/a_(b)">docs</a>.<br>` + "\nv1.2 costs 5-10 &lt;USD&gt; &amp; more!</p>\n" +
+			"<ul>\n<li>one<ol>\n<li>sub</li>\n</ol></li>\n</ul>\n" +
+			"<blockquote>\n<p>quote</p>\n</blockquote>\n" +
+			`<pre><code class="language-go">if a &lt; b {}</code></pre>`},
+	}
+	for _, tt := range tests {
+		if got := Convert(src, tt.d); got != tt.want {
+			t.Errorf("%s:\n%s\nwant\n%s", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		d        Dialect
+		in, want string
+	}{
+		{Telegram, "a_b*c [d](e) 1.5!", `a\_b\*c \[d\]\(e\) 1\.5\!`},
+		{Markdown, `snake_case *x* _y_`, `snake_case \*x\* \_y\_`},
+		{Slack, "<@U1> & co", "&lt;@U1&gt; &amp; co"},
+		{Discord, "**not bold**", `\*\*not bold\*\*`},
+		{HTML, `<a href="x">`, "&lt;a href=&#34;x&#34;&gt;"},
+		{"unknown", "*as is*", "*as is*"},
+	}
+	for _, tt := range tests {
+		if got := Escape(tt.in, tt.d); got != tt.want {
+			t.Errorf("Escape(%q, %s) = %q, want %q", tt.in, tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	para := strings.Repeat("word ", 30)
+	code := "```\n" + strings.Repeat("line of code\n", 20) + "```"
+	src := "# Heading\n\n" + para + "**" + para + "**\n\n" + code + "\n\n- a\n- b\n- c"
+
+	for _, d := range []Dialect{Plain, Telegram, Discord, HTML} {
+		parts := Split(Parse(src), d, 100)
+		if len(parts) < 4 {
+			t.Errorf("%s: %d parts, want several", d, len(parts))
+		}
+		for i, p := range parts {
+			if n := runes(p); n > 100 || n == 0 {
+				t.Errorf("%s part %d has %d characters:\n%s", d, i, n, p)
+			}
+		}
+	}
+
+	// Broken spans and code blocks are closed and reopened in each part.
+	for _, p := range Split(Parse(src), Discord, 100) {
+		if strings.Count(p, "```")%2 != 0 || strings.Count(p, "**")%2 != 0 {
+			t.Errorf("unbalanced part:\n%s", p)
+		}
+	}
+
+	// Short text and blocks that fit together stay in one part.
+	if got := Split(Parse("a\n\nb"), Telegram, 100); len(got) != 1 || got[0] != "a\n\nb" {
+		t.Errorf("Split short = %q", got)
+	}
+	if got := Split(Parse(para), Plain, 0); len(got) != 1 {
+		t.Errorf("Split without limit = %d parts, want 1", len(got))
+	}
+	// A word longer than the limit is cut.
+	got := Split(Parse(strings.Repeat("x", 25)), Plain, 10)
+	if strings.Join(got, "") != strings.Repeat("x", 25) {
+		t.Errorf("Split long word = %q", got)
+	}
+	for _, p := range got {
+		if len(p) > 10 {
+			t.Errorf("Split long word part %q is over the limit", p)
+		}
+	}
+}
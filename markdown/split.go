@@ -0,0 +1,225 @@
+package markdown
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Split renders n in dialect d as parts of at most limit characters, for
+// platforms that cap message length. Parts break between blocks where
+// possible, then between list items, lines and words. A span or code block
+// that has to be broken is closed and reopened, so every part renders on its
+// own. A limit of 0 or less returns a single part.
+func Split(n *Node, d Dialect, limit int) []string {
+	m := lookup(d)
+	if limit <= 0 {
+		return []string{m.block(n)}
+	}
+	blocks := []*Node{n}
+	if n.Kind == Document {
+		blocks = n.Children
+	}
+
+	var parts []string
+	var cur string
+	for _, b := range blocks {
+		for _, s := range m.fit(b, limit) {
+			switch {
+			case cur == "":
+				cur = s
+			case runes(cur)+runes(m.sep)+runes(s) <= limit:
+				cur += m.sep + s
+			default:
+				parts = append(parts, cur)
+				cur = s
+			}
+		}
+	}
+	if cur != "" || len(parts) == 0 {
+		parts = append(parts, cur)
+	}
+	return parts
+}
+
+// SplitText cuts unformatted text into parts of at most limit characters,
+// breaking between paragraphs where possible, then at newlines and spaces.
+// Unlike Split it neither parses nor escapes s. A limit of 0 or less, or a
+// text that already fits, returns s as the single part.
+func SplitText(s string, limit int) []string {
+	if limit <= 0 || runes(s) <= limit {
+		return []string{s}
+	}
+	var parts []string
+	var cur string
+	for _, para := range strings.Split(s, "\n\n") {
+		for _, piece := range hardSplit(para, limit) {
+			switch {
+			case cur == "":
+				cur = piece
+			case runes(cur)+2+runes(piece) <= limit:
+				cur += "\n\n" + piece
+			default:
+				parts = append(parts, cur)
+				cur = piece
+			}
+		}
+	}
+	if cur != "" || len(parts) == 0 {
+		parts = append(parts, cur)
+	}
+	return parts
+}
+
+// fit renders n as one or more pieces of at most limit characters.
+func (m *markup) fit(n *Node, limit int) []string {
+	s := m.block(n)
+	if runes(s) <= limit {
+		return []string{s}
+	}
+	if a, b, ok := halve(n); ok {
+		return append(m.fit(a, limit), m.fit(b, limit)...)
+	}
+	return hardSplit(s, limit)
+}
+
+// halve splits a block into two smaller blocks of the same kind.
+func halve(n *Node) (*Node, *Node, bool) {
+	switch n.Kind {
+	case Document, Quote, List:
+		if len(n.Children) > 1 {
+			mid := len(n.Children) / 2
+			return n.with(n.Children[:mid]), n.with(n.Children[mid:]), true
+		}
+		if len(n.Children) == 1 {
+			a, b, ok := halve(n.Children[0])
+			return n.with([]*Node{a}), n.with([]*Node{b}), ok
+		}
+	case Paragraph, Heading, Item:
+		a, b, ok := halveInline(n.Children)
+		return n.with(a), n.with(b), ok
+	case CodeBlock:
+		lines := strings.Split(n.Text, "\n")
+		if len(lines) > 1 {
+			mid := len(lines) / 2
+			a, b := *n, *n
+			a.Text, b.Text = strings.Join(lines[:mid], "\n"), strings.Join(lines[mid:], "\n")
+			return &a, &b, true
+		}
+		if x, y, ok := halveText(n.Text); ok {
+			a, b := *n, *n
+			a.Text, b.Text = x, y
+			return &a, &b, true
+		}
+	}
+	return nil, nil, false
+}
+
+// halveInline splits inline nodes at the line break nearest the middle, else
+// between nodes, else inside the only node.
+func halveInline(nodes []*Node) ([]*Node, []*Node, bool) {
+	best := -1
+	for i, n := range nodes {
+		if n.Kind == LineBreak && (best < 0 || abs(i-len(nodes)/2) < abs(best-len(nodes)/2)) {
+			best = i
+		}
+	}
+	if best >= 0 {
+		return nodes[:best], nodes[best+1:], true
+	}
+	if len(nodes) > 1 {
+		mid := len(nodes) / 2
+		return trimText(nodes[:mid], false), trimText(nodes[mid:], true), true
+	}
+	if len(nodes) == 0 {
+		return nil, nil, false
+	}
+	n := nodes[0]
+	switch n.Kind {
+	case Text, Code:
+		x, y, ok := halveText(n.Text)
+		a, b := *n, *n
+		a.Text, b.Text = x, y
+		return []*Node{&a}, []*Node{&b}, ok
+	case Strong, Emphasis, Strike, Link:
+		x, y, ok := halveInline(n.Children)
+		return []*Node{n.with(x)}, []*Node{n.with(y)}, ok
+	}
+	return nil, nil, false
+}
+
+// trimText drops the spaces left at the end (or, with leading, the start)
+// of inline nodes after a split.
+func trimText(nodes []*Node, leading bool) []*Node {
+	i := len(nodes) - 1
+	if leading {
+		i = 0
+	}
+	if nodes[i].Kind != Text {
+		return nodes
+	}
+	t := *nodes[i]
+	if leading {
+		t.Text = strings.TrimLeft(t.Text, " ")
+	} else {
+		t.Text = strings.TrimRight(t.Text, " ")
+	}
+	out := append([]*Node(nil), nodes...)
+	out[i] = &t
+	return out
+}
+
+// halveText splits s at the space nearest its middle, or at the middle if it
+// has no spaces.
+func halveText(s string) (string, string, bool) {
+	r := []rune(s)
+	if len(r) < 2 {
+		return "", "", false
+	}
+	mid := len(r) / 2
+	for d := 0; d < mid; d++ {
+		for _, i := range []int{mid - d, mid + d} {
+			if i > 0 && i < len(r)-1 && r[i] == ' ' {
+				return string(r[:i]), string(r[i+1:]), true
+			}
+		}
+	}
+	return string(r[:mid]), string(r[mid:]), true
+}
+
+// hardSplit cuts rendered text into pieces of at most limit characters,
+// preferring to break at a newline or space.
+func hardSplit(s string, limit int) []string {
+	var out []string
+	for runes(s) > limit {
+		r := []rune(s)
+		cut := limit
+		for i := limit; i > limit/2; i-- {
+			if r[i] == '\n' || r[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+		out = append(out, string(r[:cut]))
+		s = strings.TrimLeft(string(r[cut:]), " \n")
+	}
+	if s != "" {
+		out = append(out, s)
+	}
+	return out
+}
+
+// with returns a copy of n with other children.
+func (n *Node) with(children []*Node) *Node {
+	c := *n
+	c.Children = children
+	return &c
+}
+
+func runes(s string) int { return utf8.RuneCountInString(s) }
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -68,12 +68,21 @@ func (a *App) RegisterTool(h ToolHandler) {
 }
 
 // RegisterChannel registers a ChannelHandler capability.
-func (a *App) RegisterChannel(h ChannelHandler) {
-	pb.RegisterChannelServiceServer(a.server, &channelBridge{
+// Inbound messages are buffered in memory until Nebo acknowledges them;
+// see WithInboundBuffer and WithPersistentInbound. Handlers that implement
+// ChannelConnector can serve several accounts at once.
+func (a *App) RegisterChannel(h ChannelHandler, opts ...ChannelRegisterOption) {
+	b := &channelBridge{
 		handler:     h,
 		onConfigure: a.onConfigure,
 		env:         a.env,
-	})
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	// Open the default connection now so a persisted buffer is replayed early.
+	b.conn("", false)
+	pb.RegisterChannelServiceServer(a.server, b)
 	a.hasHandlers = true
 }
 
@@ -68,6 +68,7 @@ func (x *IDResponse) GetId() string {
 type ChannelConnectRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Config        map[string]string      `protobuf:"bytes,1,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ConnectionId  string                 `protobuf:"bytes,2,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -109,9 +110,17 @@ func (x *ChannelConnectRequest) GetConfig() map[string]string {
 	return nil
 }
 
+func (x *ChannelConnectRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
 type ChannelConnectResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,2,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -153,27 +162,34 @@ func (x *ChannelConnectResponse) GetError() string {
 	return ""
 }
 
-type ChannelDisconnectResponse struct {
+func (x *ChannelConnectResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
+type ChannelDisconnectRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChannelDisconnectResponse) Reset() {
-	*x = ChannelDisconnectResponse{}
+func (x *ChannelDisconnectRequest) Reset() {
+	*x = ChannelDisconnectRequest{}
 	mi := &file_proto_apps_v0_channel_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChannelDisconnectResponse) String() string {
+func (x *ChannelDisconnectRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChannelDisconnectResponse) ProtoMessage() {}
+func (*ChannelDisconnectRequest) ProtoMessage() {}
 
-func (x *ChannelDisconnectResponse) ProtoReflect() protoreflect.Message {
+func (x *ChannelDisconnectRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_apps_v0_channel_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -185,47 +201,40 @@ func (x *ChannelDisconnectResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChannelDisconnectResponse.ProtoReflect.Descriptor instead.
-func (*ChannelDisconnectResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChannelDisconnectRequest.ProtoReflect.Descriptor instead.
+func (*ChannelDisconnectRequest) Descriptor() ([]byte, []int) {
 	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *ChannelDisconnectResponse) GetError() string {
+func (x *ChannelDisconnectRequest) GetConnectionId() string {
 	if x != nil {
-		return x.Error
+		return x.ConnectionId
 	}
 	return ""
 }
 
-type ChannelSendRequest struct {
-	state     protoimpl.MessageState `protogen:"open.v1"`
-	ChannelId string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
-	Text      string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
-	// v1 envelope fields (optional — apps can use just channel_id + text)
-	MessageId     string           `protobuf:"bytes,3,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"` // UUID v7, set by Nebo if empty
-	Sender        *MessageSender   `protobuf:"bytes,4,opt,name=sender,proto3" json:"sender,omitempty"`                        // agent identity
-	Attachments   []*Attachment    `protobuf:"bytes,5,rep,name=attachments,proto3" json:"attachments,omitempty"`
-	ReplyTo       string           `protobuf:"bytes,6,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`                // message_id for threading
-	Actions       []*MessageAction `protobuf:"bytes,7,rep,name=actions,proto3" json:"actions,omitempty"`                               // buttons, keyboards
-	PlatformData  []byte           `protobuf:"bytes,8,opt,name=platform_data,json=platformData,proto3" json:"platform_data,omitempty"` // opaque passthrough
+type ChannelDisconnectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,2,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChannelSendRequest) Reset() {
-	*x = ChannelSendRequest{}
+func (x *ChannelDisconnectResponse) Reset() {
+	*x = ChannelDisconnectResponse{}
 	mi := &file_proto_apps_v0_channel_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChannelSendRequest) String() string {
+func (x *ChannelDisconnectResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChannelSendRequest) ProtoMessage() {}
+func (*ChannelDisconnectResponse) ProtoMessage() {}
 
-func (x *ChannelSendRequest) ProtoReflect() protoreflect.Message {
+func (x *ChannelDisconnectResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_apps_v0_channel_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -237,90 +246,132 @@ func (x *ChannelSendRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChannelSendRequest.ProtoReflect.Descriptor instead.
-func (*ChannelSendRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChannelDisconnectResponse.ProtoReflect.Descriptor instead.
+func (*ChannelDisconnectResponse) Descriptor() ([]byte, []int) {
 	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ChannelSendRequest) GetChannelId() string {
+func (x *ChannelDisconnectResponse) GetError() string {
 	if x != nil {
-		return x.ChannelId
+		return x.Error
 	}
 	return ""
 }
 
-func (x *ChannelSendRequest) GetText() string {
+func (x *ChannelDisconnectResponse) GetErrorDetail() *ErrorResponse {
 	if x != nil {
-		return x.Text
+		return x.ErrorDetail
 	}
-	return ""
+	return nil
 }
 
-func (x *ChannelSendRequest) GetMessageId() string {
+type ChannelEditRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChannelId     string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"` // ID returned by Send
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Actions       []*MessageAction       `protobuf:"bytes,4,rep,name=actions,proto3" json:"actions,omitempty"`
+	PlatformData  []byte                 `protobuf:"bytes,5,opt,name=platform_data,json=platformData,proto3" json:"platform_data,omitempty"`
+	ConnectionId  string                 `protobuf:"bytes,6,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelEditRequest) Reset() {
+	*x = ChannelEditRequest{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelEditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelEditRequest) ProtoMessage() {}
+
+func (x *ChannelEditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[5]
 	if x != nil {
-		return x.MessageId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *ChannelSendRequest) GetSender() *MessageSender {
+// Deprecated: Use ChannelEditRequest.ProtoReflect.Descriptor instead.
+func (*ChannelEditRequest) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ChannelEditRequest) GetChannelId() string {
 	if x != nil {
-		return x.Sender
+		return x.ChannelId
 	}
-	return nil
+	return ""
 }
 
-func (x *ChannelSendRequest) GetAttachments() []*Attachment {
+func (x *ChannelEditRequest) GetMessageId() string {
 	if x != nil {
-		return x.Attachments
+		return x.MessageId
 	}
-	return nil
+	return ""
 }
 
-func (x *ChannelSendRequest) GetReplyTo() string {
+func (x *ChannelEditRequest) GetText() string {
 	if x != nil {
-		return x.ReplyTo
+		return x.Text
 	}
 	return ""
 }
 
-func (x *ChannelSendRequest) GetActions() []*MessageAction {
+func (x *ChannelEditRequest) GetActions() []*MessageAction {
 	if x != nil {
 		return x.Actions
 	}
 	return nil
 }
 
-func (x *ChannelSendRequest) GetPlatformData() []byte {
+func (x *ChannelEditRequest) GetPlatformData() []byte {
 	if x != nil {
 		return x.PlatformData
 	}
 	return nil
 }
 
-type ChannelSendResponse struct {
+func (x *ChannelEditRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+type ChannelEditResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
-	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"` // echoed or platform-assigned ID
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,2,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChannelSendResponse) Reset() {
-	*x = ChannelSendResponse{}
-	mi := &file_proto_apps_v0_channel_proto_msgTypes[5]
+func (x *ChannelEditResponse) Reset() {
+	*x = ChannelEditResponse{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChannelSendResponse) String() string {
+func (x *ChannelEditResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChannelSendResponse) ProtoMessage() {}
+func (*ChannelEditResponse) ProtoMessage() {}
 
-func (x *ChannelSendResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_apps_v0_channel_proto_msgTypes[5]
+func (x *ChannelEditResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -331,58 +382,49 @@ func (x *ChannelSendResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChannelSendResponse.ProtoReflect.Descriptor instead.
-func (*ChannelSendResponse) Descriptor() ([]byte, []int) {
-	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ChannelEditResponse.ProtoReflect.Descriptor instead.
+func (*ChannelEditResponse) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ChannelSendResponse) GetError() string {
+func (x *ChannelEditResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-func (x *ChannelSendResponse) GetMessageId() string {
+func (x *ChannelEditResponse) GetErrorDetail() *ErrorResponse {
 	if x != nil {
-		return x.MessageId
+		return x.ErrorDetail
 	}
-	return ""
+	return nil
 }
 
-type InboundMessage struct {
-	state     protoimpl.MessageState `protogen:"open.v1"`
-	ChannelId string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
-	UserId    string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Text      string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
-	Metadata  string                 `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"` // JSON-encoded metadata (legacy)
-	// v1 envelope fields
-	MessageId     string           `protobuf:"bytes,5,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
-	Sender        *MessageSender   `protobuf:"bytes,6,opt,name=sender,proto3" json:"sender,omitempty"`
-	Attachments   []*Attachment    `protobuf:"bytes,7,rep,name=attachments,proto3" json:"attachments,omitempty"`
-	ReplyTo       string           `protobuf:"bytes,8,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`
-	Actions       []*MessageAction `protobuf:"bytes,9,rep,name=actions,proto3" json:"actions,omitempty"`
-	PlatformData  []byte           `protobuf:"bytes,10,opt,name=platform_data,json=platformData,proto3" json:"platform_data,omitempty"`
-	Timestamp     string           `protobuf:"bytes,11,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // RFC3339
+type ChannelDeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChannelId     string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ConnectionId  string                 `protobuf:"bytes,3,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InboundMessage) Reset() {
-	*x = InboundMessage{}
-	mi := &file_proto_apps_v0_channel_proto_msgTypes[6]
+func (x *ChannelDeleteRequest) Reset() {
+	*x = ChannelDeleteRequest{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InboundMessage) String() string {
+func (x *ChannelDeleteRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InboundMessage) ProtoMessage() {}
+func (*ChannelDeleteRequest) ProtoMessage() {}
 
-func (x *InboundMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_apps_v0_channel_proto_msgTypes[6]
+func (x *ChannelDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -393,113 +435,110 @@ func (x *InboundMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InboundMessage.ProtoReflect.Descriptor instead.
-func (*InboundMessage) Descriptor() ([]byte, []int) {
-	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ChannelDeleteRequest.ProtoReflect.Descriptor instead.
+func (*ChannelDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *InboundMessage) GetChannelId() string {
+func (x *ChannelDeleteRequest) GetChannelId() string {
 	if x != nil {
 		return x.ChannelId
 	}
 	return ""
 }
 
-func (x *InboundMessage) GetUserId() string {
+func (x *ChannelDeleteRequest) GetMessageId() string {
 	if x != nil {
-		return x.UserId
+		return x.MessageId
 	}
 	return ""
 }
 
-func (x *InboundMessage) GetText() string {
+func (x *ChannelDeleteRequest) GetConnectionId() string {
 	if x != nil {
-		return x.Text
+		return x.ConnectionId
 	}
 	return ""
 }
 
-func (x *InboundMessage) GetMetadata() string {
-	if x != nil {
-		return x.Metadata
-	}
-	return ""
+type ChannelDeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,2,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InboundMessage) GetMessageId() string {
-	if x != nil {
-		return x.MessageId
-	}
-	return ""
+func (x *ChannelDeleteResponse) Reset() {
+	*x = ChannelDeleteResponse{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *InboundMessage) GetSender() *MessageSender {
-	if x != nil {
-		return x.Sender
-	}
-	return nil
+func (x *ChannelDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *InboundMessage) GetAttachments() []*Attachment {
-	if x != nil {
-		return x.Attachments
-	}
-	return nil
-}
+func (*ChannelDeleteResponse) ProtoMessage() {}
 
-func (x *InboundMessage) GetReplyTo() string {
+func (x *ChannelDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[8]
 	if x != nil {
-		return x.ReplyTo
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *InboundMessage) GetActions() []*MessageAction {
-	if x != nil {
-		return x.Actions
-	}
-	return nil
+// Deprecated: Use ChannelDeleteResponse.ProtoReflect.Descriptor instead.
+func (*ChannelDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *InboundMessage) GetPlatformData() []byte {
+func (x *ChannelDeleteResponse) GetError() string {
 	if x != nil {
-		return x.PlatformData
+		return x.Error
 	}
-	return nil
+	return ""
 }
 
-func (x *InboundMessage) GetTimestamp() string {
+func (x *ChannelDeleteResponse) GetErrorDetail() *ErrorResponse {
 	if x != nil {
-		return x.Timestamp
+		return x.ErrorDetail
 	}
-	return ""
+	return nil
 }
 
-// MessageSender identifies who sent a message.
-type MessageSender struct {
+type ChannelReactRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`                // relationship dynamic (Friend, COO, Mentor)
-	BotId         string                 `protobuf:"bytes,3,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"` // NeboLoop bot UUID
+	ChannelId     string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Emoji         string                 `protobuf:"bytes,3,opt,name=emoji,proto3" json:"emoji,omitempty"`    // Unicode emoji or platform shortcode
+	Remove        bool                   `protobuf:"varint,4,opt,name=remove,proto3" json:"remove,omitempty"` // Remove the reaction instead of adding it
+	ConnectionId  string                 `protobuf:"bytes,5,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MessageSender) Reset() {
-	*x = MessageSender{}
-	mi := &file_proto_apps_v0_channel_proto_msgTypes[7]
+func (x *ChannelReactRequest) Reset() {
+	*x = ChannelReactRequest{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MessageSender) String() string {
+func (x *ChannelReactRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MessageSender) ProtoMessage() {}
+func (*ChannelReactRequest) ProtoMessage() {}
 
-func (x *MessageSender) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_apps_v0_channel_proto_msgTypes[7]
+func (x *ChannelReactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -510,58 +549,69 @@ func (x *MessageSender) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MessageSender.ProtoReflect.Descriptor instead.
-func (*MessageSender) Descriptor() ([]byte, []int) {
-	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ChannelReactRequest.ProtoReflect.Descriptor instead.
+func (*ChannelReactRequest) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *MessageSender) GetName() string {
+func (x *ChannelReactRequest) GetChannelId() string {
 	if x != nil {
-		return x.Name
+		return x.ChannelId
 	}
 	return ""
 }
 
-func (x *MessageSender) GetRole() string {
+func (x *ChannelReactRequest) GetMessageId() string {
 	if x != nil {
-		return x.Role
+		return x.MessageId
 	}
 	return ""
 }
 
-func (x *MessageSender) GetBotId() string {
+func (x *ChannelReactRequest) GetEmoji() string {
 	if x != nil {
-		return x.BotId
+		return x.Emoji
 	}
 	return ""
 }
 
-// Attachment represents a file or media attachment.
-type Attachment struct {
+func (x *ChannelReactRequest) GetRemove() bool {
+	if x != nil {
+		return x.Remove
+	}
+	return false
+}
+
+func (x *ChannelReactRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+type ChannelReactResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "image", "file", "audio", "video"
-	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
-	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
-	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"` // bytes
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,2,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Attachment) Reset() {
-	*x = Attachment{}
-	mi := &file_proto_apps_v0_channel_proto_msgTypes[8]
+func (x *ChannelReactResponse) Reset() {
+	*x = ChannelReactResponse{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Attachment) String() string {
+func (x *ChannelReactResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Attachment) ProtoMessage() {}
+func (*ChannelReactResponse) ProtoMessage() {}
 
-func (x *Attachment) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_apps_v0_channel_proto_msgTypes[8]
+func (x *ChannelReactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -572,9 +622,1382 @@ func (x *Attachment) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
-func (*Attachment) Descriptor() ([]byte, []int) {
-	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ChannelReactResponse.ProtoReflect.Descriptor instead.
+func (*ChannelReactResponse) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ChannelReactResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ChannelReactResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
+type ChannelTypingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChannelId     string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Typing        bool                   `protobuf:"varint,2,opt,name=typing,proto3" json:"typing,omitempty"` // false clears the indicator
+	ConnectionId  string                 `protobuf:"bytes,3,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelTypingRequest) Reset() {
+	*x = ChannelTypingRequest{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelTypingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelTypingRequest) ProtoMessage() {}
+
+func (x *ChannelTypingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelTypingRequest.ProtoReflect.Descriptor instead.
+func (*ChannelTypingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ChannelTypingRequest) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *ChannelTypingRequest) GetTyping() bool {
+	if x != nil {
+		return x.Typing
+	}
+	return false
+}
+
+func (x *ChannelTypingRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+type ChannelTypingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,2,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelTypingResponse) Reset() {
+	*x = ChannelTypingResponse{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelTypingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelTypingResponse) ProtoMessage() {}
+
+func (x *ChannelTypingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelTypingResponse.ProtoReflect.Descriptor instead.
+func (*ChannelTypingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ChannelTypingResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ChannelTypingResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
+type ChannelMarkReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChannelId     string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ConnectionId  string                 `protobuf:"bytes,3,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelMarkReadRequest) Reset() {
+	*x = ChannelMarkReadRequest{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelMarkReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelMarkReadRequest) ProtoMessage() {}
+
+func (x *ChannelMarkReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelMarkReadRequest.ProtoReflect.Descriptor instead.
+func (*ChannelMarkReadRequest) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ChannelMarkReadRequest) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *ChannelMarkReadRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ChannelMarkReadRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+type ChannelMarkReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,2,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelMarkReadResponse) Reset() {
+	*x = ChannelMarkReadResponse{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelMarkReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelMarkReadResponse) ProtoMessage() {}
+
+func (x *ChannelMarkReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelMarkReadResponse.ProtoReflect.Descriptor instead.
+func (*ChannelMarkReadResponse) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ChannelMarkReadResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ChannelMarkReadResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
+type ChannelReceiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ack           bool                   `protobuf:"varint,1,opt,name=ack,proto3" json:"ack,omitempty"` // Nebo will call Ack; keep messages until acknowledged
+	ConnectionId  string                 `protobuf:"bytes,2,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelReceiveRequest) Reset() {
+	*x = ChannelReceiveRequest{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelReceiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelReceiveRequest) ProtoMessage() {}
+
+func (x *ChannelReceiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelReceiveRequest.ProtoReflect.Descriptor instead.
+func (*ChannelReceiveRequest) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ChannelReceiveRequest) GetAck() bool {
+	if x != nil {
+		return x.Ack
+	}
+	return false
+}
+
+func (x *ChannelReceiveRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+type ChannelAckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeliveryIds   []string               `protobuf:"bytes,1,rep,name=delivery_ids,json=deliveryIds,proto3" json:"delivery_ids,omitempty"`
+	ConnectionId  string                 `protobuf:"bytes,2,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"` // Delivery IDs are scoped to a connection
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelAckRequest) Reset() {
+	*x = ChannelAckRequest{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelAckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelAckRequest) ProtoMessage() {}
+
+func (x *ChannelAckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelAckRequest.ProtoReflect.Descriptor instead.
+func (*ChannelAckRequest) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ChannelAckRequest) GetDeliveryIds() []string {
+	if x != nil {
+		return x.DeliveryIds
+	}
+	return nil
+}
+
+func (x *ChannelAckRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+type ChannelAckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,2,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelAckResponse) Reset() {
+	*x = ChannelAckResponse{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelAckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelAckResponse) ProtoMessage() {}
+
+func (x *ChannelAckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelAckResponse.ProtoReflect.Descriptor instead.
+func (*ChannelAckResponse) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ChannelAckResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ChannelAckResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
+type ChannelSendRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ChannelId string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Text      string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	// v1 envelope fields (optional — apps can use just channel_id + text)
+	MessageId     string           `protobuf:"bytes,3,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"` // UUID v7, set by Nebo if empty
+	Sender        *MessageSender   `protobuf:"bytes,4,opt,name=sender,proto3" json:"sender,omitempty"`                        // agent identity
+	Attachments   []*Attachment    `protobuf:"bytes,5,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	ReplyTo       string           `protobuf:"bytes,6,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`                // message_id for threading
+	Actions       []*MessageAction `protobuf:"bytes,7,rep,name=actions,proto3" json:"actions,omitempty"`                               // buttons, keyboards
+	PlatformData  []byte           `protobuf:"bytes,8,opt,name=platform_data,json=platformData,proto3" json:"platform_data,omitempty"` // opaque passthrough
+	ConnectionId  string           `protobuf:"bytes,9,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	ThreadId      string           `protobuf:"bytes,10,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"` // Thread or topic to post in, within channel_id
+	Mentions      []*Mention       `protobuf:"bytes,11,rep,name=mentions,proto3" json:"mentions,omitempty"`                 // Users mentioned in text
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelSendRequest) Reset() {
+	*x = ChannelSendRequest{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelSendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelSendRequest) ProtoMessage() {}
+
+func (x *ChannelSendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelSendRequest.ProtoReflect.Descriptor instead.
+func (*ChannelSendRequest) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ChannelSendRequest) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *ChannelSendRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ChannelSendRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ChannelSendRequest) GetSender() *MessageSender {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *ChannelSendRequest) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *ChannelSendRequest) GetReplyTo() string {
+	if x != nil {
+		return x.ReplyTo
+	}
+	return ""
+}
+
+func (x *ChannelSendRequest) GetActions() []*MessageAction {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *ChannelSendRequest) GetPlatformData() []byte {
+	if x != nil {
+		return x.PlatformData
+	}
+	return nil
+}
+
+func (x *ChannelSendRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *ChannelSendRequest) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *ChannelSendRequest) GetMentions() []*Mention {
+	if x != nil {
+		return x.Mentions
+	}
+	return nil
+}
+
+type ChannelStreamChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         *ChannelSendRequest    `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"` // First chunk only; its text is the initial content
+	Delta         string                 `protobuf:"bytes,2,opt,name=delta,proto3" json:"delta,omitempty"` // Text appended to the reply
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelStreamChunk) Reset() {
+	*x = ChannelStreamChunk{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelStreamChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelStreamChunk) ProtoMessage() {}
+
+func (x *ChannelStreamChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelStreamChunk.ProtoReflect.Descriptor instead.
+func (*ChannelStreamChunk) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ChannelStreamChunk) GetStart() *ChannelSendRequest {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *ChannelStreamChunk) GetDelta() string {
+	if x != nil {
+		return x.Delta
+	}
+	return ""
+}
+
+type ChannelSendResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Error       string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	MessageId   string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"` // echoed or platform-assigned ID
+	ErrorDetail *ErrorResponse         `protobuf:"bytes,3,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
+	// Every part's ID, in order, when the app split a long message; message_id
+	// is the first. On error, the parts sent before it.
+	MessageIds    []string `protobuf:"bytes,4,rep,name=message_ids,json=messageIds,proto3" json:"message_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelSendResponse) Reset() {
+	*x = ChannelSendResponse{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelSendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelSendResponse) ProtoMessage() {}
+
+func (x *ChannelSendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelSendResponse.ProtoReflect.Descriptor instead.
+func (*ChannelSendResponse) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ChannelSendResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ChannelSendResponse) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ChannelSendResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
+func (x *ChannelSendResponse) GetMessageIds() []string {
+	if x != nil {
+		return x.MessageIds
+	}
+	return nil
+}
+
+type InboundMessage struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ChannelId string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	UserId    string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Text      string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Metadata  string                 `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"` // JSON-encoded metadata (legacy)
+	// v1 envelope fields
+	MessageId    string           `protobuf:"bytes,5,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Sender       *MessageSender   `protobuf:"bytes,6,opt,name=sender,proto3" json:"sender,omitempty"`
+	Attachments  []*Attachment    `protobuf:"bytes,7,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	ReplyTo      string           `protobuf:"bytes,8,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`
+	Actions      []*MessageAction `protobuf:"bytes,9,rep,name=actions,proto3" json:"actions,omitempty"`
+	PlatformData []byte           `protobuf:"bytes,10,opt,name=platform_data,json=platformData,proto3" json:"platform_data,omitempty"`
+	Timestamp    string           `protobuf:"bytes,11,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                     // RFC3339
+	DeliveryId   string           `protobuf:"bytes,12,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"` // Assigned by the SDK; pass to Ack
+	// What happened. For edits, deletes and reactions, message_id is the affected
+	// message and sender is who acted.
+	Kind            string          `protobuf:"bytes,13,opt,name=kind,proto3" json:"kind,omitempty"`                                               // "message" (default), "edit", "delete", "reaction", "receipt", "presence", "callback"
+	Reaction        string          `protobuf:"bytes,14,opt,name=reaction,proto3" json:"reaction,omitempty"`                                       // Emoji, for kind "reaction"
+	ReactionRemoved bool            `protobuf:"varint,15,opt,name=reaction_removed,json=reactionRemoved,proto3" json:"reaction_removed,omitempty"` // The reaction was taken away
+	Receipt         *Receipt        `protobuf:"bytes,16,opt,name=receipt,proto3" json:"receipt,omitempty"`                                         // For kind "receipt"; message_id is the latest message covered
+	Presence        *Presence       `protobuf:"bytes,17,opt,name=presence,proto3" json:"presence,omitempty"`                                       // For kind "presence"; sender is whose presence changed
+	Callback        *ActionCallback `protobuf:"bytes,18,opt,name=callback,proto3" json:"callback,omitempty"`                                       // For kind "callback"; message_id is the message holding the action
+	ConnectionId    string          `protobuf:"bytes,19,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`           // Account the message arrived on
+	// Conversation context.
+	ThreadId         string         `protobuf:"bytes,20,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`                         // Thread or topic within channel_id, if any
+	ConversationType string         `protobuf:"bytes,21,opt,name=conversation_type,json=conversationType,proto3" json:"conversation_type,omitempty"` // "direct", "group" or "channel"
+	Participants     []*Participant `protobuf:"bytes,22,rep,name=participants,proto3" json:"participants,omitempty"`                                 // Known members, if the platform reports them
+	Mentions         []*Mention     `protobuf:"bytes,23,rep,name=mentions,proto3" json:"mentions,omitempty"`                                         // Users mentioned in text
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *InboundMessage) Reset() {
+	*x = InboundMessage{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InboundMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InboundMessage) ProtoMessage() {}
+
+func (x *InboundMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InboundMessage.ProtoReflect.Descriptor instead.
+func (*InboundMessage) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *InboundMessage) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *InboundMessage) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *InboundMessage) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *InboundMessage) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *InboundMessage) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *InboundMessage) GetSender() *MessageSender {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *InboundMessage) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *InboundMessage) GetReplyTo() string {
+	if x != nil {
+		return x.ReplyTo
+	}
+	return ""
+}
+
+func (x *InboundMessage) GetActions() []*MessageAction {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *InboundMessage) GetPlatformData() []byte {
+	if x != nil {
+		return x.PlatformData
+	}
+	return nil
+}
+
+func (x *InboundMessage) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *InboundMessage) GetDeliveryId() string {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return ""
+}
+
+func (x *InboundMessage) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *InboundMessage) GetReaction() string {
+	if x != nil {
+		return x.Reaction
+	}
+	return ""
+}
+
+func (x *InboundMessage) GetReactionRemoved() bool {
+	if x != nil {
+		return x.ReactionRemoved
+	}
+	return false
+}
+
+func (x *InboundMessage) GetReceipt() *Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+func (x *InboundMessage) GetPresence() *Presence {
+	if x != nil {
+		return x.Presence
+	}
+	return nil
+}
+
+func (x *InboundMessage) GetCallback() *ActionCallback {
+	if x != nil {
+		return x.Callback
+	}
+	return nil
+}
+
+func (x *InboundMessage) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *InboundMessage) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *InboundMessage) GetConversationType() string {
+	if x != nil {
+		return x.ConversationType
+	}
+	return ""
+}
+
+func (x *InboundMessage) GetParticipants() []*Participant {
+	if x != nil {
+		return x.Participants
+	}
+	return nil
+}
+
+func (x *InboundMessage) GetMentions() []*Mention {
+	if x != nil {
+		return x.Mentions
+	}
+	return nil
+}
+
+// Participant is a member of a conversation.
+type Participant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	IsBot         bool                   `protobuf:"varint,3,opt,name=is_bot,json=isBot,proto3" json:"is_bot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Participant) Reset() {
+	*x = Participant{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Participant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Participant) ProtoMessage() {}
+
+func (x *Participant) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Participant.ProtoReflect.Descriptor instead.
+func (*Participant) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Participant) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Participant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Participant) GetIsBot() bool {
+	if x != nil {
+		return x.IsBot
+	}
+	return false
+}
+
+// Mention is a reference to a user inside a message's text. Offsets count
+// Unicode code points.
+type Mention struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length        int32                  `protobuf:"varint,4,opt,name=length,proto3" json:"length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Mention) Reset() {
+	*x = Mention{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Mention) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mention) ProtoMessage() {}
+
+func (x *Mention) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mention.ProtoReflect.Descriptor instead.
+func (*Mention) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Mention) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Mention) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Mention) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *Mention) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type ChannelConnectionsResponse struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Connections   []*ChannelConnectionStatus `protobuf:"bytes,1,rep,name=connections,proto3" json:"connections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelConnectionsResponse) Reset() {
+	*x = ChannelConnectionsResponse{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelConnectionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelConnectionsResponse) ProtoMessage() {}
+
+func (x *ChannelConnectionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelConnectionsResponse.ProtoReflect.Descriptor instead.
+func (*ChannelConnectionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ChannelConnectionsResponse) GetConnections() []*ChannelConnectionStatus {
+	if x != nil {
+		return x.Connections
+	}
+	return nil
+}
+
+// ChannelConnectionStatus is the health of one account connection.
+type ChannelConnectionStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	Healthy       bool                   `protobuf:"varint,2,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // "connected", "connecting", "degraded", "disconnected"
+	Detail        string                 `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"` // Last error, if any
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelConnectionStatus) Reset() {
+	*x = ChannelConnectionStatus{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelConnectionStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelConnectionStatus) ProtoMessage() {}
+
+func (x *ChannelConnectionStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelConnectionStatus.ProtoReflect.Descriptor instead.
+func (*ChannelConnectionStatus) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ChannelConnectionStatus) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *ChannelConnectionStatus) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *ChannelConnectionStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ChannelConnectionStatus) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+// ActionCallback reports that a user pressed a button or chose from a select menu.
+type ActionCallback struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CallbackId    string                 `protobuf:"bytes,1,opt,name=callback_id,json=callbackId,proto3" json:"callback_id,omitempty"` // MessageAction.callback_id
+	Payload       string                 `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`                         // Chosen option value for select menus, else platform-specific
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActionCallback) Reset() {
+	*x = ActionCallback{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionCallback) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionCallback) ProtoMessage() {}
+
+func (x *ActionCallback) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionCallback.ProtoReflect.Descriptor instead.
+func (*ActionCallback) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ActionCallback) GetCallbackId() string {
+	if x != nil {
+		return x.CallbackId
+	}
+	return ""
+}
+
+func (x *ActionCallback) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+// Receipt reports that the user received or read the bot's messages.
+type Receipt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // "delivered" or "read"
+	At            string                 `protobuf:"bytes,2,opt,name=at,proto3" json:"at,omitempty"`         // RFC3339
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Receipt) Reset() {
+	*x = Receipt{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Receipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Receipt) ProtoMessage() {}
+
+func (x *Receipt) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Receipt.ProtoReflect.Descriptor instead.
+func (*Receipt) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Receipt) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Receipt) GetAt() string {
+	if x != nil {
+		return x.At
+	}
+	return ""
+}
+
+// Presence reports a user's availability.
+type Presence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`                     // "online", "away", "offline", "typing"
+	LastSeen      string                 `protobuf:"bytes,2,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"` // RFC3339, if the platform reports it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Presence) Reset() {
+	*x = Presence{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Presence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Presence) ProtoMessage() {}
+
+func (x *Presence) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Presence.ProtoReflect.Descriptor instead.
+func (*Presence) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Presence) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Presence) GetLastSeen() string {
+	if x != nil {
+		return x.LastSeen
+	}
+	return ""
+}
+
+// MessageSender identifies who sent a message.
+type MessageSender struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`                // relationship dynamic (Friend, COO, Mentor)
+	BotId         string                 `protobuf:"bytes,3,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"` // NeboLoop bot UUID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageSender) Reset() {
+	*x = MessageSender{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageSender) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageSender) ProtoMessage() {}
+
+func (x *MessageSender) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageSender.ProtoReflect.Descriptor instead.
+func (*MessageSender) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *MessageSender) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MessageSender) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *MessageSender) GetBotId() string {
+	if x != nil {
+		return x.BotId
+	}
+	return ""
+}
+
+// Attachment represents a file or media attachment.
+type Attachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "image", "file", "audio", "video"
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"` // bytes
+	MimeType      string                 `protobuf:"bytes,5,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	Sha256        string                 `protobuf:"bytes,6,opt,name=sha256,proto3" json:"sha256,omitempty"` // hex-encoded checksum of the content
+	Id            string                 `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"`         // ID in the app's attachment store; fetch with DownloadAttachment
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *Attachment) GetType() string {
@@ -605,18 +2028,199 @@ func (x *Attachment) GetSize() int64 {
 	return 0
 }
 
+func (x *Attachment) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *Attachment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type AttachmentChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Info          *Attachment            `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"` // First chunk only
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentChunk) Reset() {
+	*x = AttachmentChunk{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentChunk) ProtoMessage() {}
+
+func (x *AttachmentChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentChunk.ProtoReflect.Descriptor instead.
+func (*AttachmentChunk) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AttachmentChunk) GetInfo() *Attachment {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *AttachmentChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type AttachmentUploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachment    *Attachment            `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"` // As stored, with id, size and sha256 filled in
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,3,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentUploadResponse) Reset() {
+	*x = AttachmentUploadResponse{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentUploadResponse) ProtoMessage() {}
+
+func (x *AttachmentUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentUploadResponse.ProtoReflect.Descriptor instead.
+func (*AttachmentUploadResponse) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *AttachmentUploadResponse) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+func (x *AttachmentUploadResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AttachmentUploadResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
+type AttachmentDownloadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentDownloadRequest) Reset() {
+	*x = AttachmentDownloadRequest{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentDownloadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentDownloadRequest) ProtoMessage() {}
+
+func (x *AttachmentDownloadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentDownloadRequest.ProtoReflect.Descriptor instead.
+func (*AttachmentDownloadRequest) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AttachmentDownloadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
 // MessageAction represents an interactive element (button, keyboard row).
 type MessageAction struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Label         string                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
 	CallbackId    string                 `protobuf:"bytes,2,opt,name=callback_id,json=callbackId,proto3" json:"callback_id,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`       // "button" (default), "url", "select"
+	Url           string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`         // For "url": opened by the client, no callback
+	Options       []*SelectOption        `protobuf:"bytes,5,rep,name=options,proto3" json:"options,omitempty"` // For "select"
+	Row           int32                  `protobuf:"varint,6,opt,name=row,proto3" json:"row,omitempty"`        // Keyboard row; actions sharing a row are laid out together
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *MessageAction) Reset() {
 	*x = MessageAction{}
-	mi := &file_proto_apps_v0_channel_proto_msgTypes[9]
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -628,7 +2232,7 @@ func (x *MessageAction) String() string {
 func (*MessageAction) ProtoMessage() {}
 
 func (x *MessageAction) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_apps_v0_channel_proto_msgTypes[9]
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -641,7 +2245,7 @@ func (x *MessageAction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MessageAction.ProtoReflect.Descriptor instead.
 func (*MessageAction) Descriptor() ([]byte, []int) {
-	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{9}
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *MessageAction) GetLabel() string {
@@ -658,6 +2262,86 @@ func (x *MessageAction) GetCallbackId() string {
 	return ""
 }
 
+func (x *MessageAction) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *MessageAction) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *MessageAction) GetOptions() []*SelectOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *MessageAction) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+type SelectOption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Label         string                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"` // Sent back as ActionCallback.payload
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelectOption) Reset() {
+	*x = SelectOption{}
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelectOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectOption) ProtoMessage() {}
+
+func (x *SelectOption) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_channel_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectOption.ProtoReflect.Descriptor instead.
+func (*SelectOption) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_channel_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SelectOption) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *SelectOption) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
 var File_proto_apps_v0_channel_proto protoreflect.FileDescriptor
 
 const file_proto_apps_v0_channel_proto_rawDesc = "" +
@@ -665,16 +2349,79 @@ const file_proto_apps_v0_channel_proto_rawDesc = "" +
 	"\x1bproto/apps/v0/channel.proto\x12\aapps.v0\x1a\x1aproto/apps/v0/common.proto\"\x1c\n" +
 	"\n" +
 	"IDResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x96\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xbb\x01\n" +
 	"\x15ChannelConnectRequest\x12B\n" +
-	"\x06config\x18\x01 \x03(\v2*.apps.v0.ChannelConnectRequest.ConfigEntryR\x06config\x1a9\n" +
+	"\x06config\x18\x01 \x03(\v2*.apps.v0.ChannelConnectRequest.ConfigEntryR\x06config\x12#\n" +
+	"\rconnection_id\x18\x02 \x01(\tR\fconnectionId\x1a9\n" +
 	"\vConfigEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\".\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"i\n" +
 	"\x16ChannelConnectResponse\x12\x14\n" +
-	"\x05error\x18\x01 \x01(\tR\x05error\"1\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x02 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\"?\n" +
+	"\x18ChannelDisconnectRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\"l\n" +
 	"\x19ChannelDisconnectResponse\x12\x14\n" +
-	"\x05error\x18\x01 \x01(\tR\x05error\"\xbf\x02\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x02 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\"\xe2\x01\n" +
+	"\x12ChannelEditRequest\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x01 \x01(\tR\tchannelId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x120\n" +
+	"\aactions\x18\x04 \x03(\v2\x16.apps.v0.MessageActionR\aactions\x12#\n" +
+	"\rplatform_data\x18\x05 \x01(\fR\fplatformData\x12#\n" +
+	"\rconnection_id\x18\x06 \x01(\tR\fconnectionId\"f\n" +
+	"\x13ChannelEditResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x02 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\"y\n" +
+	"\x14ChannelDeleteRequest\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x01 \x01(\tR\tchannelId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12#\n" +
+	"\rconnection_id\x18\x03 \x01(\tR\fconnectionId\"h\n" +
+	"\x15ChannelDeleteResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x02 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\"\xa6\x01\n" +
+	"\x13ChannelReactRequest\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x01 \x01(\tR\tchannelId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x14\n" +
+	"\x05emoji\x18\x03 \x01(\tR\x05emoji\x12\x16\n" +
+	"\x06remove\x18\x04 \x01(\bR\x06remove\x12#\n" +
+	"\rconnection_id\x18\x05 \x01(\tR\fconnectionId\"g\n" +
+	"\x14ChannelReactResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x02 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\"r\n" +
+	"\x14ChannelTypingRequest\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x01 \x01(\tR\tchannelId\x12\x16\n" +
+	"\x06typing\x18\x02 \x01(\bR\x06typing\x12#\n" +
+	"\rconnection_id\x18\x03 \x01(\tR\fconnectionId\"h\n" +
+	"\x15ChannelTypingResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x02 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\"{\n" +
+	"\x16ChannelMarkReadRequest\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x01 \x01(\tR\tchannelId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12#\n" +
+	"\rconnection_id\x18\x03 \x01(\tR\fconnectionId\"j\n" +
+	"\x17ChannelMarkReadResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x02 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\"N\n" +
+	"\x15ChannelReceiveRequest\x12\x10\n" +
+	"\x03ack\x18\x01 \x01(\bR\x03ack\x12#\n" +
+	"\rconnection_id\x18\x02 \x01(\tR\fconnectionId\"[\n" +
+	"\x11ChannelAckRequest\x12!\n" +
+	"\fdelivery_ids\x18\x01 \x03(\tR\vdeliveryIds\x12#\n" +
+	"\rconnection_id\x18\x02 \x01(\tR\fconnectionId\"e\n" +
+	"\x12ChannelAckResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x02 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\"\xaf\x03\n" +
 	"\x12ChannelSendRequest\x12\x1d\n" +
 	"\n" +
 	"channel_id\x18\x01 \x01(\tR\tchannelId\x12\x12\n" +
@@ -685,11 +2432,21 @@ const file_proto_apps_v0_channel_proto_rawDesc = "" +
 	"\vattachments\x18\x05 \x03(\v2\x13.apps.v0.AttachmentR\vattachments\x12\x19\n" +
 	"\breply_to\x18\x06 \x01(\tR\areplyTo\x120\n" +
 	"\aactions\x18\a \x03(\v2\x16.apps.v0.MessageActionR\aactions\x12#\n" +
-	"\rplatform_data\x18\b \x01(\fR\fplatformData\"J\n" +
+	"\rplatform_data\x18\b \x01(\fR\fplatformData\x12#\n" +
+	"\rconnection_id\x18\t \x01(\tR\fconnectionId\x12\x1b\n" +
+	"\tthread_id\x18\n" +
+	" \x01(\tR\bthreadId\x12,\n" +
+	"\bmentions\x18\v \x03(\v2\x10.apps.v0.MentionR\bmentions\"]\n" +
+	"\x12ChannelStreamChunk\x121\n" +
+	"\x05start\x18\x01 \x01(\v2\x1b.apps.v0.ChannelSendRequestR\x05start\x12\x14\n" +
+	"\x05delta\x18\x02 \x01(\tR\x05delta\"\xa6\x01\n" +
 	"\x13ChannelSendResponse\x12\x14\n" +
 	"\x05error\x18\x01 \x01(\tR\x05error\x12\x1d\n" +
 	"\n" +
-	"message_id\x18\x02 \x01(\tR\tmessageId\"\x8e\x03\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x129\n" +
+	"\ferror_detail\x18\x03 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\x12\x1f\n" +
+	"\vmessage_ids\x18\x04 \x03(\tR\n" +
+	"messageIds\"\xf1\x06\n" +
 	"\x0eInboundMessage\x12\x1d\n" +
 	"\n" +
 	"channel_id\x18\x01 \x01(\tR\tchannelId\x12\x17\n" +
@@ -704,29 +2461,100 @@ const file_proto_apps_v0_channel_proto_rawDesc = "" +
 	"\aactions\x18\t \x03(\v2\x16.apps.v0.MessageActionR\aactions\x12#\n" +
 	"\rplatform_data\x18\n" +
 	" \x01(\fR\fplatformData\x12\x1c\n" +
-	"\ttimestamp\x18\v \x01(\tR\ttimestamp\"N\n" +
+	"\ttimestamp\x18\v \x01(\tR\ttimestamp\x12\x1f\n" +
+	"\vdelivery_id\x18\f \x01(\tR\n" +
+	"deliveryId\x12\x12\n" +
+	"\x04kind\x18\r \x01(\tR\x04kind\x12\x1a\n" +
+	"\breaction\x18\x0e \x01(\tR\breaction\x12)\n" +
+	"\x10reaction_removed\x18\x0f \x01(\bR\x0freactionRemoved\x12*\n" +
+	"\areceipt\x18\x10 \x01(\v2\x10.apps.v0.ReceiptR\areceipt\x12-\n" +
+	"\bpresence\x18\x11 \x01(\v2\x11.apps.v0.PresenceR\bpresence\x123\n" +
+	"\bcallback\x18\x12 \x01(\v2\x17.apps.v0.ActionCallbackR\bcallback\x12#\n" +
+	"\rconnection_id\x18\x13 \x01(\tR\fconnectionId\x12\x1b\n" +
+	"\tthread_id\x18\x14 \x01(\tR\bthreadId\x12+\n" +
+	"\x11conversation_type\x18\x15 \x01(\tR\x10conversationType\x128\n" +
+	"\fparticipants\x18\x16 \x03(\v2\x14.apps.v0.ParticipantR\fparticipants\x12,\n" +
+	"\bmentions\x18\x17 \x03(\v2\x10.apps.v0.MentionR\bmentions\"Q\n" +
+	"\vParticipant\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x15\n" +
+	"\x06is_bot\x18\x03 \x01(\bR\x05isBot\"f\n" +
+	"\aMention\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06length\x18\x04 \x01(\x05R\x06length\"`\n" +
+	"\x1aChannelConnectionsResponse\x12B\n" +
+	"\vconnections\x18\x01 \x03(\v2 .apps.v0.ChannelConnectionStatusR\vconnections\"\x88\x01\n" +
+	"\x17ChannelConnectionStatus\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12\x18\n" +
+	"\ahealthy\x18\x02 \x01(\bR\ahealthy\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x16\n" +
+	"\x06detail\x18\x04 \x01(\tR\x06detail\"K\n" +
+	"\x0eActionCallback\x12\x1f\n" +
+	"\vcallback_id\x18\x01 \x01(\tR\n" +
+	"callbackId\x12\x18\n" +
+	"\apayload\x18\x02 \x01(\tR\apayload\"1\n" +
+	"\aReceipt\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x0e\n" +
+	"\x02at\x18\x02 \x01(\tR\x02at\"?\n" +
+	"\bPresence\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x1b\n" +
+	"\tlast_seen\x18\x02 \x01(\tR\blastSeen\"N\n" +
 	"\rMessageSender\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
 	"\x04role\x18\x02 \x01(\tR\x04role\x12\x15\n" +
-	"\x06bot_id\x18\x03 \x01(\tR\x05botId\"b\n" +
+	"\x06bot_id\x18\x03 \x01(\tR\x05botId\"\xa7\x01\n" +
 	"\n" +
 	"Attachment\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\tR\x04type\x12\x10\n" +
 	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1a\n" +
 	"\bfilename\x18\x03 \x01(\tR\bfilename\x12\x12\n" +
-	"\x04size\x18\x04 \x01(\x03R\x04size\"F\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x1b\n" +
+	"\tmime_type\x18\x05 \x01(\tR\bmimeType\x12\x16\n" +
+	"\x06sha256\x18\x06 \x01(\tR\x06sha256\x12\x0e\n" +
+	"\x02id\x18\a \x01(\tR\x02id\"N\n" +
+	"\x0fAttachmentChunk\x12'\n" +
+	"\x04info\x18\x01 \x01(\v2\x13.apps.v0.AttachmentR\x04info\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"\xa0\x01\n" +
+	"\x18AttachmentUploadResponse\x123\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x13.apps.v0.AttachmentR\n" +
+	"attachment\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x03 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\"+\n" +
+	"\x19AttachmentDownloadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xaf\x01\n" +
 	"\rMessageAction\x12\x14\n" +
 	"\x05label\x18\x01 \x01(\tR\x05label\x12\x1f\n" +
 	"\vcallback_id\x18\x02 \x01(\tR\n" +
-	"callbackId2\xbf\x03\n" +
+	"callbackId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12/\n" +
+	"\aoptions\x18\x05 \x03(\v2\x15.apps.v0.SelectOptionR\aoptions\x12\x10\n" +
+	"\x03row\x18\x06 \x01(\x05R\x03row\":\n" +
+	"\fSelectOption\x12\x14\n" +
+	"\x05label\x18\x01 \x01(\tR\x05label\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value2\xc7\t\n" +
 	"\x0eChannelService\x12H\n" +
 	"\vHealthCheck\x12\x1b.apps.v0.HealthCheckRequest\x1a\x1c.apps.v0.HealthCheckResponse\x12)\n" +
 	"\x02ID\x12\x0e.apps.v0.Empty\x1a\x13.apps.v0.IDResponse\x12J\n" +
-	"\aConnect\x12\x1e.apps.v0.ChannelConnectRequest\x1a\x1f.apps.v0.ChannelConnectResponse\x12@\n" +
+	"\aConnect\x12\x1e.apps.v0.ChannelConnectRequest\x1a\x1f.apps.v0.ChannelConnectResponse\x12S\n" +
+	"\n" +
+	"Disconnect\x12!.apps.v0.ChannelDisconnectRequest\x1a\".apps.v0.ChannelDisconnectResponse\x12B\n" +
+	"\vConnections\x12\x0e.apps.v0.Empty\x1a#.apps.v0.ChannelConnectionsResponse\x12A\n" +
+	"\x04Send\x12\x1b.apps.v0.ChannelSendRequest\x1a\x1c.apps.v0.ChannelSendResponse\x12I\n" +
 	"\n" +
-	"Disconnect\x12\x0e.apps.v0.Empty\x1a\".apps.v0.ChannelDisconnectResponse\x12A\n" +
-	"\x04Send\x12\x1b.apps.v0.ChannelSendRequest\x1a\x1c.apps.v0.ChannelSendResponse\x124\n" +
-	"\aReceive\x12\x0e.apps.v0.Empty\x1a\x17.apps.v0.InboundMessage0\x01\x121\n" +
+	"SendStream\x12\x1b.apps.v0.ChannelStreamChunk\x1a\x1c.apps.v0.ChannelSendResponse(\x01\x12A\n" +
+	"\x04Edit\x12\x1b.apps.v0.ChannelEditRequest\x1a\x1c.apps.v0.ChannelEditResponse\x12G\n" +
+	"\x06Delete\x12\x1d.apps.v0.ChannelDeleteRequest\x1a\x1e.apps.v0.ChannelDeleteResponse\x12D\n" +
+	"\x05React\x12\x1c.apps.v0.ChannelReactRequest\x1a\x1d.apps.v0.ChannelReactResponse\x12J\n" +
+	"\tSetTyping\x12\x1d.apps.v0.ChannelTypingRequest\x1a\x1e.apps.v0.ChannelTypingResponse\x12M\n" +
+	"\bMarkRead\x12\x1f.apps.v0.ChannelMarkReadRequest\x1a .apps.v0.ChannelMarkReadResponse\x12Q\n" +
+	"\x10UploadAttachment\x12\x18.apps.v0.AttachmentChunk\x1a!.apps.v0.AttachmentUploadResponse(\x01\x12T\n" +
+	"\x12DownloadAttachment\x12\".apps.v0.AttachmentDownloadRequest\x1a\x18.apps.v0.AttachmentChunk0\x01\x12D\n" +
+	"\aReceive\x12\x1e.apps.v0.ChannelReceiveRequest\x1a\x17.apps.v0.InboundMessage0\x01\x12>\n" +
+	"\x03Ack\x12\x1a.apps.v0.ChannelAckRequest\x1a\x1b.apps.v0.ChannelAckResponse\x121\n" +
 	"\tConfigure\x12\x14.apps.v0.SettingsMap\x1a\x0e.apps.v0.EmptyB$Z\"github.com/neboloop/nebo-sdk-go/pbb\x06proto3"
 
 var (
@@ -741,51 +2569,120 @@ func file_proto_apps_v0_channel_proto_rawDescGZIP() []byte {
 	return file_proto_apps_v0_channel_proto_rawDescData
 }
 
-var file_proto_apps_v0_channel_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_apps_v0_channel_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
 var file_proto_apps_v0_channel_proto_goTypes = []any{
-	(*IDResponse)(nil),                // 0: apps.v0.IDResponse
-	(*ChannelConnectRequest)(nil),     // 1: apps.v0.ChannelConnectRequest
-	(*ChannelConnectResponse)(nil),    // 2: apps.v0.ChannelConnectResponse
-	(*ChannelDisconnectResponse)(nil), // 3: apps.v0.ChannelDisconnectResponse
-	(*ChannelSendRequest)(nil),        // 4: apps.v0.ChannelSendRequest
-	(*ChannelSendResponse)(nil),       // 5: apps.v0.ChannelSendResponse
-	(*InboundMessage)(nil),            // 6: apps.v0.InboundMessage
-	(*MessageSender)(nil),             // 7: apps.v0.MessageSender
-	(*Attachment)(nil),                // 8: apps.v0.Attachment
-	(*MessageAction)(nil),             // 9: apps.v0.MessageAction
-	nil,                               // 10: apps.v0.ChannelConnectRequest.ConfigEntry
-	(*HealthCheckRequest)(nil),        // 11: apps.v0.HealthCheckRequest
-	(*Empty)(nil),                     // 12: apps.v0.Empty
-	(*SettingsMap)(nil),               // 13: apps.v0.SettingsMap
-	(*HealthCheckResponse)(nil),       // 14: apps.v0.HealthCheckResponse
+	(*IDResponse)(nil),                 // 0: apps.v0.IDResponse
+	(*ChannelConnectRequest)(nil),      // 1: apps.v0.ChannelConnectRequest
+	(*ChannelConnectResponse)(nil),     // 2: apps.v0.ChannelConnectResponse
+	(*ChannelDisconnectRequest)(nil),   // 3: apps.v0.ChannelDisconnectRequest
+	(*ChannelDisconnectResponse)(nil),  // 4: apps.v0.ChannelDisconnectResponse
+	(*ChannelEditRequest)(nil),         // 5: apps.v0.ChannelEditRequest
+	(*ChannelEditResponse)(nil),        // 6: apps.v0.ChannelEditResponse
+	(*ChannelDeleteRequest)(nil),       // 7: apps.v0.ChannelDeleteRequest
+	(*ChannelDeleteResponse)(nil),      // 8: apps.v0.ChannelDeleteResponse
+	(*ChannelReactRequest)(nil),        // 9: apps.v0.ChannelReactRequest
+	(*ChannelReactResponse)(nil),       // 10: apps.v0.ChannelReactResponse
+	(*ChannelTypingRequest)(nil),       // 11: apps.v0.ChannelTypingRequest
+	(*ChannelTypingResponse)(nil),      // 12: apps.v0.ChannelTypingResponse
+	(*ChannelMarkReadRequest)(nil),     // 13: apps.v0.ChannelMarkReadRequest
+	(*ChannelMarkReadResponse)(nil),    // 14: apps.v0.ChannelMarkReadResponse
+	(*ChannelReceiveRequest)(nil),      // 15: apps.v0.ChannelReceiveRequest
+	(*ChannelAckRequest)(nil),          // 16: apps.v0.ChannelAckRequest
+	(*ChannelAckResponse)(nil),         // 17: apps.v0.ChannelAckResponse
+	(*ChannelSendRequest)(nil),         // 18: apps.v0.ChannelSendRequest
+	(*ChannelStreamChunk)(nil),         // 19: apps.v0.ChannelStreamChunk
+	(*ChannelSendResponse)(nil),        // 20: apps.v0.ChannelSendResponse
+	(*InboundMessage)(nil),             // 21: apps.v0.InboundMessage
+	(*Participant)(nil),                // 22: apps.v0.Participant
+	(*Mention)(nil),                    // 23: apps.v0.Mention
+	(*ChannelConnectionsResponse)(nil), // 24: apps.v0.ChannelConnectionsResponse
+	(*ChannelConnectionStatus)(nil),    // 25: apps.v0.ChannelConnectionStatus
+	(*ActionCallback)(nil),             // 26: apps.v0.ActionCallback
+	(*Receipt)(nil),                    // 27: apps.v0.Receipt
+	(*Presence)(nil),                   // 28: apps.v0.Presence
+	(*MessageSender)(nil),              // 29: apps.v0.MessageSender
+	(*Attachment)(nil),                 // 30: apps.v0.Attachment
+	(*AttachmentChunk)(nil),            // 31: apps.v0.AttachmentChunk
+	(*AttachmentUploadResponse)(nil),   // 32: apps.v0.AttachmentUploadResponse
+	(*AttachmentDownloadRequest)(nil),  // 33: apps.v0.AttachmentDownloadRequest
+	(*MessageAction)(nil),              // 34: apps.v0.MessageAction
+	(*SelectOption)(nil),               // 35: apps.v0.SelectOption
+	nil,                                // 36: apps.v0.ChannelConnectRequest.ConfigEntry
+	(*ErrorResponse)(nil),              // 37: apps.v0.ErrorResponse
+	(*HealthCheckRequest)(nil),         // 38: apps.v0.HealthCheckRequest
+	(*Empty)(nil),                      // 39: apps.v0.Empty
+	(*SettingsMap)(nil),                // 40: apps.v0.SettingsMap
+	(*HealthCheckResponse)(nil),        // 41: apps.v0.HealthCheckResponse
 }
 var file_proto_apps_v0_channel_proto_depIdxs = []int32{
-	10, // 0: apps.v0.ChannelConnectRequest.config:type_name -> apps.v0.ChannelConnectRequest.ConfigEntry
-	7,  // 1: apps.v0.ChannelSendRequest.sender:type_name -> apps.v0.MessageSender
-	8,  // 2: apps.v0.ChannelSendRequest.attachments:type_name -> apps.v0.Attachment
-	9,  // 3: apps.v0.ChannelSendRequest.actions:type_name -> apps.v0.MessageAction
-	7,  // 4: apps.v0.InboundMessage.sender:type_name -> apps.v0.MessageSender
-	8,  // 5: apps.v0.InboundMessage.attachments:type_name -> apps.v0.Attachment
-	9,  // 6: apps.v0.InboundMessage.actions:type_name -> apps.v0.MessageAction
-	11, // 7: apps.v0.ChannelService.HealthCheck:input_type -> apps.v0.HealthCheckRequest
-	12, // 8: apps.v0.ChannelService.ID:input_type -> apps.v0.Empty
-	1,  // 9: apps.v0.ChannelService.Connect:input_type -> apps.v0.ChannelConnectRequest
-	12, // 10: apps.v0.ChannelService.Disconnect:input_type -> apps.v0.Empty
-	4,  // 11: apps.v0.ChannelService.Send:input_type -> apps.v0.ChannelSendRequest
-	12, // 12: apps.v0.ChannelService.Receive:input_type -> apps.v0.Empty
-	13, // 13: apps.v0.ChannelService.Configure:input_type -> apps.v0.SettingsMap
-	14, // 14: apps.v0.ChannelService.HealthCheck:output_type -> apps.v0.HealthCheckResponse
-	0,  // 15: apps.v0.ChannelService.ID:output_type -> apps.v0.IDResponse
-	2,  // 16: apps.v0.ChannelService.Connect:output_type -> apps.v0.ChannelConnectResponse
-	3,  // 17: apps.v0.ChannelService.Disconnect:output_type -> apps.v0.ChannelDisconnectResponse
-	5,  // 18: apps.v0.ChannelService.Send:output_type -> apps.v0.ChannelSendResponse
-	6,  // 19: apps.v0.ChannelService.Receive:output_type -> apps.v0.InboundMessage
-	12, // 20: apps.v0.ChannelService.Configure:output_type -> apps.v0.Empty
-	14, // [14:21] is the sub-list for method output_type
-	7,  // [7:14] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	36, // 0: apps.v0.ChannelConnectRequest.config:type_name -> apps.v0.ChannelConnectRequest.ConfigEntry
+	37, // 1: apps.v0.ChannelConnectResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	37, // 2: apps.v0.ChannelDisconnectResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	34, // 3: apps.v0.ChannelEditRequest.actions:type_name -> apps.v0.MessageAction
+	37, // 4: apps.v0.ChannelEditResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	37, // 5: apps.v0.ChannelDeleteResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	37, // 6: apps.v0.ChannelReactResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	37, // 7: apps.v0.ChannelTypingResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	37, // 8: apps.v0.ChannelMarkReadResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	37, // 9: apps.v0.ChannelAckResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	29, // 10: apps.v0.ChannelSendRequest.sender:type_name -> apps.v0.MessageSender
+	30, // 11: apps.v0.ChannelSendRequest.attachments:type_name -> apps.v0.Attachment
+	34, // 12: apps.v0.ChannelSendRequest.actions:type_name -> apps.v0.MessageAction
+	23, // 13: apps.v0.ChannelSendRequest.mentions:type_name -> apps.v0.Mention
+	18, // 14: apps.v0.ChannelStreamChunk.start:type_name -> apps.v0.ChannelSendRequest
+	37, // 15: apps.v0.ChannelSendResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	29, // 16: apps.v0.InboundMessage.sender:type_name -> apps.v0.MessageSender
+	30, // 17: apps.v0.InboundMessage.attachments:type_name -> apps.v0.Attachment
+	34, // 18: apps.v0.InboundMessage.actions:type_name -> apps.v0.MessageAction
+	27, // 19: apps.v0.InboundMessage.receipt:type_name -> apps.v0.Receipt
+	28, // 20: apps.v0.InboundMessage.presence:type_name -> apps.v0.Presence
+	26, // 21: apps.v0.InboundMessage.callback:type_name -> apps.v0.ActionCallback
+	22, // 22: apps.v0.InboundMessage.participants:type_name -> apps.v0.Participant
+	23, // 23: apps.v0.InboundMessage.mentions:type_name -> apps.v0.Mention
+	25, // 24: apps.v0.ChannelConnectionsResponse.connections:type_name -> apps.v0.ChannelConnectionStatus
+	30, // 25: apps.v0.AttachmentChunk.info:type_name -> apps.v0.Attachment
+	30, // 26: apps.v0.AttachmentUploadResponse.attachment:type_name -> apps.v0.Attachment
+	37, // 27: apps.v0.AttachmentUploadResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	35, // 28: apps.v0.MessageAction.options:type_name -> apps.v0.SelectOption
+	38, // 29: apps.v0.ChannelService.HealthCheck:input_type -> apps.v0.HealthCheckRequest
+	39, // 30: apps.v0.ChannelService.ID:input_type -> apps.v0.Empty
+	1,  // 31: apps.v0.ChannelService.Connect:input_type -> apps.v0.ChannelConnectRequest
+	3,  // 32: apps.v0.ChannelService.Disconnect:input_type -> apps.v0.ChannelDisconnectRequest
+	39, // 33: apps.v0.ChannelService.Connections:input_type -> apps.v0.Empty
+	18, // 34: apps.v0.ChannelService.Send:input_type -> apps.v0.ChannelSendRequest
+	19, // 35: apps.v0.ChannelService.SendStream:input_type -> apps.v0.ChannelStreamChunk
+	5,  // 36: apps.v0.ChannelService.Edit:input_type -> apps.v0.ChannelEditRequest
+	7,  // 37: apps.v0.ChannelService.Delete:input_type -> apps.v0.ChannelDeleteRequest
+	9,  // 38: apps.v0.ChannelService.React:input_type -> apps.v0.ChannelReactRequest
+	11, // 39: apps.v0.ChannelService.SetTyping:input_type -> apps.v0.ChannelTypingRequest
+	13, // 40: apps.v0.ChannelService.MarkRead:input_type -> apps.v0.ChannelMarkReadRequest
+	31, // 41: apps.v0.ChannelService.UploadAttachment:input_type -> apps.v0.AttachmentChunk
+	33, // 42: apps.v0.ChannelService.DownloadAttachment:input_type -> apps.v0.AttachmentDownloadRequest
+	15, // 43: apps.v0.ChannelService.Receive:input_type -> apps.v0.ChannelReceiveRequest
+	16, // 44: apps.v0.ChannelService.Ack:input_type -> apps.v0.ChannelAckRequest
+	40, // 45: apps.v0.ChannelService.Configure:input_type -> apps.v0.SettingsMap
+	41, // 46: apps.v0.ChannelService.HealthCheck:output_type -> apps.v0.HealthCheckResponse
+	0,  // 47: apps.v0.ChannelService.ID:output_type -> apps.v0.IDResponse
+	2,  // 48: apps.v0.ChannelService.Connect:output_type -> apps.v0.ChannelConnectResponse
+	4,  // 49: apps.v0.ChannelService.Disconnect:output_type -> apps.v0.ChannelDisconnectResponse
+	24, // 50: apps.v0.ChannelService.Connections:output_type -> apps.v0.ChannelConnectionsResponse
+	20, // 51: apps.v0.ChannelService.Send:output_type -> apps.v0.ChannelSendResponse
+	20, // 52: apps.v0.ChannelService.SendStream:output_type -> apps.v0.ChannelSendResponse
+	6,  // 53: apps.v0.ChannelService.Edit:output_type -> apps.v0.ChannelEditResponse
+	8,  // 54: apps.v0.ChannelService.Delete:output_type -> apps.v0.ChannelDeleteResponse
+	10, // 55: apps.v0.ChannelService.React:output_type -> apps.v0.ChannelReactResponse
+	12, // 56: apps.v0.ChannelService.SetTyping:output_type -> apps.v0.ChannelTypingResponse
+	14, // 57: apps.v0.ChannelService.MarkRead:output_type -> apps.v0.ChannelMarkReadResponse
+	32, // 58: apps.v0.ChannelService.UploadAttachment:output_type -> apps.v0.AttachmentUploadResponse
+	31, // 59: apps.v0.ChannelService.DownloadAttachment:output_type -> apps.v0.AttachmentChunk
+	21, // 60: apps.v0.ChannelService.Receive:output_type -> apps.v0.InboundMessage
+	17, // 61: apps.v0.ChannelService.Ack:output_type -> apps.v0.ChannelAckResponse
+	39, // 62: apps.v0.ChannelService.Configure:output_type -> apps.v0.Empty
+	46, // [46:63] is the sub-list for method output_type
+	29, // [29:46] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_proto_apps_v0_channel_proto_init() }
@@ -800,7 +2697,7 @@ func file_proto_apps_v0_channel_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_apps_v0_channel_proto_rawDesc), len(file_proto_apps_v0_channel_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   37,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,13 +19,23 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ChannelService_HealthCheck_FullMethodName = "/apps.v0.ChannelService/HealthCheck"
-	ChannelService_ID_FullMethodName          = "/apps.v0.ChannelService/ID"
-	ChannelService_Connect_FullMethodName     = "/apps.v0.ChannelService/Connect"
-	ChannelService_Disconnect_FullMethodName  = "/apps.v0.ChannelService/Disconnect"
-	ChannelService_Send_FullMethodName        = "/apps.v0.ChannelService/Send"
-	ChannelService_Receive_FullMethodName     = "/apps.v0.ChannelService/Receive"
-	ChannelService_Configure_FullMethodName   = "/apps.v0.ChannelService/Configure"
+	ChannelService_HealthCheck_FullMethodName        = "/apps.v0.ChannelService/HealthCheck"
+	ChannelService_ID_FullMethodName                 = "/apps.v0.ChannelService/ID"
+	ChannelService_Connect_FullMethodName            = "/apps.v0.ChannelService/Connect"
+	ChannelService_Disconnect_FullMethodName         = "/apps.v0.ChannelService/Disconnect"
+	ChannelService_Connections_FullMethodName        = "/apps.v0.ChannelService/Connections"
+	ChannelService_Send_FullMethodName               = "/apps.v0.ChannelService/Send"
+	ChannelService_SendStream_FullMethodName         = "/apps.v0.ChannelService/SendStream"
+	ChannelService_Edit_FullMethodName               = "/apps.v0.ChannelService/Edit"
+	ChannelService_Delete_FullMethodName             = "/apps.v0.ChannelService/Delete"
+	ChannelService_React_FullMethodName              = "/apps.v0.ChannelService/React"
+	ChannelService_SetTyping_FullMethodName          = "/apps.v0.ChannelService/SetTyping"
+	ChannelService_MarkRead_FullMethodName           = "/apps.v0.ChannelService/MarkRead"
+	ChannelService_UploadAttachment_FullMethodName   = "/apps.v0.ChannelService/UploadAttachment"
+	ChannelService_DownloadAttachment_FullMethodName = "/apps.v0.ChannelService/DownloadAttachment"
+	ChannelService_Receive_FullMethodName            = "/apps.v0.ChannelService/Receive"
+	ChannelService_Ack_FullMethodName                = "/apps.v0.ChannelService/Ack"
+	ChannelService_Configure_FullMethodName          = "/apps.v0.ChannelService/Configure"
 )
 
 // ChannelServiceClient is the client API for ChannelService service.
@@ -41,11 +51,39 @@ type ChannelServiceClient interface {
 	// Connect establishes the channel connection.
 	Connect(ctx context.Context, in *ChannelConnectRequest, opts ...grpc.CallOption) (*ChannelConnectResponse, error)
 	// Disconnect closes the channel connection.
-	Disconnect(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChannelDisconnectResponse, error)
+	Disconnect(ctx context.Context, in *ChannelDisconnectRequest, opts ...grpc.CallOption) (*ChannelDisconnectResponse, error)
+	// Connections reports the state of every account connection.
+	Connections(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChannelConnectionsResponse, error)
 	// Send sends a message to a channel.
 	Send(ctx context.Context, in *ChannelSendRequest, opts ...grpc.CallOption) (*ChannelSendResponse, error)
+	// SendStream sends a reply whose text is produced incrementally. The first
+	// chunk carries the message envelope; every chunk may carry a text delta.
+	// Apps without streaming support receive the concatenated text as one Send.
+	SendStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ChannelStreamChunk, ChannelSendResponse], error)
+	// Edit replaces the content of a message the app sent earlier.
+	Edit(ctx context.Context, in *ChannelEditRequest, opts ...grpc.CallOption) (*ChannelEditResponse, error)
+	// Delete retracts a message.
+	Delete(ctx context.Context, in *ChannelDeleteRequest, opts ...grpc.CallOption) (*ChannelDeleteResponse, error)
+	// React adds or removes an emoji reaction on a message.
+	React(ctx context.Context, in *ChannelReactRequest, opts ...grpc.CallOption) (*ChannelReactResponse, error)
+	// SetTyping shows or clears the bot's typing indicator in a conversation.
+	SetTyping(ctx context.Context, in *ChannelTypingRequest, opts ...grpc.CallOption) (*ChannelTypingResponse, error)
+	// MarkRead marks messages up to and including message_id as read by the bot.
+	MarkRead(ctx context.Context, in *ChannelMarkReadRequest, opts ...grpc.CallOption) (*ChannelMarkReadResponse, error)
+	// UploadAttachment streams a file from Nebo into the app's attachment store.
+	// The first chunk carries the attachment's metadata. The returned attachment's
+	// id can be used in a later Send.
+	UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[AttachmentChunk, AttachmentUploadResponse], error)
+	// DownloadAttachment streams a stored attachment (for example one received
+	// from the platform) to Nebo. The first chunk carries its metadata.
+	DownloadAttachment(ctx context.Context, in *AttachmentDownloadRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AttachmentChunk], error)
 	// Receive streams inbound messages from the channel to Nebo.
-	Receive(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[InboundMessage], error)
+	// The app buffers messages while no stream is attached. With ack set, each
+	// message stays buffered until Nebo acknowledges its delivery_id and is
+	// redelivered on the next Receive if the stream breaks first.
+	Receive(ctx context.Context, in *ChannelReceiveRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[InboundMessage], error)
+	// Ack confirms that Nebo has durably handled inbound messages.
+	Ack(ctx context.Context, in *ChannelAckRequest, opts ...grpc.CallOption) (*ChannelAckResponse, error)
 	// Configure updates the app's settings.
 	Configure(ctx context.Context, in *SettingsMap, opts ...grpc.CallOption) (*Empty, error)
 }
@@ -88,7 +126,7 @@ func (c *channelServiceClient) Connect(ctx context.Context, in *ChannelConnectRe
 	return out, nil
 }
 
-func (c *channelServiceClient) Disconnect(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChannelDisconnectResponse, error) {
+func (c *channelServiceClient) Disconnect(ctx context.Context, in *ChannelDisconnectRequest, opts ...grpc.CallOption) (*ChannelDisconnectResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ChannelDisconnectResponse)
 	err := c.cc.Invoke(ctx, ChannelService_Disconnect_FullMethodName, in, out, cOpts...)
@@ -98,6 +136,16 @@ func (c *channelServiceClient) Disconnect(ctx context.Context, in *Empty, opts .
 	return out, nil
 }
 
+func (c *channelServiceClient) Connections(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChannelConnectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChannelConnectionsResponse)
+	err := c.cc.Invoke(ctx, ChannelService_Connections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *channelServiceClient) Send(ctx context.Context, in *ChannelSendRequest, opts ...grpc.CallOption) (*ChannelSendResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ChannelSendResponse)
@@ -108,13 +156,108 @@ func (c *channelServiceClient) Send(ctx context.Context, in *ChannelSendRequest,
 	return out, nil
 }
 
-func (c *channelServiceClient) Receive(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[InboundMessage], error) {
+func (c *channelServiceClient) SendStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ChannelStreamChunk, ChannelSendResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ChannelService_ServiceDesc.Streams[0], ChannelService_SendStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ChannelStreamChunk, ChannelSendResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChannelService_SendStreamClient = grpc.ClientStreamingClient[ChannelStreamChunk, ChannelSendResponse]
+
+func (c *channelServiceClient) Edit(ctx context.Context, in *ChannelEditRequest, opts ...grpc.CallOption) (*ChannelEditResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChannelEditResponse)
+	err := c.cc.Invoke(ctx, ChannelService_Edit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelServiceClient) Delete(ctx context.Context, in *ChannelDeleteRequest, opts ...grpc.CallOption) (*ChannelDeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChannelDeleteResponse)
+	err := c.cc.Invoke(ctx, ChannelService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelServiceClient) React(ctx context.Context, in *ChannelReactRequest, opts ...grpc.CallOption) (*ChannelReactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChannelReactResponse)
+	err := c.cc.Invoke(ctx, ChannelService_React_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelServiceClient) SetTyping(ctx context.Context, in *ChannelTypingRequest, opts ...grpc.CallOption) (*ChannelTypingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChannelTypingResponse)
+	err := c.cc.Invoke(ctx, ChannelService_SetTyping_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelServiceClient) MarkRead(ctx context.Context, in *ChannelMarkReadRequest, opts ...grpc.CallOption) (*ChannelMarkReadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChannelMarkReadResponse)
+	err := c.cc.Invoke(ctx, ChannelService_MarkRead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelServiceClient) UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[AttachmentChunk, AttachmentUploadResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &ChannelService_ServiceDesc.Streams[0], ChannelService_Receive_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &ChannelService_ServiceDesc.Streams[1], ChannelService_UploadAttachment_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[Empty, InboundMessage]{ClientStream: stream}
+	x := &grpc.GenericClientStream[AttachmentChunk, AttachmentUploadResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChannelService_UploadAttachmentClient = grpc.ClientStreamingClient[AttachmentChunk, AttachmentUploadResponse]
+
+func (c *channelServiceClient) DownloadAttachment(ctx context.Context, in *AttachmentDownloadRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AttachmentChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ChannelService_ServiceDesc.Streams[2], ChannelService_DownloadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AttachmentDownloadRequest, AttachmentChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChannelService_DownloadAttachmentClient = grpc.ServerStreamingClient[AttachmentChunk]
+
+func (c *channelServiceClient) Receive(ctx context.Context, in *ChannelReceiveRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[InboundMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ChannelService_ServiceDesc.Streams[3], ChannelService_Receive_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ChannelReceiveRequest, InboundMessage]{ClientStream: stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -127,6 +270,16 @@ func (c *channelServiceClient) Receive(ctx context.Context, in *Empty, opts ...g
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ChannelService_ReceiveClient = grpc.ServerStreamingClient[InboundMessage]
 
+func (c *channelServiceClient) Ack(ctx context.Context, in *ChannelAckRequest, opts ...grpc.CallOption) (*ChannelAckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChannelAckResponse)
+	err := c.cc.Invoke(ctx, ChannelService_Ack_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *channelServiceClient) Configure(ctx context.Context, in *SettingsMap, opts ...grpc.CallOption) (*Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Empty)
@@ -150,11 +303,39 @@ type ChannelServiceServer interface {
 	// Connect establishes the channel connection.
 	Connect(context.Context, *ChannelConnectRequest) (*ChannelConnectResponse, error)
 	// Disconnect closes the channel connection.
-	Disconnect(context.Context, *Empty) (*ChannelDisconnectResponse, error)
+	Disconnect(context.Context, *ChannelDisconnectRequest) (*ChannelDisconnectResponse, error)
+	// Connections reports the state of every account connection.
+	Connections(context.Context, *Empty) (*ChannelConnectionsResponse, error)
 	// Send sends a message to a channel.
 	Send(context.Context, *ChannelSendRequest) (*ChannelSendResponse, error)
+	// SendStream sends a reply whose text is produced incrementally. The first
+	// chunk carries the message envelope; every chunk may carry a text delta.
+	// Apps without streaming support receive the concatenated text as one Send.
+	SendStream(grpc.ClientStreamingServer[ChannelStreamChunk, ChannelSendResponse]) error
+	// Edit replaces the content of a message the app sent earlier.
+	Edit(context.Context, *ChannelEditRequest) (*ChannelEditResponse, error)
+	// Delete retracts a message.
+	Delete(context.Context, *ChannelDeleteRequest) (*ChannelDeleteResponse, error)
+	// React adds or removes an emoji reaction on a message.
+	React(context.Context, *ChannelReactRequest) (*ChannelReactResponse, error)
+	// SetTyping shows or clears the bot's typing indicator in a conversation.
+	SetTyping(context.Context, *ChannelTypingRequest) (*ChannelTypingResponse, error)
+	// MarkRead marks messages up to and including message_id as read by the bot.
+	MarkRead(context.Context, *ChannelMarkReadRequest) (*ChannelMarkReadResponse, error)
+	// UploadAttachment streams a file from Nebo into the app's attachment store.
+	// The first chunk carries the attachment's metadata. The returned attachment's
+	// id can be used in a later Send.
+	UploadAttachment(grpc.ClientStreamingServer[AttachmentChunk, AttachmentUploadResponse]) error
+	// DownloadAttachment streams a stored attachment (for example one received
+	// from the platform) to Nebo. The first chunk carries its metadata.
+	DownloadAttachment(*AttachmentDownloadRequest, grpc.ServerStreamingServer[AttachmentChunk]) error
 	// Receive streams inbound messages from the channel to Nebo.
-	Receive(*Empty, grpc.ServerStreamingServer[InboundMessage]) error
+	// The app buffers messages while no stream is attached. With ack set, each
+	// message stays buffered until Nebo acknowledges its delivery_id and is
+	// redelivered on the next Receive if the stream breaks first.
+	Receive(*ChannelReceiveRequest, grpc.ServerStreamingServer[InboundMessage]) error
+	// Ack confirms that Nebo has durably handled inbound messages.
+	Ack(context.Context, *ChannelAckRequest) (*ChannelAckResponse, error)
 	// Configure updates the app's settings.
 	Configure(context.Context, *SettingsMap) (*Empty, error)
 	mustEmbedUnimplementedChannelServiceServer()
@@ -176,15 +357,45 @@ func (UnimplementedChannelServiceServer) ID(context.Context, *Empty) (*IDRespons
 func (UnimplementedChannelServiceServer) Connect(context.Context, *ChannelConnectRequest) (*ChannelConnectResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Connect not implemented")
 }
-func (UnimplementedChannelServiceServer) Disconnect(context.Context, *Empty) (*ChannelDisconnectResponse, error) {
+func (UnimplementedChannelServiceServer) Disconnect(context.Context, *ChannelDisconnectRequest) (*ChannelDisconnectResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Disconnect not implemented")
 }
+func (UnimplementedChannelServiceServer) Connections(context.Context, *Empty) (*ChannelConnectionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Connections not implemented")
+}
 func (UnimplementedChannelServiceServer) Send(context.Context, *ChannelSendRequest) (*ChannelSendResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Send not implemented")
 }
-func (UnimplementedChannelServiceServer) Receive(*Empty, grpc.ServerStreamingServer[InboundMessage]) error {
+func (UnimplementedChannelServiceServer) SendStream(grpc.ClientStreamingServer[ChannelStreamChunk, ChannelSendResponse]) error {
+	return status.Error(codes.Unimplemented, "method SendStream not implemented")
+}
+func (UnimplementedChannelServiceServer) Edit(context.Context, *ChannelEditRequest) (*ChannelEditResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Edit not implemented")
+}
+func (UnimplementedChannelServiceServer) Delete(context.Context, *ChannelDeleteRequest) (*ChannelDeleteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedChannelServiceServer) React(context.Context, *ChannelReactRequest) (*ChannelReactResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method React not implemented")
+}
+func (UnimplementedChannelServiceServer) SetTyping(context.Context, *ChannelTypingRequest) (*ChannelTypingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetTyping not implemented")
+}
+func (UnimplementedChannelServiceServer) MarkRead(context.Context, *ChannelMarkReadRequest) (*ChannelMarkReadResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MarkRead not implemented")
+}
+func (UnimplementedChannelServiceServer) UploadAttachment(grpc.ClientStreamingServer[AttachmentChunk, AttachmentUploadResponse]) error {
+	return status.Error(codes.Unimplemented, "method UploadAttachment not implemented")
+}
+func (UnimplementedChannelServiceServer) DownloadAttachment(*AttachmentDownloadRequest, grpc.ServerStreamingServer[AttachmentChunk]) error {
+	return status.Error(codes.Unimplemented, "method DownloadAttachment not implemented")
+}
+func (UnimplementedChannelServiceServer) Receive(*ChannelReceiveRequest, grpc.ServerStreamingServer[InboundMessage]) error {
 	return status.Error(codes.Unimplemented, "method Receive not implemented")
 }
+func (UnimplementedChannelServiceServer) Ack(context.Context, *ChannelAckRequest) (*ChannelAckResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Ack not implemented")
+}
 func (UnimplementedChannelServiceServer) Configure(context.Context, *SettingsMap) (*Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method Configure not implemented")
 }
@@ -264,7 +475,7 @@ func _ChannelService_Connect_Handler(srv interface{}, ctx context.Context, dec f
 }
 
 func _ChannelService_Disconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+	in := new(ChannelDisconnectRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -276,7 +487,25 @@ func _ChannelService_Disconnect_Handler(srv interface{}, ctx context.Context, de
 		FullMethod: ChannelService_Disconnect_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChannelServiceServer).Disconnect(ctx, req.(*Empty))
+		return srv.(ChannelServiceServer).Disconnect(ctx, req.(*ChannelDisconnectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelService_Connections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelServiceServer).Connections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChannelService_Connections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelServiceServer).Connections(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -299,17 +528,150 @@ func _ChannelService_Send_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChannelService_SendStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ChannelServiceServer).SendStream(&grpc.GenericServerStream[ChannelStreamChunk, ChannelSendResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChannelService_SendStreamServer = grpc.ClientStreamingServer[ChannelStreamChunk, ChannelSendResponse]
+
+func _ChannelService_Edit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelEditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelServiceServer).Edit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChannelService_Edit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelServiceServer).Edit(ctx, req.(*ChannelEditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChannelService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelServiceServer).Delete(ctx, req.(*ChannelDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelService_React_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelReactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelServiceServer).React(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChannelService_React_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelServiceServer).React(ctx, req.(*ChannelReactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelService_SetTyping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelTypingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelServiceServer).SetTyping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChannelService_SetTyping_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelServiceServer).SetTyping(ctx, req.(*ChannelTypingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelService_MarkRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelMarkReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelServiceServer).MarkRead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChannelService_MarkRead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelServiceServer).MarkRead(ctx, req.(*ChannelMarkReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelService_UploadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ChannelServiceServer).UploadAttachment(&grpc.GenericServerStream[AttachmentChunk, AttachmentUploadResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChannelService_UploadAttachmentServer = grpc.ClientStreamingServer[AttachmentChunk, AttachmentUploadResponse]
+
+func _ChannelService_DownloadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AttachmentDownloadRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChannelServiceServer).DownloadAttachment(m, &grpc.GenericServerStream[AttachmentDownloadRequest, AttachmentChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChannelService_DownloadAttachmentServer = grpc.ServerStreamingServer[AttachmentChunk]
+
 func _ChannelService_Receive_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Empty)
+	m := new(ChannelReceiveRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ChannelServiceServer).Receive(m, &grpc.GenericServerStream[Empty, InboundMessage]{ServerStream: stream})
+	return srv.(ChannelServiceServer).Receive(m, &grpc.GenericServerStream[ChannelReceiveRequest, InboundMessage]{ServerStream: stream})
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ChannelService_ReceiveServer = grpc.ServerStreamingServer[InboundMessage]
 
+func _ChannelService_Ack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelAckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelServiceServer).Ack(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChannelService_Ack_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelServiceServer).Ack(ctx, req.(*ChannelAckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ChannelService_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SettingsMap)
 	if err := dec(in); err != nil {
@@ -351,16 +713,59 @@ var ChannelService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Disconnect",
 			Handler:    _ChannelService_Disconnect_Handler,
 		},
+		{
+			MethodName: "Connections",
+			Handler:    _ChannelService_Connections_Handler,
+		},
 		{
 			MethodName: "Send",
 			Handler:    _ChannelService_Send_Handler,
 		},
+		{
+			MethodName: "Edit",
+			Handler:    _ChannelService_Edit_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _ChannelService_Delete_Handler,
+		},
+		{
+			MethodName: "React",
+			Handler:    _ChannelService_React_Handler,
+		},
+		{
+			MethodName: "SetTyping",
+			Handler:    _ChannelService_SetTyping_Handler,
+		},
+		{
+			MethodName: "MarkRead",
+			Handler:    _ChannelService_MarkRead_Handler,
+		},
+		{
+			MethodName: "Ack",
+			Handler:    _ChannelService_Ack_Handler,
+		},
 		{
 			MethodName: "Configure",
 			Handler:    _ChannelService_Configure_Handler,
 		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SendStream",
+			Handler:       _ChannelService_SendStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "UploadAttachment",
+			Handler:       _ChannelService_UploadAttachment_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadAttachment",
+			Handler:       _ChannelService_DownloadAttachment_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "Receive",
 			Handler:       _ChannelService_Receive_Handler,
@@ -156,6 +156,7 @@ func (x *CommConnectRequest) GetConfig() map[string]string {
 type CommConnectResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,2,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -197,9 +198,17 @@ func (x *CommConnectResponse) GetError() string {
 	return ""
 }
 
+func (x *CommConnectResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
 type CommDisconnectResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,2,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -241,6 +250,13 @@ func (x *CommDisconnectResponse) GetError() string {
 	return ""
 }
 
+func (x *CommDisconnectResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
 type CommIsConnectedResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Connected     bool                   `protobuf:"varint,1,opt,name=connected,proto3" json:"connected,omitempty"`
@@ -332,6 +348,7 @@ func (x *CommSendRequest) GetMessage() *CommMessage {
 type CommSendResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,2,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -373,6 +390,13 @@ func (x *CommSendResponse) GetError() string {
 	return ""
 }
 
+func (x *CommSendResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
 type CommSubscribeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Topic         string                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
@@ -420,6 +444,7 @@ func (x *CommSubscribeRequest) GetTopic() string {
 type CommSubscribeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,2,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -461,6 +486,13 @@ func (x *CommSubscribeResponse) GetError() string {
 	return ""
 }
 
+func (x *CommSubscribeResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
 type CommUnsubscribeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Topic         string                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
@@ -508,6 +540,7 @@ func (x *CommUnsubscribeRequest) GetTopic() string {
 type CommUnsubscribeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,2,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -549,6 +582,13 @@ func (x *CommUnsubscribeResponse) GetError() string {
 	return ""
 }
 
+func (x *CommUnsubscribeResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
 type CommRegisterRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
@@ -604,6 +644,7 @@ func (x *CommRegisterRequest) GetCapabilities() []string {
 type CommRegisterResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,2,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -645,9 +686,17 @@ func (x *CommRegisterResponse) GetError() string {
 	return ""
 }
 
+func (x *CommRegisterResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
 type CommDeregisterResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,2,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -689,6 +738,13 @@ func (x *CommDeregisterResponse) GetError() string {
 	return ""
 }
 
+func (x *CommDeregisterResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
 // CommMessage is an inter-agent message.
 type CommMessage struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -827,32 +883,39 @@ const file_proto_apps_v0_comm_proto_rawDesc = "" +
 	"\x06config\x18\x01 \x03(\v2'.apps.v0.CommConnectRequest.ConfigEntryR\x06config\x1a9\n" +
 	"\vConfigEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"+\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"f\n" +
 	"\x13CommConnectResponse\x12\x14\n" +
-	"\x05error\x18\x01 \x01(\tR\x05error\".\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x02 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\"i\n" +
 	"\x16CommDisconnectResponse\x12\x14\n" +
-	"\x05error\x18\x01 \x01(\tR\x05error\"7\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x02 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\"7\n" +
 	"\x17CommIsConnectedResponse\x12\x1c\n" +
 	"\tconnected\x18\x01 \x01(\bR\tconnected\"A\n" +
 	"\x0fCommSendRequest\x12.\n" +
-	"\amessage\x18\x01 \x01(\v2\x14.apps.v0.CommMessageR\amessage\"(\n" +
+	"\amessage\x18\x01 \x01(\v2\x14.apps.v0.CommMessageR\amessage\"c\n" +
 	"\x10CommSendResponse\x12\x14\n" +
-	"\x05error\x18\x01 \x01(\tR\x05error\",\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x02 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\",\n" +
 	"\x14CommSubscribeRequest\x12\x14\n" +
-	"\x05topic\x18\x01 \x01(\tR\x05topic\"-\n" +
+	"\x05topic\x18\x01 \x01(\tR\x05topic\"h\n" +
 	"\x15CommSubscribeResponse\x12\x14\n" +
-	"\x05error\x18\x01 \x01(\tR\x05error\".\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x02 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\".\n" +
 	"\x16CommUnsubscribeRequest\x12\x14\n" +
-	"\x05topic\x18\x01 \x01(\tR\x05topic\"/\n" +
+	"\x05topic\x18\x01 \x01(\tR\x05topic\"j\n" +
 	"\x17CommUnsubscribeResponse\x12\x14\n" +
-	"\x05error\x18\x01 \x01(\tR\x05error\"T\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x02 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\"T\n" +
 	"\x13CommRegisterRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\"\n" +
-	"\fcapabilities\x18\x02 \x03(\tR\fcapabilities\",\n" +
+	"\fcapabilities\x18\x02 \x03(\tR\fcapabilities\"g\n" +
 	"\x14CommRegisterResponse\x12\x14\n" +
-	"\x05error\x18\x01 \x01(\tR\x05error\".\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x02 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\"i\n" +
 	"\x16CommDeregisterResponse\x12\x14\n" +
-	"\x05error\x18\x01 \x01(\tR\x05error\"\x8b\x03\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x02 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\"\x8b\x03\n" +
 	"\vCommMessage\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
@@ -918,46 +981,54 @@ var file_proto_apps_v0_comm_proto_goTypes = []any{
 	(*CommMessage)(nil),             // 15: apps.v0.CommMessage
 	nil,                             // 16: apps.v0.CommConnectRequest.ConfigEntry
 	nil,                             // 17: apps.v0.CommMessage.MetadataEntry
-	(*HealthCheckRequest)(nil),      // 18: apps.v0.HealthCheckRequest
-	(*Empty)(nil),                   // 19: apps.v0.Empty
-	(*SettingsMap)(nil),             // 20: apps.v0.SettingsMap
-	(*HealthCheckResponse)(nil),     // 21: apps.v0.HealthCheckResponse
+	(*ErrorResponse)(nil),           // 18: apps.v0.ErrorResponse
+	(*HealthCheckRequest)(nil),      // 19: apps.v0.HealthCheckRequest
+	(*Empty)(nil),                   // 20: apps.v0.Empty
+	(*SettingsMap)(nil),             // 21: apps.v0.SettingsMap
+	(*HealthCheckResponse)(nil),     // 22: apps.v0.HealthCheckResponse
 }
 var file_proto_apps_v0_comm_proto_depIdxs = []int32{
 	16, // 0: apps.v0.CommConnectRequest.config:type_name -> apps.v0.CommConnectRequest.ConfigEntry
-	15, // 1: apps.v0.CommSendRequest.message:type_name -> apps.v0.CommMessage
-	17, // 2: apps.v0.CommMessage.metadata:type_name -> apps.v0.CommMessage.MetadataEntry
-	18, // 3: apps.v0.CommService.HealthCheck:input_type -> apps.v0.HealthCheckRequest
-	19, // 4: apps.v0.CommService.Name:input_type -> apps.v0.Empty
-	19, // 5: apps.v0.CommService.Version:input_type -> apps.v0.Empty
-	2,  // 6: apps.v0.CommService.Connect:input_type -> apps.v0.CommConnectRequest
-	19, // 7: apps.v0.CommService.Disconnect:input_type -> apps.v0.Empty
-	19, // 8: apps.v0.CommService.IsConnected:input_type -> apps.v0.Empty
-	6,  // 9: apps.v0.CommService.Send:input_type -> apps.v0.CommSendRequest
-	8,  // 10: apps.v0.CommService.Subscribe:input_type -> apps.v0.CommSubscribeRequest
-	10, // 11: apps.v0.CommService.Unsubscribe:input_type -> apps.v0.CommUnsubscribeRequest
-	12, // 12: apps.v0.CommService.Register:input_type -> apps.v0.CommRegisterRequest
-	19, // 13: apps.v0.CommService.Deregister:input_type -> apps.v0.Empty
-	19, // 14: apps.v0.CommService.Receive:input_type -> apps.v0.Empty
-	20, // 15: apps.v0.CommService.Configure:input_type -> apps.v0.SettingsMap
-	21, // 16: apps.v0.CommService.HealthCheck:output_type -> apps.v0.HealthCheckResponse
-	0,  // 17: apps.v0.CommService.Name:output_type -> apps.v0.CommNameResponse
-	1,  // 18: apps.v0.CommService.Version:output_type -> apps.v0.CommVersionResponse
-	3,  // 19: apps.v0.CommService.Connect:output_type -> apps.v0.CommConnectResponse
-	4,  // 20: apps.v0.CommService.Disconnect:output_type -> apps.v0.CommDisconnectResponse
-	5,  // 21: apps.v0.CommService.IsConnected:output_type -> apps.v0.CommIsConnectedResponse
-	7,  // 22: apps.v0.CommService.Send:output_type -> apps.v0.CommSendResponse
-	9,  // 23: apps.v0.CommService.Subscribe:output_type -> apps.v0.CommSubscribeResponse
-	11, // 24: apps.v0.CommService.Unsubscribe:output_type -> apps.v0.CommUnsubscribeResponse
-	13, // 25: apps.v0.CommService.Register:output_type -> apps.v0.CommRegisterResponse
-	14, // 26: apps.v0.CommService.Deregister:output_type -> apps.v0.CommDeregisterResponse
-	15, // 27: apps.v0.CommService.Receive:output_type -> apps.v0.CommMessage
-	19, // 28: apps.v0.CommService.Configure:output_type -> apps.v0.Empty
-	16, // [16:29] is the sub-list for method output_type
-	3,  // [3:16] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	18, // 1: apps.v0.CommConnectResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	18, // 2: apps.v0.CommDisconnectResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	15, // 3: apps.v0.CommSendRequest.message:type_name -> apps.v0.CommMessage
+	18, // 4: apps.v0.CommSendResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	18, // 5: apps.v0.CommSubscribeResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	18, // 6: apps.v0.CommUnsubscribeResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	18, // 7: apps.v0.CommRegisterResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	18, // 8: apps.v0.CommDeregisterResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	17, // 9: apps.v0.CommMessage.metadata:type_name -> apps.v0.CommMessage.MetadataEntry
+	19, // 10: apps.v0.CommService.HealthCheck:input_type -> apps.v0.HealthCheckRequest
+	20, // 11: apps.v0.CommService.Name:input_type -> apps.v0.Empty
+	20, // 12: apps.v0.CommService.Version:input_type -> apps.v0.Empty
+	2,  // 13: apps.v0.CommService.Connect:input_type -> apps.v0.CommConnectRequest
+	20, // 14: apps.v0.CommService.Disconnect:input_type -> apps.v0.Empty
+	20, // 15: apps.v0.CommService.IsConnected:input_type -> apps.v0.Empty
+	6,  // 16: apps.v0.CommService.Send:input_type -> apps.v0.CommSendRequest
+	8,  // 17: apps.v0.CommService.Subscribe:input_type -> apps.v0.CommSubscribeRequest
+	10, // 18: apps.v0.CommService.Unsubscribe:input_type -> apps.v0.CommUnsubscribeRequest
+	12, // 19: apps.v0.CommService.Register:input_type -> apps.v0.CommRegisterRequest
+	20, // 20: apps.v0.CommService.Deregister:input_type -> apps.v0.Empty
+	20, // 21: apps.v0.CommService.Receive:input_type -> apps.v0.Empty
+	21, // 22: apps.v0.CommService.Configure:input_type -> apps.v0.SettingsMap
+	22, // 23: apps.v0.CommService.HealthCheck:output_type -> apps.v0.HealthCheckResponse
+	0,  // 24: apps.v0.CommService.Name:output_type -> apps.v0.CommNameResponse
+	1,  // 25: apps.v0.CommService.Version:output_type -> apps.v0.CommVersionResponse
+	3,  // 26: apps.v0.CommService.Connect:output_type -> apps.v0.CommConnectResponse
+	4,  // 27: apps.v0.CommService.Disconnect:output_type -> apps.v0.CommDisconnectResponse
+	5,  // 28: apps.v0.CommService.IsConnected:output_type -> apps.v0.CommIsConnectedResponse
+	7,  // 29: apps.v0.CommService.Send:output_type -> apps.v0.CommSendResponse
+	9,  // 30: apps.v0.CommService.Subscribe:output_type -> apps.v0.CommSubscribeResponse
+	11, // 31: apps.v0.CommService.Unsubscribe:output_type -> apps.v0.CommUnsubscribeResponse
+	13, // 32: apps.v0.CommService.Register:output_type -> apps.v0.CommRegisterResponse
+	14, // 33: apps.v0.CommService.Deregister:output_type -> apps.v0.CommDeregisterResponse
+	15, // 34: apps.v0.CommService.Receive:output_type -> apps.v0.CommMessage
+	20, // 35: apps.v0.CommService.Configure:output_type -> apps.v0.Empty
+	23, // [23:36] is the sub-list for method output_type
+	10, // [10:23] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_proto_apps_v0_comm_proto_init() }
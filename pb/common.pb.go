@@ -63,6 +63,8 @@ type HealthCheckResponse struct {
 	Healthy       bool                   `protobuf:"varint,1,opt,name=healthy,proto3" json:"healthy,omitempty"`
 	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
 	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // Optional capability-specific state, e.g. "connected", "degraded"
+	Detail        string                 `protobuf:"bytes,5,opt,name=detail,proto3" json:"detail,omitempty"` // Why the app is not fully healthy
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -118,6 +120,20 @@ func (x *HealthCheckResponse) GetName() string {
 	return ""
 }
 
+func (x *HealthCheckResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *HealthCheckResponse) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
 // SettingsMap is used for Configurable settings exchange.
 type SettingsMap struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -264,10 +280,15 @@ func (*Empty) Descriptor() ([]byte, []int) {
 }
 
 // ErrorResponse is returned when an RPC encounters an error.
+// Responses that carry a legacy string error also carry an ErrorResponse
+// with the same message so older hosts keep working.
 type ErrorResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`                                                                                 // "not_found", "invalid_argument", "unauthenticated", "rate_limited", "unavailable", "internal", "unimplemented"
+	Retryable     bool                   `protobuf:"varint,3,opt,name=retryable,proto3" json:"retryable,omitempty"`                                                                      // Safe to retry the same request
+	RetryAfterMs  int64                  `protobuf:"varint,4,opt,name=retry_after_ms,json=retryAfterMs,proto3" json:"retry_after_ms,omitempty"`                                          // Suggested delay before retrying (0 = caller decides)
+	Details       map[string]string      `protobuf:"bytes,5,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Free-form structured context
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -316,16 +337,116 @@ func (x *ErrorResponse) GetCode() string {
 	return ""
 }
 
+func (x *ErrorResponse) GetRetryable() bool {
+	if x != nil {
+		return x.Retryable
+	}
+	return false
+}
+
+func (x *ErrorResponse) GetRetryAfterMs() int64 {
+	if x != nil {
+		return x.RetryAfterMs
+	}
+	return 0
+}
+
+func (x *ErrorResponse) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+// DeliveryTarget says where the output of a task should be sent.
+type DeliveryTarget struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       string                 `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`                   // Channel type, e.g. "telegram", "slack"
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`                             // Recipient or conversation ID on that channel
+	ThreadId      string                 `protobuf:"bytes,3,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"` // Reply inside this thread (optional)
+	Format        string                 `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"`                     // "text" (default), "markdown" or "html"
+	Fallbacks     []*DeliveryTarget      `protobuf:"bytes,5,rep,name=fallbacks,proto3" json:"fallbacks,omitempty"`               // Tried in order if delivery to this target fails
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliveryTarget) Reset() {
+	*x = DeliveryTarget{}
+	mi := &file_proto_apps_v0_common_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryTarget) ProtoMessage() {}
+
+func (x *DeliveryTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_common_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryTarget.ProtoReflect.Descriptor instead.
+func (*DeliveryTarget) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_common_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeliveryTarget) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *DeliveryTarget) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *DeliveryTarget) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *DeliveryTarget) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *DeliveryTarget) GetFallbacks() []*DeliveryTarget {
+	if x != nil {
+		return x.Fallbacks
+	}
+	return nil
+}
+
 var File_proto_apps_v0_common_proto protoreflect.FileDescriptor
 
 const file_proto_apps_v0_common_proto_rawDesc = "" +
 	"\n" +
 	"\x1aproto/apps/v0/common.proto\x12\aapps.v0\"\x14\n" +
-	"\x12HealthCheckRequest\"]\n" +
+	"\x12HealthCheckRequest\"\x8d\x01\n" +
 	"\x13HealthCheckResponse\x12\x18\n" +
 	"\ahealthy\x18\x01 \x01(\bR\ahealthy\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\tR\aversion\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\"\x82\x01\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x16\n" +
+	"\x06detail\x18\x05 \x01(\tR\x06detail\"\x82\x01\n" +
 	"\vSettingsMap\x128\n" +
 	"\x06values\x18\x01 \x03(\v2 .apps.v0.SettingsMap.ValuesEntryR\x06values\x1a9\n" +
 	"\vValuesEntry\x12\x10\n" +
@@ -335,10 +456,22 @@ const file_proto_apps_v0_common_proto_rawDesc = "" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
 	"\x04plan\x18\x03 \x01(\tR\x04plan\"\a\n" +
-	"\x05Empty\"=\n" +
+	"\x05Empty\"\xfc\x01\n" +
 	"\rErrorResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x12\n" +
-	"\x04code\x18\x02 \x01(\tR\x04codeB$Z\"github.com/neboloop/nebo-sdk-go/pbb\x06proto3"
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x1c\n" +
+	"\tretryable\x18\x03 \x01(\bR\tretryable\x12$\n" +
+	"\x0eretry_after_ms\x18\x04 \x01(\x03R\fretryAfterMs\x12=\n" +
+	"\adetails\x18\x05 \x03(\v2#.apps.v0.ErrorResponse.DetailsEntryR\adetails\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa6\x01\n" +
+	"\x0eDeliveryTarget\x12\x18\n" +
+	"\achannel\x18\x01 \x01(\tR\achannel\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x1b\n" +
+	"\tthread_id\x18\x03 \x01(\tR\bthreadId\x12\x16\n" +
+	"\x06format\x18\x04 \x01(\tR\x06format\x125\n" +
+	"\tfallbacks\x18\x05 \x03(\v2\x17.apps.v0.DeliveryTargetR\tfallbacksB$Z\"github.com/neboloop/nebo-sdk-go/pbb\x06proto3"
 
 var (
 	file_proto_apps_v0_common_proto_rawDescOnce sync.Once
@@ -352,7 +485,7 @@ func file_proto_apps_v0_common_proto_rawDescGZIP() []byte {
 	return file_proto_apps_v0_common_proto_rawDescData
 }
 
-var file_proto_apps_v0_common_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_apps_v0_common_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_proto_apps_v0_common_proto_goTypes = []any{
 	(*HealthCheckRequest)(nil),  // 0: apps.v0.HealthCheckRequest
 	(*HealthCheckResponse)(nil), // 1: apps.v0.HealthCheckResponse
@@ -360,15 +493,19 @@ var file_proto_apps_v0_common_proto_goTypes = []any{
 	(*UserContext)(nil),         // 3: apps.v0.UserContext
 	(*Empty)(nil),               // 4: apps.v0.Empty
 	(*ErrorResponse)(nil),       // 5: apps.v0.ErrorResponse
-	nil,                         // 6: apps.v0.SettingsMap.ValuesEntry
+	(*DeliveryTarget)(nil),      // 6: apps.v0.DeliveryTarget
+	nil,                         // 7: apps.v0.SettingsMap.ValuesEntry
+	nil,                         // 8: apps.v0.ErrorResponse.DetailsEntry
 }
 var file_proto_apps_v0_common_proto_depIdxs = []int32{
-	6, // 0: apps.v0.SettingsMap.values:type_name -> apps.v0.SettingsMap.ValuesEntry
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	7, // 0: apps.v0.SettingsMap.values:type_name -> apps.v0.SettingsMap.ValuesEntry
+	8, // 1: apps.v0.ErrorResponse.details:type_name -> apps.v0.ErrorResponse.DetailsEntry
+	6, // 2: apps.v0.DeliveryTarget.fallbacks:type_name -> apps.v0.DeliveryTarget
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_proto_apps_v0_common_proto_init() }
@@ -382,7 +519,7 @@ func file_proto_apps_v0_common_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_apps_v0_common_proto_rawDesc), len(file_proto_apps_v0_common_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -461,6 +461,8 @@ func (x *CancelRequest) GetRequestId() string {
 type CancelResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Cancelled     bool                   `protobuf:"varint,1,opt,name=cancelled,proto3" json:"cancelled,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,3,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -502,6 +504,20 @@ func (x *CancelResponse) GetCancelled() bool {
 	return false
 }
 
+func (x *CancelResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CancelResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
 var File_proto_apps_v0_gateway_proto protoreflect.FileDescriptor
 
 const file_proto_apps_v0_gateway_proto_rawDesc = "" +
@@ -542,9 +558,11 @@ const file_proto_apps_v0_gateway_proto_rawDesc = "" +
 	"\bcomplete\x18\x02 \x01(\bR\bcomplete\".\n" +
 	"\rCancelRequest\x12\x1d\n" +
 	"\n" +
-	"request_id\x18\x01 \x01(\tR\trequestId\".\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\"\x7f\n" +
 	"\x0eCancelResponse\x12\x1c\n" +
-	"\tcancelled\x18\x01 \x01(\bR\tcancelled2\xb9\x02\n" +
+	"\tcancelled\x18\x01 \x01(\bR\tcancelled\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x03 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail2\xb9\x02\n" +
 	"\x0eGatewayService\x12H\n" +
 	"\vHealthCheck\x12\x1b.apps.v0.HealthCheckRequest\x1a\x1c.apps.v0.HealthCheckResponse\x12:\n" +
 	"\x06Stream\x12\x17.apps.v0.GatewayRequest\x1a\x15.apps.v0.GatewayEvent0\x01\x123\n" +
@@ -575,31 +593,33 @@ var file_proto_apps_v0_gateway_proto_goTypes = []any{
 	(*CancelRequest)(nil),       // 6: apps.v0.CancelRequest
 	(*CancelResponse)(nil),      // 7: apps.v0.CancelResponse
 	(*UserContext)(nil),         // 8: apps.v0.UserContext
-	(*HealthCheckRequest)(nil),  // 9: apps.v0.HealthCheckRequest
-	(*SettingsMap)(nil),         // 10: apps.v0.SettingsMap
-	(*HealthCheckResponse)(nil), // 11: apps.v0.HealthCheckResponse
-	(*Empty)(nil),               // 12: apps.v0.Empty
+	(*ErrorResponse)(nil),       // 9: apps.v0.ErrorResponse
+	(*HealthCheckRequest)(nil),  // 10: apps.v0.HealthCheckRequest
+	(*SettingsMap)(nil),         // 11: apps.v0.SettingsMap
+	(*HealthCheckResponse)(nil), // 12: apps.v0.HealthCheckResponse
+	(*Empty)(nil),               // 13: apps.v0.Empty
 }
 var file_proto_apps_v0_gateway_proto_depIdxs = []int32{
 	1,  // 0: apps.v0.GatewayRequest.messages:type_name -> apps.v0.GatewayMessage
 	2,  // 1: apps.v0.GatewayRequest.tools:type_name -> apps.v0.GatewayToolDef
 	8,  // 2: apps.v0.GatewayRequest.user:type_name -> apps.v0.UserContext
 	3,  // 3: apps.v0.PollResponse.events:type_name -> apps.v0.GatewayEvent
-	9,  // 4: apps.v0.GatewayService.HealthCheck:input_type -> apps.v0.HealthCheckRequest
-	0,  // 5: apps.v0.GatewayService.Stream:input_type -> apps.v0.GatewayRequest
-	4,  // 6: apps.v0.GatewayService.Poll:input_type -> apps.v0.PollRequest
-	6,  // 7: apps.v0.GatewayService.Cancel:input_type -> apps.v0.CancelRequest
-	10, // 8: apps.v0.GatewayService.Configure:input_type -> apps.v0.SettingsMap
-	11, // 9: apps.v0.GatewayService.HealthCheck:output_type -> apps.v0.HealthCheckResponse
-	3,  // 10: apps.v0.GatewayService.Stream:output_type -> apps.v0.GatewayEvent
-	5,  // 11: apps.v0.GatewayService.Poll:output_type -> apps.v0.PollResponse
-	7,  // 12: apps.v0.GatewayService.Cancel:output_type -> apps.v0.CancelResponse
-	12, // 13: apps.v0.GatewayService.Configure:output_type -> apps.v0.Empty
-	9,  // [9:14] is the sub-list for method output_type
-	4,  // [4:9] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	9,  // 4: apps.v0.CancelResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	10, // 5: apps.v0.GatewayService.HealthCheck:input_type -> apps.v0.HealthCheckRequest
+	0,  // 6: apps.v0.GatewayService.Stream:input_type -> apps.v0.GatewayRequest
+	4,  // 7: apps.v0.GatewayService.Poll:input_type -> apps.v0.PollRequest
+	6,  // 8: apps.v0.GatewayService.Cancel:input_type -> apps.v0.CancelRequest
+	11, // 9: apps.v0.GatewayService.Configure:input_type -> apps.v0.SettingsMap
+	12, // 10: apps.v0.GatewayService.HealthCheck:output_type -> apps.v0.HealthCheckResponse
+	3,  // 11: apps.v0.GatewayService.Stream:output_type -> apps.v0.GatewayEvent
+	5,  // 12: apps.v0.GatewayService.Poll:output_type -> apps.v0.PollResponse
+	7,  // 13: apps.v0.GatewayService.Cancel:output_type -> apps.v0.CancelResponse
+	13, // 14: apps.v0.GatewayService.Configure:output_type -> apps.v0.Empty
+	10, // [10:15] is the sub-list for method output_type
+	5,  // [5:10] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_proto_apps_v0_gateway_proto_init() }
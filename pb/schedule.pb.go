@@ -23,23 +23,28 @@ const (
 
 // Schedule represents a single scheduled task.
 type Schedule struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Expression    string                 `protobuf:"bytes,3,opt,name=expression,proto3" json:"expression,omitempty"`             // Cron expression (6-field with seconds)
-	TaskType      string                 `protobuf:"bytes,4,opt,name=task_type,json=taskType,proto3" json:"task_type,omitempty"` // "bash" or "agent"
-	Command       string                 `protobuf:"bytes,5,opt,name=command,proto3" json:"command,omitempty"`                   // Shell command (for bash tasks)
-	Message       string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`                   // Agent prompt (for agent tasks)
-	Deliver       string                 `protobuf:"bytes,7,opt,name=deliver,proto3" json:"deliver,omitempty"`                   // JSON: {"channel":"telegram","to":"123"}
-	Enabled       bool                   `protobuf:"varint,8,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	LastRun       string                 `protobuf:"bytes,9,opt,name=last_run,json=lastRun,proto3" json:"last_run,omitempty"`  // RFC3339 timestamp
-	NextRun       string                 `protobuf:"bytes,10,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"` // RFC3339 timestamp
-	RunCount      int64                  `protobuf:"varint,11,opt,name=run_count,json=runCount,proto3" json:"run_count,omitempty"`
-	LastError     string                 `protobuf:"bytes,12,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC3339 timestamp
-	Metadata      map[string]string      `protobuf:"bytes,14,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Expression          string                 `protobuf:"bytes,3,opt,name=expression,proto3" json:"expression,omitempty"`             // Cron expression (6-field with seconds), or a description of trigger
+	TaskType            string                 `protobuf:"bytes,4,opt,name=task_type,json=taskType,proto3" json:"task_type,omitempty"` // "bash" or "agent"
+	Command             string                 `protobuf:"bytes,5,opt,name=command,proto3" json:"command,omitempty"`                   // Shell command (for bash tasks)
+	Message             string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`                   // Agent prompt (for agent tasks)
+	Deliver             string                 `protobuf:"bytes,7,opt,name=deliver,proto3" json:"deliver,omitempty"`                   // Legacy JSON: {"channel":"telegram","to":"123"}; mirrors delivery
+	Enabled             bool                   `protobuf:"varint,8,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	LastRun             string                 `protobuf:"bytes,9,opt,name=last_run,json=lastRun,proto3" json:"last_run,omitempty"`  // RFC3339 timestamp
+	NextRun             string                 `protobuf:"bytes,10,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"` // RFC3339 timestamp
+	RunCount            int64                  `protobuf:"varint,11,opt,name=run_count,json=runCount,proto3" json:"run_count,omitempty"`
+	LastError           string                 `protobuf:"bytes,12,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CreatedAt           string                 `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC3339 timestamp
+	Metadata            map[string]string      `protobuf:"bytes,14,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Trigger             *TriggerSpec           `protobuf:"bytes,15,opt,name=trigger,proto3" json:"trigger,omitempty"` // When the schedule fires; takes precedence over expression
+	Policy              *ExecutionPolicy       `protobuf:"bytes,16,opt,name=policy,proto3" json:"policy,omitempty"`   // Overlap, concurrency and retry handling
+	ConsecutiveFailures int32                  `protobuf:"varint,17,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	DeadLettered        bool                   `protobuf:"varint,18,opt,name=dead_lettered,json=deadLettered,proto3" json:"dead_lettered,omitempty"` // Disabled after policy.dead_letter_after consecutive failures
+	Delivery            []*DeliveryTarget      `protobuf:"bytes,19,rep,name=delivery,proto3" json:"delivery,omitempty"`                              // Where to send the task's output; every target receives it
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *Schedule) Reset() {
@@ -170,25 +175,313 @@ func (x *Schedule) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *Schedule) GetTrigger() *TriggerSpec {
+	if x != nil {
+		return x.Trigger
+	}
+	return nil
+}
+
+func (x *Schedule) GetPolicy() *ExecutionPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *Schedule) GetConsecutiveFailures() int32 {
+	if x != nil {
+		return x.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (x *Schedule) GetDeadLettered() bool {
+	if x != nil {
+		return x.DeadLettered
+	}
+	return false
+}
+
+func (x *Schedule) GetDelivery() []*DeliveryTarget {
+	if x != nil {
+		return x.Delivery
+	}
+	return nil
+}
+
+// ExecutionPolicy controls what happens when runs overlap or fail.
+type ExecutionPolicy struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Overlap             string                 `protobuf:"bytes,1,opt,name=overlap,proto3" json:"overlap,omitempty"`                                                       // "allow" (default), "skip", "queue", "replace"
+	MaxConcurrent       int32                  `protobuf:"varint,2,opt,name=max_concurrent,json=maxConcurrent,proto3" json:"max_concurrent,omitempty"`                     // Runs in flight before overlap applies (0 = 1, or unlimited for "allow")
+	MaxRetries          int32                  `protobuf:"varint,3,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`                              // Retries after a failed run (0 = none)
+	RetryBackoffSeconds int64                  `protobuf:"varint,4,opt,name=retry_backoff_seconds,json=retryBackoffSeconds,proto3" json:"retry_backoff_seconds,omitempty"` // Delay before the first retry, doubled on each further attempt
+	MaxBackoffSeconds   int64                  `protobuf:"varint,5,opt,name=max_backoff_seconds,json=maxBackoffSeconds,proto3" json:"max_backoff_seconds,omitempty"`       // Upper bound on the retry delay (0 = none)
+	DeadLetterAfter     int32                  `protobuf:"varint,6,opt,name=dead_letter_after,json=deadLetterAfter,proto3" json:"dead_letter_after,omitempty"`             // Disable after this many consecutive failed runs (0 = never)
+	RunTimeoutSeconds   int64                  `protobuf:"varint,7,opt,name=run_timeout_seconds,json=runTimeoutSeconds,proto3" json:"run_timeout_seconds,omitempty"`       // Stop counting a run as in flight if no finish report arrives (0 = 1 hour)
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ExecutionPolicy) Reset() {
+	*x = ExecutionPolicy{}
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionPolicy) ProtoMessage() {}
+
+func (x *ExecutionPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutionPolicy.ProtoReflect.Descriptor instead.
+func (*ExecutionPolicy) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExecutionPolicy) GetOverlap() string {
+	if x != nil {
+		return x.Overlap
+	}
+	return ""
+}
+
+func (x *ExecutionPolicy) GetMaxConcurrent() int32 {
+	if x != nil {
+		return x.MaxConcurrent
+	}
+	return 0
+}
+
+func (x *ExecutionPolicy) GetMaxRetries() int32 {
+	if x != nil {
+		return x.MaxRetries
+	}
+	return 0
+}
+
+func (x *ExecutionPolicy) GetRetryBackoffSeconds() int64 {
+	if x != nil {
+		return x.RetryBackoffSeconds
+	}
+	return 0
+}
+
+func (x *ExecutionPolicy) GetMaxBackoffSeconds() int64 {
+	if x != nil {
+		return x.MaxBackoffSeconds
+	}
+	return 0
+}
+
+func (x *ExecutionPolicy) GetDeadLetterAfter() int32 {
+	if x != nil {
+		return x.DeadLetterAfter
+	}
+	return 0
+}
+
+func (x *ExecutionPolicy) GetRunTimeoutSeconds() int64 {
+	if x != nil {
+		return x.RunTimeoutSeconds
+	}
+	return 0
+}
+
+// TriggerSpec describes when a schedule fires.
+// Exactly one of cron, interval_seconds, at or rrule should be set.
+type TriggerSpec struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Kind:
+	//
+	//	*TriggerSpec_Cron
+	//	*TriggerSpec_IntervalSeconds
+	//	*TriggerSpec_At
+	//	*TriggerSpec_Rrule
+	Kind          isTriggerSpec_Kind `protobuf_oneof:"kind"`
+	Timezone      string             `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`                                 // IANA zone the trigger is evaluated in (default: app local)
+	JitterSeconds int64              `protobuf:"varint,6,opt,name=jitter_seconds,json=jitterSeconds,proto3" json:"jitter_seconds,omitempty"` // Random delay in [0, jitter) added to each run
+	StartAt       string             `protobuf:"bytes,7,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`                    // RFC3339; no runs before this time
+	EndAt         string             `protobuf:"bytes,8,opt,name=end_at,json=endAt,proto3" json:"end_at,omitempty"`                          // RFC3339; no runs after this time
+	MaxRuns       int64              `protobuf:"varint,9,opt,name=max_runs,json=maxRuns,proto3" json:"max_runs,omitempty"`                   // Stop after this many runs (0 = unlimited)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerSpec) Reset() {
+	*x = TriggerSpec{}
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerSpec) ProtoMessage() {}
+
+func (x *TriggerSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerSpec.ProtoReflect.Descriptor instead.
+func (*TriggerSpec) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TriggerSpec) GetKind() isTriggerSpec_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *TriggerSpec) GetCron() string {
+	if x != nil {
+		if x, ok := x.Kind.(*TriggerSpec_Cron); ok {
+			return x.Cron
+		}
+	}
+	return ""
+}
+
+func (x *TriggerSpec) GetIntervalSeconds() int64 {
+	if x != nil {
+		if x, ok := x.Kind.(*TriggerSpec_IntervalSeconds); ok {
+			return x.IntervalSeconds
+		}
+	}
+	return 0
+}
+
+func (x *TriggerSpec) GetAt() string {
+	if x != nil {
+		if x, ok := x.Kind.(*TriggerSpec_At); ok {
+			return x.At
+		}
+	}
+	return ""
+}
+
+func (x *TriggerSpec) GetRrule() string {
+	if x != nil {
+		if x, ok := x.Kind.(*TriggerSpec_Rrule); ok {
+			return x.Rrule
+		}
+	}
+	return ""
+}
+
+func (x *TriggerSpec) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *TriggerSpec) GetJitterSeconds() int64 {
+	if x != nil {
+		return x.JitterSeconds
+	}
+	return 0
+}
+
+func (x *TriggerSpec) GetStartAt() string {
+	if x != nil {
+		return x.StartAt
+	}
+	return ""
+}
+
+func (x *TriggerSpec) GetEndAt() string {
+	if x != nil {
+		return x.EndAt
+	}
+	return ""
+}
+
+func (x *TriggerSpec) GetMaxRuns() int64 {
+	if x != nil {
+		return x.MaxRuns
+	}
+	return 0
+}
+
+type isTriggerSpec_Kind interface {
+	isTriggerSpec_Kind()
+}
+
+type TriggerSpec_Cron struct {
+	Cron string `protobuf:"bytes,1,opt,name=cron,proto3,oneof"` // 6-field cron expression with seconds
+}
+
+type TriggerSpec_IntervalSeconds struct {
+	IntervalSeconds int64 `protobuf:"varint,2,opt,name=interval_seconds,json=intervalSeconds,proto3,oneof"` // Fixed interval, anchored at start_at (or creation time)
+}
+
+type TriggerSpec_At struct {
+	At string `protobuf:"bytes,3,opt,name=at,proto3,oneof"` // RFC3339 timestamp for a one-shot run
+}
+
+type TriggerSpec_Rrule struct {
+	Rrule string `protobuf:"bytes,4,opt,name=rrule,proto3,oneof"` // RFC 5545 recurrence rule, e.g. "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1"
+}
+
+func (*TriggerSpec_Cron) isTriggerSpec_Kind() {}
+
+func (*TriggerSpec_IntervalSeconds) isTriggerSpec_Kind() {}
+
+func (*TriggerSpec_At) isTriggerSpec_Kind() {}
+
+func (*TriggerSpec_Rrule) isTriggerSpec_Kind() {}
+
 // ScheduleTrigger is emitted by the app when a schedule fires.
 // Denormalized so Nebo can route without an extra lookup.
 type ScheduleTrigger struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ScheduleId    string                 `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	TaskType      string                 `protobuf:"bytes,3,opt,name=task_type,json=taskType,proto3" json:"task_type,omitempty"`
-	Command       string                 `protobuf:"bytes,4,opt,name=command,proto3" json:"command,omitempty"`
-	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
-	Deliver       string                 `protobuf:"bytes,6,opt,name=deliver,proto3" json:"deliver,omitempty"`
-	FiredAt       string                 `protobuf:"bytes,7,opt,name=fired_at,json=firedAt,proto3" json:"fired_at,omitempty"` // RFC3339 timestamp
-	Metadata      map[string]string      `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	ScheduleId         string                 `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	Name               string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	TaskType           string                 `protobuf:"bytes,3,opt,name=task_type,json=taskType,proto3" json:"task_type,omitempty"`
+	Command            string                 `protobuf:"bytes,4,opt,name=command,proto3" json:"command,omitempty"`
+	Message            string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	Deliver            string                 `protobuf:"bytes,6,opt,name=deliver,proto3" json:"deliver,omitempty"`
+	FiredAt            string                 `protobuf:"bytes,7,opt,name=fired_at,json=firedAt,proto3" json:"fired_at,omitempty"` // RFC3339 timestamp
+	Metadata           map[string]string      `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	TriggerId          string                 `protobuf:"bytes,9,opt,name=trigger_id,json=triggerId,proto3" json:"trigger_id,omitempty"`                               // Unique per firing; echoed in ExecutionReport
+	Attempt            int32                  `protobuf:"varint,10,opt,name=attempt,proto3" json:"attempt,omitempty"`                                                  // 1 for a scheduled run, 2+ for retries
+	ReplacesTriggerIds []string               `protobuf:"bytes,11,rep,name=replaces_trigger_ids,json=replacesTriggerIds,proto3" json:"replaces_trigger_ids,omitempty"` // Runs superseded under overlap "replace"; Nebo should cancel them
+	Delivery           []*DeliveryTarget      `protobuf:"bytes,12,rep,name=delivery,proto3" json:"delivery,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *ScheduleTrigger) Reset() {
 	*x = ScheduleTrigger{}
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[1]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -200,7 +493,7 @@ func (x *ScheduleTrigger) String() string {
 func (*ScheduleTrigger) ProtoMessage() {}
 
 func (x *ScheduleTrigger) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[1]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -213,7 +506,7 @@ func (x *ScheduleTrigger) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScheduleTrigger.ProtoReflect.Descriptor instead.
 func (*ScheduleTrigger) Descriptor() ([]byte, []int) {
-	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{1}
+	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ScheduleTrigger) GetScheduleId() string {
@@ -272,22 +565,199 @@ func (x *ScheduleTrigger) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *ScheduleTrigger) GetTriggerId() string {
+	if x != nil {
+		return x.TriggerId
+	}
+	return ""
+}
+
+func (x *ScheduleTrigger) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *ScheduleTrigger) GetReplacesTriggerIds() []string {
+	if x != nil {
+		return x.ReplacesTriggerIds
+	}
+	return nil
+}
+
+func (x *ScheduleTrigger) GetDelivery() []*DeliveryTarget {
+	if x != nil {
+		return x.Delivery
+	}
+	return nil
+}
+
+// ExecutionReport tells the app how a triggered task went.
+// Nebo sends one report with state "started" and one with "succeeded" or "failed".
+type ExecutionReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TriggerId     string                 `protobuf:"bytes,1,opt,name=trigger_id,json=triggerId,proto3" json:"trigger_id,omitempty"`
+	ScheduleName  string                 `protobuf:"bytes,2,opt,name=schedule_name,json=scheduleName,proto3" json:"schedule_name,omitempty"`
+	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`                             // "started", "succeeded", "failed"
+	StartedAt     string                 `protobuf:"bytes,4,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`    // RFC3339
+	FinishedAt    string                 `protobuf:"bytes,5,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"` // RFC3339, set for succeeded/failed
+	Output        string                 `protobuf:"bytes,6,opt,name=output,proto3" json:"output,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecutionReport) Reset() {
+	*x = ExecutionReport{}
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionReport) ProtoMessage() {}
+
+func (x *ExecutionReport) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutionReport.ProtoReflect.Descriptor instead.
+func (*ExecutionReport) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExecutionReport) GetTriggerId() string {
+	if x != nil {
+		return x.TriggerId
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetScheduleName() string {
+	if x != nil {
+		return x.ScheduleName
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ExecutionReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,2,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecutionReportResponse) Reset() {
+	*x = ExecutionReportResponse{}
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionReportResponse) ProtoMessage() {}
+
+func (x *ExecutionReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutionReportResponse.ProtoReflect.Descriptor instead.
+func (*ExecutionReportResponse) Descriptor() ([]byte, []int) {
+	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ExecutionReportResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ExecutionReportResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
 type CreateScheduleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Expression    string                 `protobuf:"bytes,2,opt,name=expression,proto3" json:"expression,omitempty"`
+	Expression    string                 `protobuf:"bytes,2,opt,name=expression,proto3" json:"expression,omitempty"` // Cron shorthand; ignored when trigger is set
 	TaskType      string                 `protobuf:"bytes,3,opt,name=task_type,json=taskType,proto3" json:"task_type,omitempty"`
 	Command       string                 `protobuf:"bytes,4,opt,name=command,proto3" json:"command,omitempty"`
 	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
 	Deliver       string                 `protobuf:"bytes,6,opt,name=deliver,proto3" json:"deliver,omitempty"`
 	Metadata      map[string]string      `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Trigger       *TriggerSpec           `protobuf:"bytes,8,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	Policy        *ExecutionPolicy       `protobuf:"bytes,9,opt,name=policy,proto3" json:"policy,omitempty"`
+	Delivery      []*DeliveryTarget      `protobuf:"bytes,10,rep,name=delivery,proto3" json:"delivery,omitempty"` // Takes precedence over deliver
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateScheduleRequest) Reset() {
 	*x = CreateScheduleRequest{}
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[2]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -299,7 +769,7 @@ func (x *CreateScheduleRequest) String() string {
 func (*CreateScheduleRequest) ProtoMessage() {}
 
 func (x *CreateScheduleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[2]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -312,7 +782,7 @@ func (x *CreateScheduleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateScheduleRequest.ProtoReflect.Descriptor instead.
 func (*CreateScheduleRequest) Descriptor() ([]byte, []int) {
-	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{2}
+	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CreateScheduleRequest) GetName() string {
@@ -364,6 +834,27 @@ func (x *CreateScheduleRequest) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *CreateScheduleRequest) GetTrigger() *TriggerSpec {
+	if x != nil {
+		return x.Trigger
+	}
+	return nil
+}
+
+func (x *CreateScheduleRequest) GetPolicy() *ExecutionPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *CreateScheduleRequest) GetDelivery() []*DeliveryTarget {
+	if x != nil {
+		return x.Delivery
+	}
+	return nil
+}
+
 type GetScheduleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -373,7 +864,7 @@ type GetScheduleRequest struct {
 
 func (x *GetScheduleRequest) Reset() {
 	*x = GetScheduleRequest{}
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[3]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -385,7 +876,7 @@ func (x *GetScheduleRequest) String() string {
 func (*GetScheduleRequest) ProtoMessage() {}
 
 func (x *GetScheduleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[3]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -398,7 +889,7 @@ func (x *GetScheduleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetScheduleRequest.ProtoReflect.Descriptor instead.
 func (*GetScheduleRequest) Descriptor() ([]byte, []int) {
-	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{3}
+	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetScheduleRequest) GetName() string {
@@ -413,13 +904,14 @@ type ListSchedulesRequest struct {
 	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
 	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
 	EnabledOnly   bool                   `protobuf:"varint,3,opt,name=enabled_only,json=enabledOnly,proto3" json:"enabled_only,omitempty"`
+	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"` // Opaque; from ListSchedulesResponse.next_cursor
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListSchedulesRequest) Reset() {
 	*x = ListSchedulesRequest{}
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[4]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -431,7 +923,7 @@ func (x *ListSchedulesRequest) String() string {
 func (*ListSchedulesRequest) ProtoMessage() {}
 
 func (x *ListSchedulesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[4]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -444,7 +936,7 @@ func (x *ListSchedulesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSchedulesRequest.ProtoReflect.Descriptor instead.
 func (*ListSchedulesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{4}
+	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ListSchedulesRequest) GetLimit() int32 {
@@ -468,17 +960,27 @@ func (x *ListSchedulesRequest) GetEnabledOnly() bool {
 	return false
 }
 
+func (x *ListSchedulesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 type ListSchedulesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Schedules     []*Schedule            `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
 	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,4,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListSchedulesResponse) Reset() {
 	*x = ListSchedulesResponse{}
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[5]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -490,7 +992,7 @@ func (x *ListSchedulesResponse) String() string {
 func (*ListSchedulesResponse) ProtoMessage() {}
 
 func (x *ListSchedulesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[5]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -503,7 +1005,7 @@ func (x *ListSchedulesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSchedulesResponse.ProtoReflect.Descriptor instead.
 func (*ListSchedulesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{5}
+	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ListSchedulesResponse) GetSchedules() []*Schedule {
@@ -520,6 +1022,27 @@ func (x *ListSchedulesResponse) GetTotal() int64 {
 	return 0
 }
 
+func (x *ListSchedulesResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListSchedulesResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
+func (x *ListSchedulesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 type UpdateScheduleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -529,13 +1052,16 @@ type UpdateScheduleRequest struct {
 	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
 	Deliver       string                 `protobuf:"bytes,6,opt,name=deliver,proto3" json:"deliver,omitempty"`
 	Metadata      map[string]string      `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Trigger       *TriggerSpec           `protobuf:"bytes,8,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	Policy        *ExecutionPolicy       `protobuf:"bytes,9,opt,name=policy,proto3" json:"policy,omitempty"`
+	Delivery      []*DeliveryTarget      `protobuf:"bytes,10,rep,name=delivery,proto3" json:"delivery,omitempty"` // Takes precedence over deliver
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateScheduleRequest) Reset() {
 	*x = UpdateScheduleRequest{}
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[6]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -547,7 +1073,7 @@ func (x *UpdateScheduleRequest) String() string {
 func (*UpdateScheduleRequest) ProtoMessage() {}
 
 func (x *UpdateScheduleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[6]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -560,7 +1086,7 @@ func (x *UpdateScheduleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateScheduleRequest.ProtoReflect.Descriptor instead.
 func (*UpdateScheduleRequest) Descriptor() ([]byte, []int) {
-	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{6}
+	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *UpdateScheduleRequest) GetName() string {
@@ -612,17 +1138,39 @@ func (x *UpdateScheduleRequest) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *UpdateScheduleRequest) GetTrigger() *TriggerSpec {
+	if x != nil {
+		return x.Trigger
+	}
+	return nil
+}
+
+func (x *UpdateScheduleRequest) GetPolicy() *ExecutionPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *UpdateScheduleRequest) GetDelivery() []*DeliveryTarget {
+	if x != nil {
+		return x.Delivery
+	}
+	return nil
+}
+
 type ScheduleResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Schedule      *Schedule              `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
 	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,3,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ScheduleResponse) Reset() {
 	*x = ScheduleResponse{}
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[7]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -634,7 +1182,7 @@ func (x *ScheduleResponse) String() string {
 func (*ScheduleResponse) ProtoMessage() {}
 
 func (x *ScheduleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[7]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -647,7 +1195,7 @@ func (x *ScheduleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScheduleResponse.ProtoReflect.Descriptor instead.
 func (*ScheduleResponse) Descriptor() ([]byte, []int) {
-	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{7}
+	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ScheduleResponse) GetSchedule() *Schedule {
@@ -664,6 +1212,13 @@ func (x *ScheduleResponse) GetError() string {
 	return ""
 }
 
+func (x *ScheduleResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
 type DeleteScheduleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -673,7 +1228,7 @@ type DeleteScheduleRequest struct {
 
 func (x *DeleteScheduleRequest) Reset() {
 	*x = DeleteScheduleRequest{}
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[8]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -685,7 +1240,7 @@ func (x *DeleteScheduleRequest) String() string {
 func (*DeleteScheduleRequest) ProtoMessage() {}
 
 func (x *DeleteScheduleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[8]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -698,7 +1253,7 @@ func (x *DeleteScheduleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteScheduleRequest.ProtoReflect.Descriptor instead.
 func (*DeleteScheduleRequest) Descriptor() ([]byte, []int) {
-	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{8}
+	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *DeleteScheduleRequest) GetName() string {
@@ -712,13 +1267,14 @@ type DeleteScheduleResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,3,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeleteScheduleResponse) Reset() {
 	*x = DeleteScheduleResponse{}
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[9]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -730,7 +1286,7 @@ func (x *DeleteScheduleResponse) String() string {
 func (*DeleteScheduleResponse) ProtoMessage() {}
 
 func (x *DeleteScheduleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[9]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -743,7 +1299,7 @@ func (x *DeleteScheduleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteScheduleResponse.ProtoReflect.Descriptor instead.
 func (*DeleteScheduleResponse) Descriptor() ([]byte, []int) {
-	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{9}
+	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *DeleteScheduleResponse) GetSuccess() bool {
@@ -760,6 +1316,13 @@ func (x *DeleteScheduleResponse) GetError() string {
 	return ""
 }
 
+func (x *DeleteScheduleResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
 type ScheduleNameRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -769,7 +1332,7 @@ type ScheduleNameRequest struct {
 
 func (x *ScheduleNameRequest) Reset() {
 	*x = ScheduleNameRequest{}
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[10]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -781,7 +1344,7 @@ func (x *ScheduleNameRequest) String() string {
 func (*ScheduleNameRequest) ProtoMessage() {}
 
 func (x *ScheduleNameRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[10]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -794,7 +1357,7 @@ func (x *ScheduleNameRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScheduleNameRequest.ProtoReflect.Descriptor instead.
 func (*ScheduleNameRequest) Descriptor() ([]byte, []int) {
-	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{10}
+	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ScheduleNameRequest) GetName() string {
@@ -809,13 +1372,14 @@ type TriggerResponse struct {
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Output        string                 `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
 	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,4,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TriggerResponse) Reset() {
 	*x = TriggerResponse{}
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[11]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -827,7 +1391,7 @@ func (x *TriggerResponse) String() string {
 func (*TriggerResponse) ProtoMessage() {}
 
 func (x *TriggerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[11]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -840,7 +1404,7 @@ func (x *TriggerResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TriggerResponse.ProtoReflect.Descriptor instead.
 func (*TriggerResponse) Descriptor() ([]byte, []int) {
-	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{11}
+	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *TriggerResponse) GetSuccess() bool {
@@ -864,18 +1428,26 @@ func (x *TriggerResponse) GetError() string {
 	return ""
 }
 
+func (x *TriggerResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
 type ScheduleHistoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"` // Opaque; from ScheduleHistoryResponse.next_cursor
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ScheduleHistoryRequest) Reset() {
 	*x = ScheduleHistoryRequest{}
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[12]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -887,7 +1459,7 @@ func (x *ScheduleHistoryRequest) String() string {
 func (*ScheduleHistoryRequest) ProtoMessage() {}
 
 func (x *ScheduleHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[12]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -900,7 +1472,7 @@ func (x *ScheduleHistoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScheduleHistoryRequest.ProtoReflect.Descriptor instead.
 func (*ScheduleHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{12}
+	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *ScheduleHistoryRequest) GetName() string {
@@ -924,17 +1496,27 @@ func (x *ScheduleHistoryRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *ScheduleHistoryRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 type ScheduleHistoryResponse struct {
 	state         protoimpl.MessageState  `protogen:"open.v1"`
 	Entries       []*ScheduleHistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
 	Total         int64                   `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Error         string                  `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorDetail   *ErrorResponse          `protobuf:"bytes,4,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"`
+	NextCursor    string                  `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ScheduleHistoryResponse) Reset() {
 	*x = ScheduleHistoryResponse{}
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[13]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -946,7 +1528,7 @@ func (x *ScheduleHistoryResponse) String() string {
 func (*ScheduleHistoryResponse) ProtoMessage() {}
 
 func (x *ScheduleHistoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[13]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -959,7 +1541,7 @@ func (x *ScheduleHistoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScheduleHistoryResponse.ProtoReflect.Descriptor instead.
 func (*ScheduleHistoryResponse) Descriptor() ([]byte, []int) {
-	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{13}
+	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ScheduleHistoryResponse) GetEntries() []*ScheduleHistoryEntry {
@@ -976,6 +1558,27 @@ func (x *ScheduleHistoryResponse) GetTotal() int64 {
 	return 0
 }
 
+func (x *ScheduleHistoryResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ScheduleHistoryResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
+func (x *ScheduleHistoryResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 type ScheduleHistoryEntry struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -985,13 +1588,15 @@ type ScheduleHistoryEntry struct {
 	Success       bool                   `protobuf:"varint,5,opt,name=success,proto3" json:"success,omitempty"`
 	Output        string                 `protobuf:"bytes,6,opt,name=output,proto3" json:"output,omitempty"`
 	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	TriggerId     string                 `protobuf:"bytes,8,opt,name=trigger_id,json=triggerId,proto3" json:"trigger_id,omitempty"` // ScheduleTrigger.trigger_id of the run
+	Attempt       int32                  `protobuf:"varint,9,opt,name=attempt,proto3" json:"attempt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ScheduleHistoryEntry) Reset() {
 	*x = ScheduleHistoryEntry{}
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[14]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1003,7 +1608,7 @@ func (x *ScheduleHistoryEntry) String() string {
 func (*ScheduleHistoryEntry) ProtoMessage() {}
 
 func (x *ScheduleHistoryEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_apps_v0_schedule_proto_msgTypes[14]
+	mi := &file_proto_apps_v0_schedule_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1016,7 +1621,7 @@ func (x *ScheduleHistoryEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScheduleHistoryEntry.ProtoReflect.Descriptor instead.
 func (*ScheduleHistoryEntry) Descriptor() ([]byte, []int) {
-	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{14}
+	return file_proto_apps_v0_schedule_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ScheduleHistoryEntry) GetId() string {
@@ -1068,11 +1673,25 @@ func (x *ScheduleHistoryEntry) GetError() string {
 	return ""
 }
 
+func (x *ScheduleHistoryEntry) GetTriggerId() string {
+	if x != nil {
+		return x.TriggerId
+	}
+	return ""
+}
+
+func (x *ScheduleHistoryEntry) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
 var File_proto_apps_v0_schedule_proto protoreflect.FileDescriptor
 
 const file_proto_apps_v0_schedule_proto_rawDesc = "" +
 	"\n" +
-	"\x1cproto/apps/v0/schedule.proto\x12\aapps.v0\x1a\x1aproto/apps/v0/common.proto\"\xde\x03\n" +
+	"\x1cproto/apps/v0/schedule.proto\x12\aapps.v0\x1a\x1aproto/apps/v0/common.proto\"\xcd\x05\n" +
 	"\bSchedule\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1e\n" +
@@ -1092,10 +1711,35 @@ const file_proto_apps_v0_schedule_proto_rawDesc = "" +
 	"last_error\x18\f \x01(\tR\tlastError\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\r \x01(\tR\tcreatedAt\x12;\n" +
-	"\bmetadata\x18\x0e \x03(\v2\x1f.apps.v0.Schedule.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x0e \x03(\v2\x1f.apps.v0.Schedule.MetadataEntryR\bmetadata\x12.\n" +
+	"\atrigger\x18\x0f \x01(\v2\x14.apps.v0.TriggerSpecR\atrigger\x120\n" +
+	"\x06policy\x18\x10 \x01(\v2\x18.apps.v0.ExecutionPolicyR\x06policy\x121\n" +
+	"\x14consecutive_failures\x18\x11 \x01(\x05R\x13consecutiveFailures\x12#\n" +
+	"\rdead_lettered\x18\x12 \x01(\bR\fdeadLettered\x123\n" +
+	"\bdelivery\x18\x13 \x03(\v2\x17.apps.v0.DeliveryTargetR\bdelivery\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xcd\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb3\x02\n" +
+	"\x0fExecutionPolicy\x12\x18\n" +
+	"\aoverlap\x18\x01 \x01(\tR\aoverlap\x12%\n" +
+	"\x0emax_concurrent\x18\x02 \x01(\x05R\rmaxConcurrent\x12\x1f\n" +
+	"\vmax_retries\x18\x03 \x01(\x05R\n" +
+	"maxRetries\x122\n" +
+	"\x15retry_backoff_seconds\x18\x04 \x01(\x03R\x13retryBackoffSeconds\x12.\n" +
+	"\x13max_backoff_seconds\x18\x05 \x01(\x03R\x11maxBackoffSeconds\x12*\n" +
+	"\x11dead_letter_after\x18\x06 \x01(\x05R\x0fdeadLetterAfter\x12.\n" +
+	"\x13run_timeout_seconds\x18\a \x01(\x03R\x11runTimeoutSeconds\"\x92\x02\n" +
+	"\vTriggerSpec\x12\x14\n" +
+	"\x04cron\x18\x01 \x01(\tH\x00R\x04cron\x12+\n" +
+	"\x10interval_seconds\x18\x02 \x01(\x03H\x00R\x0fintervalSeconds\x12\x10\n" +
+	"\x02at\x18\x03 \x01(\tH\x00R\x02at\x12\x16\n" +
+	"\x05rrule\x18\x04 \x01(\tH\x00R\x05rrule\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\x12%\n" +
+	"\x0ejitter_seconds\x18\x06 \x01(\x03R\rjitterSeconds\x12\x19\n" +
+	"\bstart_at\x18\a \x01(\tR\astartAt\x12\x15\n" +
+	"\x06end_at\x18\b \x01(\tR\x05endAt\x12\x19\n" +
+	"\bmax_runs\x18\t \x01(\x03R\amaxRunsB\x06\n" +
+	"\x04kind\"\xed\x03\n" +
 	"\x0fScheduleTrigger\x12\x1f\n" +
 	"\vschedule_id\x18\x01 \x01(\tR\n" +
 	"scheduleId\x12\x12\n" +
@@ -1105,10 +1749,30 @@ const file_proto_apps_v0_schedule_proto_rawDesc = "" +
 	"\amessage\x18\x05 \x01(\tR\amessage\x12\x18\n" +
 	"\adeliver\x18\x06 \x01(\tR\adeliver\x12\x19\n" +
 	"\bfired_at\x18\a \x01(\tR\afiredAt\x12B\n" +
-	"\bmetadata\x18\b \x03(\v2&.apps.v0.ScheduleTrigger.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\b \x03(\v2&.apps.v0.ScheduleTrigger.MetadataEntryR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"trigger_id\x18\t \x01(\tR\ttriggerId\x12\x18\n" +
+	"\aattempt\x18\n" +
+	" \x01(\x05R\aattempt\x120\n" +
+	"\x14replaces_trigger_ids\x18\v \x03(\tR\x12replacesTriggerIds\x123\n" +
+	"\bdelivery\x18\f \x03(\v2\x17.apps.v0.DeliveryTargetR\bdelivery\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbd\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd9\x01\n" +
+	"\x0fExecutionReport\x12\x1d\n" +
+	"\n" +
+	"trigger_id\x18\x01 \x01(\tR\ttriggerId\x12#\n" +
+	"\rschedule_name\x18\x02 \x01(\tR\fscheduleName\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x04 \x01(\tR\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\x05 \x01(\tR\n" +
+	"finishedAt\x12\x16\n" +
+	"\x06output\x18\x06 \x01(\tR\x06output\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"j\n" +
+	"\x17ExecutionReportResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x02 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\"\xd4\x03\n" +
 	"\x15CreateScheduleRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1e\n" +
 	"\n" +
@@ -1118,19 +1782,28 @@ const file_proto_apps_v0_schedule_proto_rawDesc = "" +
 	"\acommand\x18\x04 \x01(\tR\acommand\x12\x18\n" +
 	"\amessage\x18\x05 \x01(\tR\amessage\x12\x18\n" +
 	"\adeliver\x18\x06 \x01(\tR\adeliver\x12H\n" +
-	"\bmetadata\x18\a \x03(\v2,.apps.v0.CreateScheduleRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\a \x03(\v2,.apps.v0.CreateScheduleRequest.MetadataEntryR\bmetadata\x12.\n" +
+	"\atrigger\x18\b \x01(\v2\x14.apps.v0.TriggerSpecR\atrigger\x120\n" +
+	"\x06policy\x18\t \x01(\v2\x18.apps.v0.ExecutionPolicyR\x06policy\x123\n" +
+	"\bdelivery\x18\n" +
+	" \x03(\v2\x17.apps.v0.DeliveryTargetR\bdelivery\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"(\n" +
 	"\x12GetScheduleRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"g\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x7f\n" +
 	"\x14ListSchedulesRequest\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12!\n" +
-	"\fenabled_only\x18\x03 \x01(\bR\venabledOnly\"^\n" +
+	"\fenabled_only\x18\x03 \x01(\bR\venabledOnly\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\"\xd0\x01\n" +
 	"\x15ListSchedulesResponse\x12/\n" +
 	"\tschedules\x18\x01 \x03(\v2\x11.apps.v0.ScheduleR\tschedules\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x03R\x05total\"\xbd\x02\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x04 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\"\xd4\x03\n" +
 	"\x15UpdateScheduleRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1e\n" +
 	"\n" +
@@ -1140,31 +1813,43 @@ const file_proto_apps_v0_schedule_proto_rawDesc = "" +
 	"\acommand\x18\x04 \x01(\tR\acommand\x12\x18\n" +
 	"\amessage\x18\x05 \x01(\tR\amessage\x12\x18\n" +
 	"\adeliver\x18\x06 \x01(\tR\adeliver\x12H\n" +
-	"\bmetadata\x18\a \x03(\v2,.apps.v0.UpdateScheduleRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\a \x03(\v2,.apps.v0.UpdateScheduleRequest.MetadataEntryR\bmetadata\x12.\n" +
+	"\atrigger\x18\b \x01(\v2\x14.apps.v0.TriggerSpecR\atrigger\x120\n" +
+	"\x06policy\x18\t \x01(\v2\x18.apps.v0.ExecutionPolicyR\x06policy\x123\n" +
+	"\bdelivery\x18\n" +
+	" \x03(\v2\x17.apps.v0.DeliveryTargetR\bdelivery\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"W\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x92\x01\n" +
 	"\x10ScheduleResponse\x12-\n" +
 	"\bschedule\x18\x01 \x01(\v2\x11.apps.v0.ScheduleR\bschedule\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"+\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x03 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\"+\n" +
 	"\x15DeleteScheduleRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"H\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x83\x01\n" +
 	"\x16DeleteScheduleResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\")\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x03 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\")\n" +
 	"\x13ScheduleNameRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"Y\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x94\x01\n" +
 	"\x0fTriggerResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
 	"\x06output\x18\x02 \x01(\tR\x06output\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\"Z\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x04 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\"r\n" +
 	"\x16ScheduleHistoryRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"h\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\"\xda\x01\n" +
 	"\x17ScheduleHistoryResponse\x127\n" +
 	"\aentries\x18\x01 \x03(\v2\x1d.apps.v0.ScheduleHistoryEntryR\aentries\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x03R\x05total\"\xd3\x01\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x129\n" +
+	"\ferror_detail\x18\x04 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\"\x8c\x02\n" +
 	"\x14ScheduleHistoryEntry\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
 	"\rschedule_name\x18\x02 \x01(\tR\fscheduleName\x12\x1d\n" +
@@ -1174,7 +1859,10 @@ const file_proto_apps_v0_schedule_proto_rawDesc = "" +
 	"finishedAt\x12\x18\n" +
 	"\asuccess\x18\x05 \x01(\bR\asuccess\x12\x16\n" +
 	"\x06output\x18\x06 \x01(\tR\x06output\x12\x14\n" +
-	"\x05error\x18\a \x01(\tR\x05error2\xb9\x06\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"trigger_id\x18\b \x01(\tR\ttriggerId\x12\x18\n" +
+	"\aattempt\x18\t \x01(\x05R\aattempt2\x88\a\n" +
 	"\x0fScheduleService\x12H\n" +
 	"\vHealthCheck\x12\x1b.apps.v0.HealthCheckRequest\x1a\x1c.apps.v0.HealthCheckResponse\x12C\n" +
 	"\x06Create\x12\x1e.apps.v0.CreateScheduleRequest\x1a\x19.apps.v0.ScheduleResponse\x12=\n" +
@@ -1186,7 +1874,8 @@ const file_proto_apps_v0_schedule_proto_rawDesc = "" +
 	"\aDisable\x12\x1c.apps.v0.ScheduleNameRequest\x1a\x19.apps.v0.ScheduleResponse\x12A\n" +
 	"\aTrigger\x12\x1c.apps.v0.ScheduleNameRequest\x1a\x18.apps.v0.TriggerResponse\x12L\n" +
 	"\aHistory\x12\x1f.apps.v0.ScheduleHistoryRequest\x1a .apps.v0.ScheduleHistoryResponse\x126\n" +
-	"\bTriggers\x12\x0e.apps.v0.Empty\x1a\x18.apps.v0.ScheduleTrigger0\x01\x121\n" +
+	"\bTriggers\x12\x0e.apps.v0.Empty\x1a\x18.apps.v0.ScheduleTrigger0\x01\x12M\n" +
+	"\x0fReportExecution\x12\x18.apps.v0.ExecutionReport\x1a .apps.v0.ExecutionReportResponse\x121\n" +
 	"\tConfigure\x12\x14.apps.v0.SettingsMap\x1a\x0e.apps.v0.EmptyB$Z\"github.com/neboloop/nebo-sdk-go/pbb\x06proto3"
 
 var (
@@ -1201,69 +1890,93 @@ func file_proto_apps_v0_schedule_proto_rawDescGZIP() []byte {
 	return file_proto_apps_v0_schedule_proto_rawDescData
 }
 
-var file_proto_apps_v0_schedule_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_proto_apps_v0_schedule_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_proto_apps_v0_schedule_proto_goTypes = []any{
 	(*Schedule)(nil),                // 0: apps.v0.Schedule
-	(*ScheduleTrigger)(nil),         // 1: apps.v0.ScheduleTrigger
-	(*CreateScheduleRequest)(nil),   // 2: apps.v0.CreateScheduleRequest
-	(*GetScheduleRequest)(nil),      // 3: apps.v0.GetScheduleRequest
-	(*ListSchedulesRequest)(nil),    // 4: apps.v0.ListSchedulesRequest
-	(*ListSchedulesResponse)(nil),   // 5: apps.v0.ListSchedulesResponse
-	(*UpdateScheduleRequest)(nil),   // 6: apps.v0.UpdateScheduleRequest
-	(*ScheduleResponse)(nil),        // 7: apps.v0.ScheduleResponse
-	(*DeleteScheduleRequest)(nil),   // 8: apps.v0.DeleteScheduleRequest
-	(*DeleteScheduleResponse)(nil),  // 9: apps.v0.DeleteScheduleResponse
-	(*ScheduleNameRequest)(nil),     // 10: apps.v0.ScheduleNameRequest
-	(*TriggerResponse)(nil),         // 11: apps.v0.TriggerResponse
-	(*ScheduleHistoryRequest)(nil),  // 12: apps.v0.ScheduleHistoryRequest
-	(*ScheduleHistoryResponse)(nil), // 13: apps.v0.ScheduleHistoryResponse
-	(*ScheduleHistoryEntry)(nil),    // 14: apps.v0.ScheduleHistoryEntry
-	nil,                             // 15: apps.v0.Schedule.MetadataEntry
-	nil,                             // 16: apps.v0.ScheduleTrigger.MetadataEntry
-	nil,                             // 17: apps.v0.CreateScheduleRequest.MetadataEntry
-	nil,                             // 18: apps.v0.UpdateScheduleRequest.MetadataEntry
-	(*HealthCheckRequest)(nil),      // 19: apps.v0.HealthCheckRequest
-	(*Empty)(nil),                   // 20: apps.v0.Empty
-	(*SettingsMap)(nil),             // 21: apps.v0.SettingsMap
-	(*HealthCheckResponse)(nil),     // 22: apps.v0.HealthCheckResponse
+	(*ExecutionPolicy)(nil),         // 1: apps.v0.ExecutionPolicy
+	(*TriggerSpec)(nil),             // 2: apps.v0.TriggerSpec
+	(*ScheduleTrigger)(nil),         // 3: apps.v0.ScheduleTrigger
+	(*ExecutionReport)(nil),         // 4: apps.v0.ExecutionReport
+	(*ExecutionReportResponse)(nil), // 5: apps.v0.ExecutionReportResponse
+	(*CreateScheduleRequest)(nil),   // 6: apps.v0.CreateScheduleRequest
+	(*GetScheduleRequest)(nil),      // 7: apps.v0.GetScheduleRequest
+	(*ListSchedulesRequest)(nil),    // 8: apps.v0.ListSchedulesRequest
+	(*ListSchedulesResponse)(nil),   // 9: apps.v0.ListSchedulesResponse
+	(*UpdateScheduleRequest)(nil),   // 10: apps.v0.UpdateScheduleRequest
+	(*ScheduleResponse)(nil),        // 11: apps.v0.ScheduleResponse
+	(*DeleteScheduleRequest)(nil),   // 12: apps.v0.DeleteScheduleRequest
+	(*DeleteScheduleResponse)(nil),  // 13: apps.v0.DeleteScheduleResponse
+	(*ScheduleNameRequest)(nil),     // 14: apps.v0.ScheduleNameRequest
+	(*TriggerResponse)(nil),         // 15: apps.v0.TriggerResponse
+	(*ScheduleHistoryRequest)(nil),  // 16: apps.v0.ScheduleHistoryRequest
+	(*ScheduleHistoryResponse)(nil), // 17: apps.v0.ScheduleHistoryResponse
+	(*ScheduleHistoryEntry)(nil),    // 18: apps.v0.ScheduleHistoryEntry
+	nil,                             // 19: apps.v0.Schedule.MetadataEntry
+	nil,                             // 20: apps.v0.ScheduleTrigger.MetadataEntry
+	nil,                             // 21: apps.v0.CreateScheduleRequest.MetadataEntry
+	nil,                             // 22: apps.v0.UpdateScheduleRequest.MetadataEntry
+	(*DeliveryTarget)(nil),          // 23: apps.v0.DeliveryTarget
+	(*ErrorResponse)(nil),           // 24: apps.v0.ErrorResponse
+	(*HealthCheckRequest)(nil),      // 25: apps.v0.HealthCheckRequest
+	(*Empty)(nil),                   // 26: apps.v0.Empty
+	(*SettingsMap)(nil),             // 27: apps.v0.SettingsMap
+	(*HealthCheckResponse)(nil),     // 28: apps.v0.HealthCheckResponse
 }
 var file_proto_apps_v0_schedule_proto_depIdxs = []int32{
-	15, // 0: apps.v0.Schedule.metadata:type_name -> apps.v0.Schedule.MetadataEntry
-	16, // 1: apps.v0.ScheduleTrigger.metadata:type_name -> apps.v0.ScheduleTrigger.MetadataEntry
-	17, // 2: apps.v0.CreateScheduleRequest.metadata:type_name -> apps.v0.CreateScheduleRequest.MetadataEntry
-	0,  // 3: apps.v0.ListSchedulesResponse.schedules:type_name -> apps.v0.Schedule
-	18, // 4: apps.v0.UpdateScheduleRequest.metadata:type_name -> apps.v0.UpdateScheduleRequest.MetadataEntry
-	0,  // 5: apps.v0.ScheduleResponse.schedule:type_name -> apps.v0.Schedule
-	14, // 6: apps.v0.ScheduleHistoryResponse.entries:type_name -> apps.v0.ScheduleHistoryEntry
-	19, // 7: apps.v0.ScheduleService.HealthCheck:input_type -> apps.v0.HealthCheckRequest
-	2,  // 8: apps.v0.ScheduleService.Create:input_type -> apps.v0.CreateScheduleRequest
-	3,  // 9: apps.v0.ScheduleService.Get:input_type -> apps.v0.GetScheduleRequest
-	4,  // 10: apps.v0.ScheduleService.List:input_type -> apps.v0.ListSchedulesRequest
-	6,  // 11: apps.v0.ScheduleService.Update:input_type -> apps.v0.UpdateScheduleRequest
-	8,  // 12: apps.v0.ScheduleService.Delete:input_type -> apps.v0.DeleteScheduleRequest
-	10, // 13: apps.v0.ScheduleService.Enable:input_type -> apps.v0.ScheduleNameRequest
-	10, // 14: apps.v0.ScheduleService.Disable:input_type -> apps.v0.ScheduleNameRequest
-	10, // 15: apps.v0.ScheduleService.Trigger:input_type -> apps.v0.ScheduleNameRequest
-	12, // 16: apps.v0.ScheduleService.History:input_type -> apps.v0.ScheduleHistoryRequest
-	20, // 17: apps.v0.ScheduleService.Triggers:input_type -> apps.v0.Empty
-	21, // 18: apps.v0.ScheduleService.Configure:input_type -> apps.v0.SettingsMap
-	22, // 19: apps.v0.ScheduleService.HealthCheck:output_type -> apps.v0.HealthCheckResponse
-	7,  // 20: apps.v0.ScheduleService.Create:output_type -> apps.v0.ScheduleResponse
-	7,  // 21: apps.v0.ScheduleService.Get:output_type -> apps.v0.ScheduleResponse
-	5,  // 22: apps.v0.ScheduleService.List:output_type -> apps.v0.ListSchedulesResponse
-	7,  // 23: apps.v0.ScheduleService.Update:output_type -> apps.v0.ScheduleResponse
-	9,  // 24: apps.v0.ScheduleService.Delete:output_type -> apps.v0.DeleteScheduleResponse
-	7,  // 25: apps.v0.ScheduleService.Enable:output_type -> apps.v0.ScheduleResponse
-	7,  // 26: apps.v0.ScheduleService.Disable:output_type -> apps.v0.ScheduleResponse
-	11, // 27: apps.v0.ScheduleService.Trigger:output_type -> apps.v0.TriggerResponse
-	13, // 28: apps.v0.ScheduleService.History:output_type -> apps.v0.ScheduleHistoryResponse
-	1,  // 29: apps.v0.ScheduleService.Triggers:output_type -> apps.v0.ScheduleTrigger
-	20, // 30: apps.v0.ScheduleService.Configure:output_type -> apps.v0.Empty
-	19, // [19:31] is the sub-list for method output_type
-	7,  // [7:19] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	19, // 0: apps.v0.Schedule.metadata:type_name -> apps.v0.Schedule.MetadataEntry
+	2,  // 1: apps.v0.Schedule.trigger:type_name -> apps.v0.TriggerSpec
+	1,  // 2: apps.v0.Schedule.policy:type_name -> apps.v0.ExecutionPolicy
+	23, // 3: apps.v0.Schedule.delivery:type_name -> apps.v0.DeliveryTarget
+	20, // 4: apps.v0.ScheduleTrigger.metadata:type_name -> apps.v0.ScheduleTrigger.MetadataEntry
+	23, // 5: apps.v0.ScheduleTrigger.delivery:type_name -> apps.v0.DeliveryTarget
+	24, // 6: apps.v0.ExecutionReportResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	21, // 7: apps.v0.CreateScheduleRequest.metadata:type_name -> apps.v0.CreateScheduleRequest.MetadataEntry
+	2,  // 8: apps.v0.CreateScheduleRequest.trigger:type_name -> apps.v0.TriggerSpec
+	1,  // 9: apps.v0.CreateScheduleRequest.policy:type_name -> apps.v0.ExecutionPolicy
+	23, // 10: apps.v0.CreateScheduleRequest.delivery:type_name -> apps.v0.DeliveryTarget
+	0,  // 11: apps.v0.ListSchedulesResponse.schedules:type_name -> apps.v0.Schedule
+	24, // 12: apps.v0.ListSchedulesResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	22, // 13: apps.v0.UpdateScheduleRequest.metadata:type_name -> apps.v0.UpdateScheduleRequest.MetadataEntry
+	2,  // 14: apps.v0.UpdateScheduleRequest.trigger:type_name -> apps.v0.TriggerSpec
+	1,  // 15: apps.v0.UpdateScheduleRequest.policy:type_name -> apps.v0.ExecutionPolicy
+	23, // 16: apps.v0.UpdateScheduleRequest.delivery:type_name -> apps.v0.DeliveryTarget
+	0,  // 17: apps.v0.ScheduleResponse.schedule:type_name -> apps.v0.Schedule
+	24, // 18: apps.v0.ScheduleResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	24, // 19: apps.v0.DeleteScheduleResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	24, // 20: apps.v0.TriggerResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	18, // 21: apps.v0.ScheduleHistoryResponse.entries:type_name -> apps.v0.ScheduleHistoryEntry
+	24, // 22: apps.v0.ScheduleHistoryResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	25, // 23: apps.v0.ScheduleService.HealthCheck:input_type -> apps.v0.HealthCheckRequest
+	6,  // 24: apps.v0.ScheduleService.Create:input_type -> apps.v0.CreateScheduleRequest
+	7,  // 25: apps.v0.ScheduleService.Get:input_type -> apps.v0.GetScheduleRequest
+	8,  // 26: apps.v0.ScheduleService.List:input_type -> apps.v0.ListSchedulesRequest
+	10, // 27: apps.v0.ScheduleService.Update:input_type -> apps.v0.UpdateScheduleRequest
+	12, // 28: apps.v0.ScheduleService.Delete:input_type -> apps.v0.DeleteScheduleRequest
+	14, // 29: apps.v0.ScheduleService.Enable:input_type -> apps.v0.ScheduleNameRequest
+	14, // 30: apps.v0.ScheduleService.Disable:input_type -> apps.v0.ScheduleNameRequest
+	14, // 31: apps.v0.ScheduleService.Trigger:input_type -> apps.v0.ScheduleNameRequest
+	16, // 32: apps.v0.ScheduleService.History:input_type -> apps.v0.ScheduleHistoryRequest
+	26, // 33: apps.v0.ScheduleService.Triggers:input_type -> apps.v0.Empty
+	4,  // 34: apps.v0.ScheduleService.ReportExecution:input_type -> apps.v0.ExecutionReport
+	27, // 35: apps.v0.ScheduleService.Configure:input_type -> apps.v0.SettingsMap
+	28, // 36: apps.v0.ScheduleService.HealthCheck:output_type -> apps.v0.HealthCheckResponse
+	11, // 37: apps.v0.ScheduleService.Create:output_type -> apps.v0.ScheduleResponse
+	11, // 38: apps.v0.ScheduleService.Get:output_type -> apps.v0.ScheduleResponse
+	9,  // 39: apps.v0.ScheduleService.List:output_type -> apps.v0.ListSchedulesResponse
+	11, // 40: apps.v0.ScheduleService.Update:output_type -> apps.v0.ScheduleResponse
+	13, // 41: apps.v0.ScheduleService.Delete:output_type -> apps.v0.DeleteScheduleResponse
+	11, // 42: apps.v0.ScheduleService.Enable:output_type -> apps.v0.ScheduleResponse
+	11, // 43: apps.v0.ScheduleService.Disable:output_type -> apps.v0.ScheduleResponse
+	15, // 44: apps.v0.ScheduleService.Trigger:output_type -> apps.v0.TriggerResponse
+	17, // 45: apps.v0.ScheduleService.History:output_type -> apps.v0.ScheduleHistoryResponse
+	3,  // 46: apps.v0.ScheduleService.Triggers:output_type -> apps.v0.ScheduleTrigger
+	5,  // 47: apps.v0.ScheduleService.ReportExecution:output_type -> apps.v0.ExecutionReportResponse
+	26, // 48: apps.v0.ScheduleService.Configure:output_type -> apps.v0.Empty
+	36, // [36:49] is the sub-list for method output_type
+	23, // [23:36] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_proto_apps_v0_schedule_proto_init() }
@@ -1272,13 +1985,19 @@ func file_proto_apps_v0_schedule_proto_init() {
 		return
 	}
 	file_proto_apps_v0_common_proto_init()
+	file_proto_apps_v0_schedule_proto_msgTypes[2].OneofWrappers = []any{
+		(*TriggerSpec_Cron)(nil),
+		(*TriggerSpec_IntervalSeconds)(nil),
+		(*TriggerSpec_At)(nil),
+		(*TriggerSpec_Rrule)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_apps_v0_schedule_proto_rawDesc), len(file_proto_apps_v0_schedule_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   19,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,18 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ScheduleService_HealthCheck_FullMethodName = "/apps.v0.ScheduleService/HealthCheck"
-	ScheduleService_Create_FullMethodName      = "/apps.v0.ScheduleService/Create"
-	ScheduleService_Get_FullMethodName         = "/apps.v0.ScheduleService/Get"
-	ScheduleService_List_FullMethodName        = "/apps.v0.ScheduleService/List"
-	ScheduleService_Update_FullMethodName      = "/apps.v0.ScheduleService/Update"
-	ScheduleService_Delete_FullMethodName      = "/apps.v0.ScheduleService/Delete"
-	ScheduleService_Enable_FullMethodName      = "/apps.v0.ScheduleService/Enable"
-	ScheduleService_Disable_FullMethodName     = "/apps.v0.ScheduleService/Disable"
-	ScheduleService_Trigger_FullMethodName     = "/apps.v0.ScheduleService/Trigger"
-	ScheduleService_History_FullMethodName     = "/apps.v0.ScheduleService/History"
-	ScheduleService_Triggers_FullMethodName    = "/apps.v0.ScheduleService/Triggers"
-	ScheduleService_Configure_FullMethodName   = "/apps.v0.ScheduleService/Configure"
+	ScheduleService_HealthCheck_FullMethodName     = "/apps.v0.ScheduleService/HealthCheck"
+	ScheduleService_Create_FullMethodName          = "/apps.v0.ScheduleService/Create"
+	ScheduleService_Get_FullMethodName             = "/apps.v0.ScheduleService/Get"
+	ScheduleService_List_FullMethodName            = "/apps.v0.ScheduleService/List"
+	ScheduleService_Update_FullMethodName          = "/apps.v0.ScheduleService/Update"
+	ScheduleService_Delete_FullMethodName          = "/apps.v0.ScheduleService/Delete"
+	ScheduleService_Enable_FullMethodName          = "/apps.v0.ScheduleService/Enable"
+	ScheduleService_Disable_FullMethodName         = "/apps.v0.ScheduleService/Disable"
+	ScheduleService_Trigger_FullMethodName         = "/apps.v0.ScheduleService/Trigger"
+	ScheduleService_History_FullMethodName         = "/apps.v0.ScheduleService/History"
+	ScheduleService_Triggers_FullMethodName        = "/apps.v0.ScheduleService/Triggers"
+	ScheduleService_ReportExecution_FullMethodName = "/apps.v0.ScheduleService/ReportExecution"
+	ScheduleService_Configure_FullMethodName       = "/apps.v0.ScheduleService/Configure"
 )
 
 // ScheduleServiceClient is the client API for ScheduleService service.
@@ -49,6 +50,8 @@ type ScheduleServiceClient interface {
 	// Get returns a single schedule by name.
 	Get(ctx context.Context, in *GetScheduleRequest, opts ...grpc.CallOption) (*ScheduleResponse, error)
 	// List returns all schedules with optional pagination.
+	// Pass next_cursor from the previous response as cursor to fetch the next page;
+	// offset is ignored when cursor is set.
 	List(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error)
 	// Update modifies an existing schedule.
 	Update(ctx context.Context, in *UpdateScheduleRequest, opts ...grpc.CallOption) (*ScheduleResponse, error)
@@ -61,10 +64,14 @@ type ScheduleServiceClient interface {
 	// Trigger manually fires a schedule immediately.
 	Trigger(ctx context.Context, in *ScheduleNameRequest, opts ...grpc.CallOption) (*TriggerResponse, error)
 	// History returns execution history for a schedule.
+	// Supports the same cursor pagination as List.
 	History(ctx context.Context, in *ScheduleHistoryRequest, opts ...grpc.CallOption) (*ScheduleHistoryResponse, error)
 	// Triggers is a server-streaming RPC. The app notifies Nebo each time a schedule fires.
 	// Nebo reads from this stream and routes the triggered task to LaneEvents.
 	Triggers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ScheduleTrigger], error)
+	// ReportExecution is called by Nebo when it starts and when it finishes running
+	// a triggered task, so the app can record the outcome in history.
+	ReportExecution(ctx context.Context, in *ExecutionReport, opts ...grpc.CallOption) (*ExecutionReportResponse, error)
 	// Configure updates the app's settings.
 	Configure(ctx context.Context, in *SettingsMap, opts ...grpc.CallOption) (*Empty, error)
 }
@@ -196,6 +203,16 @@ func (c *scheduleServiceClient) Triggers(ctx context.Context, in *Empty, opts ..
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ScheduleService_TriggersClient = grpc.ServerStreamingClient[ScheduleTrigger]
 
+func (c *scheduleServiceClient) ReportExecution(ctx context.Context, in *ExecutionReport, opts ...grpc.CallOption) (*ExecutionReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExecutionReportResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ReportExecution_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *scheduleServiceClient) Configure(ctx context.Context, in *SettingsMap, opts ...grpc.CallOption) (*Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Empty)
@@ -222,6 +239,8 @@ type ScheduleServiceServer interface {
 	// Get returns a single schedule by name.
 	Get(context.Context, *GetScheduleRequest) (*ScheduleResponse, error)
 	// List returns all schedules with optional pagination.
+	// Pass next_cursor from the previous response as cursor to fetch the next page;
+	// offset is ignored when cursor is set.
 	List(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error)
 	// Update modifies an existing schedule.
 	Update(context.Context, *UpdateScheduleRequest) (*ScheduleResponse, error)
@@ -234,10 +253,14 @@ type ScheduleServiceServer interface {
 	// Trigger manually fires a schedule immediately.
 	Trigger(context.Context, *ScheduleNameRequest) (*TriggerResponse, error)
 	// History returns execution history for a schedule.
+	// Supports the same cursor pagination as List.
 	History(context.Context, *ScheduleHistoryRequest) (*ScheduleHistoryResponse, error)
 	// Triggers is a server-streaming RPC. The app notifies Nebo each time a schedule fires.
 	// Nebo reads from this stream and routes the triggered task to LaneEvents.
 	Triggers(*Empty, grpc.ServerStreamingServer[ScheduleTrigger]) error
+	// ReportExecution is called by Nebo when it starts and when it finishes running
+	// a triggered task, so the app can record the outcome in history.
+	ReportExecution(context.Context, *ExecutionReport) (*ExecutionReportResponse, error)
 	// Configure updates the app's settings.
 	Configure(context.Context, *SettingsMap) (*Empty, error)
 	mustEmbedUnimplementedScheduleServiceServer()
@@ -283,6 +306,9 @@ func (UnimplementedScheduleServiceServer) History(context.Context, *ScheduleHist
 func (UnimplementedScheduleServiceServer) Triggers(*Empty, grpc.ServerStreamingServer[ScheduleTrigger]) error {
 	return status.Error(codes.Unimplemented, "method Triggers not implemented")
 }
+func (UnimplementedScheduleServiceServer) ReportExecution(context.Context, *ExecutionReport) (*ExecutionReportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReportExecution not implemented")
+}
 func (UnimplementedScheduleServiceServer) Configure(context.Context, *SettingsMap) (*Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method Configure not implemented")
 }
@@ -498,6 +524,24 @@ func _ScheduleService_Triggers_Handler(srv interface{}, stream grpc.ServerStream
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ScheduleService_TriggersServer = grpc.ServerStreamingServer[ScheduleTrigger]
 
+func _ScheduleService_ReportExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecutionReport)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ReportExecution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ReportExecution_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ReportExecution(ctx, req.(*ExecutionReport))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ScheduleService_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SettingsMap)
 	if err := dec(in); err != nil {
@@ -563,6 +607,10 @@ var ScheduleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "History",
 			Handler:    _ScheduleService_History_Handler,
 		},
+		{
+			MethodName: "ReportExecution",
+			Handler:    _ScheduleService_ReportExecution_Handler,
+		},
 		{
 			MethodName: "Configure",
 			Handler:    _ScheduleService_Configure_Handler,
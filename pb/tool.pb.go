@@ -201,6 +201,7 @@ type ExecuteResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
 	IsError       bool                   `protobuf:"varint,2,opt,name=is_error,json=isError,proto3" json:"is_error,omitempty"`
+	ErrorDetail   *ErrorResponse         `protobuf:"bytes,3,opt,name=error_detail,json=errorDetail,proto3" json:"error_detail,omitempty"` // set when is_error is true
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -249,6 +250,13 @@ func (x *ExecuteResponse) GetIsError() bool {
 	return false
 }
 
+func (x *ExecuteResponse) GetErrorDetail() *ErrorResponse {
+	if x != nil {
+		return x.ErrorDetail
+	}
+	return nil
+}
+
 type ApprovalResponse struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	RequiresApproval bool                   `protobuf:"varint,1,opt,name=requires_approval,json=requiresApproval,proto3" json:"requires_approval,omitempty"`
@@ -305,10 +313,11 @@ const file_proto_apps_v0_tool_proto_rawDesc = "" +
 	"\x0eSchemaResponse\x12\x16\n" +
 	"\x06schema\x18\x01 \x01(\fR\x06schema\"&\n" +
 	"\x0eExecuteRequest\x12\x14\n" +
-	"\x05input\x18\x01 \x01(\fR\x05input\"F\n" +
+	"\x05input\x18\x01 \x01(\fR\x05input\"\x81\x01\n" +
 	"\x0fExecuteResponse\x12\x18\n" +
 	"\acontent\x18\x01 \x01(\tR\acontent\x12\x19\n" +
-	"\bis_error\x18\x02 \x01(\bR\aisError\"?\n" +
+	"\bis_error\x18\x02 \x01(\bR\aisError\x129\n" +
+	"\ferror_detail\x18\x03 \x01(\v2\x16.apps.v0.ErrorResponseR\verrorDetail\"?\n" +
 	"\x10ApprovalResponse\x12+\n" +
 	"\x11requires_approval\x18\x01 \x01(\bR\x10requiresApproval2\xa6\x03\n" +
 	"\vToolService\x12H\n" +
@@ -340,31 +349,33 @@ var file_proto_apps_v0_tool_proto_goTypes = []any{
 	(*ExecuteRequest)(nil),      // 3: apps.v0.ExecuteRequest
 	(*ExecuteResponse)(nil),     // 4: apps.v0.ExecuteResponse
 	(*ApprovalResponse)(nil),    // 5: apps.v0.ApprovalResponse
-	(*HealthCheckRequest)(nil),  // 6: apps.v0.HealthCheckRequest
-	(*Empty)(nil),               // 7: apps.v0.Empty
-	(*SettingsMap)(nil),         // 8: apps.v0.SettingsMap
-	(*HealthCheckResponse)(nil), // 9: apps.v0.HealthCheckResponse
+	(*ErrorResponse)(nil),       // 6: apps.v0.ErrorResponse
+	(*HealthCheckRequest)(nil),  // 7: apps.v0.HealthCheckRequest
+	(*Empty)(nil),               // 8: apps.v0.Empty
+	(*SettingsMap)(nil),         // 9: apps.v0.SettingsMap
+	(*HealthCheckResponse)(nil), // 10: apps.v0.HealthCheckResponse
 }
 var file_proto_apps_v0_tool_proto_depIdxs = []int32{
-	6, // 0: apps.v0.ToolService.HealthCheck:input_type -> apps.v0.HealthCheckRequest
-	7, // 1: apps.v0.ToolService.Name:input_type -> apps.v0.Empty
-	7, // 2: apps.v0.ToolService.Description:input_type -> apps.v0.Empty
-	7, // 3: apps.v0.ToolService.Schema:input_type -> apps.v0.Empty
-	3, // 4: apps.v0.ToolService.Execute:input_type -> apps.v0.ExecuteRequest
-	7, // 5: apps.v0.ToolService.RequiresApproval:input_type -> apps.v0.Empty
-	8, // 6: apps.v0.ToolService.Configure:input_type -> apps.v0.SettingsMap
-	9, // 7: apps.v0.ToolService.HealthCheck:output_type -> apps.v0.HealthCheckResponse
-	0, // 8: apps.v0.ToolService.Name:output_type -> apps.v0.NameResponse
-	1, // 9: apps.v0.ToolService.Description:output_type -> apps.v0.DescriptionResponse
-	2, // 10: apps.v0.ToolService.Schema:output_type -> apps.v0.SchemaResponse
-	4, // 11: apps.v0.ToolService.Execute:output_type -> apps.v0.ExecuteResponse
-	5, // 12: apps.v0.ToolService.RequiresApproval:output_type -> apps.v0.ApprovalResponse
-	7, // 13: apps.v0.ToolService.Configure:output_type -> apps.v0.Empty
-	7, // [7:14] is the sub-list for method output_type
-	0, // [0:7] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	6,  // 0: apps.v0.ExecuteResponse.error_detail:type_name -> apps.v0.ErrorResponse
+	7,  // 1: apps.v0.ToolService.HealthCheck:input_type -> apps.v0.HealthCheckRequest
+	8,  // 2: apps.v0.ToolService.Name:input_type -> apps.v0.Empty
+	8,  // 3: apps.v0.ToolService.Description:input_type -> apps.v0.Empty
+	8,  // 4: apps.v0.ToolService.Schema:input_type -> apps.v0.Empty
+	3,  // 5: apps.v0.ToolService.Execute:input_type -> apps.v0.ExecuteRequest
+	8,  // 6: apps.v0.ToolService.RequiresApproval:input_type -> apps.v0.Empty
+	9,  // 7: apps.v0.ToolService.Configure:input_type -> apps.v0.SettingsMap
+	10, // 8: apps.v0.ToolService.HealthCheck:output_type -> apps.v0.HealthCheckResponse
+	0,  // 9: apps.v0.ToolService.Name:output_type -> apps.v0.NameResponse
+	1,  // 10: apps.v0.ToolService.Description:output_type -> apps.v0.DescriptionResponse
+	2,  // 11: apps.v0.ToolService.Schema:output_type -> apps.v0.SchemaResponse
+	4,  // 12: apps.v0.ToolService.Execute:output_type -> apps.v0.ExecuteResponse
+	5,  // 13: apps.v0.ToolService.RequiresApproval:output_type -> apps.v0.ApprovalResponse
+	8,  // 14: apps.v0.ToolService.Configure:output_type -> apps.v0.Empty
+	8,  // [8:15] is the sub-list for method output_type
+	1,  // [1:8] is the sub-list for method input_type
+	1,  // [1:1] is the sub-list for extension type_name
+	1,  // [1:1] is the sub-list for extension extendee
+	0,  // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_proto_apps_v0_tool_proto_init() }
@@ -15,6 +15,16 @@ type ScheduleTrigger = pb.ScheduleTrigger
 // ScheduleHistoryEntry represents one execution of a schedule.
 type ScheduleHistoryEntry = pb.ScheduleHistoryEntry
 
+// ExecutionReport is sent by Nebo when a triggered task starts and finishes.
+type ExecutionReport = pb.ExecutionReport
+
+// ExecutionPolicy controls overlapping runs, retries and dead-lettering for a schedule.
+type ExecutionPolicy = pb.ExecutionPolicy
+
+// TriggerSpec describes when a schedule fires: cron, interval, one-shot or RRULE.
+// The schedule package has helpers to build and evaluate them.
+type TriggerSpec = pb.TriggerSpec
+
 // ScheduleHandler is the interface for schedule capability apps.
 // Implement this to replace Nebo's built-in cron scheduler.
 type ScheduleHandler interface {
@@ -30,6 +40,26 @@ type ScheduleHandler interface {
 	Triggers(ctx context.Context) (<-chan *pb.ScheduleTrigger, error)
 }
 
+// ScheduleHandlerWithCursor is an optional extension for handlers whose stores
+// support cursor pagination. When implemented, the SDK uses these methods instead of
+// List and History so large result sets don't rely on offset scans. Cursors are
+// opaque to Nebo: return "" as nextCursor on the last page.
+type ScheduleHandlerWithCursor interface {
+	ScheduleHandler
+	ListCursor(ctx context.Context, limit int32, cursor string, enabledOnly bool) (schedules []*pb.Schedule, total int64, nextCursor string, err error)
+	HistoryCursor(ctx context.Context, name string, limit int32, cursor string) (entries []*pb.ScheduleHistoryEntry, total int64, nextCursor string, err error)
+}
+
+// ScheduleHandlerWithReports is an optional extension for handlers that want to
+// know how triggered tasks went. Nebo reports state "started" when it picks up a
+// trigger and "succeeded" or "failed" when the task finishes, keyed by
+// ScheduleTrigger.TriggerId. For handlers without it, reports fail with
+// CodeUnimplemented.
+type ScheduleHandlerWithReports interface {
+	ScheduleHandler
+	ReportExecution(ctx context.Context, report *pb.ExecutionReport) error
+}
+
 // scheduleBridge adapts a ScheduleHandler to the pb.ScheduleServiceServer gRPC interface.
 type scheduleBridge struct {
 	pb.UnimplementedScheduleServiceServer
@@ -49,7 +79,7 @@ func (b *scheduleBridge) HealthCheck(_ context.Context, _ *pb.HealthCheckRequest
 func (b *scheduleBridge) Create(ctx context.Context, req *pb.CreateScheduleRequest) (*pb.ScheduleResponse, error) {
 	sched, err := b.handler.Create(ctx, req)
 	if err != nil {
-		return &pb.ScheduleResponse{Error: err.Error()}, nil
+		return &pb.ScheduleResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
 	}
 	return &pb.ScheduleResponse{Schedule: sched}, nil
 }
@@ -57,15 +87,22 @@ func (b *scheduleBridge) Create(ctx context.Context, req *pb.CreateScheduleReque
 func (b *scheduleBridge) Get(ctx context.Context, req *pb.GetScheduleRequest) (*pb.ScheduleResponse, error) {
 	sched, err := b.handler.Get(ctx, req.Name)
 	if err != nil {
-		return &pb.ScheduleResponse{Error: err.Error()}, nil
+		return &pb.ScheduleResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
 	}
 	return &pb.ScheduleResponse{Schedule: sched}, nil
 }
 
 func (b *scheduleBridge) List(ctx context.Context, req *pb.ListSchedulesRequest) (*pb.ListSchedulesResponse, error) {
+	if h, ok := b.handler.(ScheduleHandlerWithCursor); ok {
+		schedules, total, next, err := h.ListCursor(ctx, req.Limit, req.Cursor, req.EnabledOnly)
+		if err != nil {
+			return &pb.ListSchedulesResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
+		}
+		return &pb.ListSchedulesResponse{Schedules: schedules, Total: total, NextCursor: next}, nil
+	}
 	schedules, total, err := b.handler.List(ctx, req.Limit, req.Offset, req.EnabledOnly)
 	if err != nil {
-		return &pb.ListSchedulesResponse{}, nil
+		return &pb.ListSchedulesResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
 	}
 	return &pb.ListSchedulesResponse{Schedules: schedules, Total: total}, nil
 }
@@ -73,14 +110,14 @@ func (b *scheduleBridge) List(ctx context.Context, req *pb.ListSchedulesRequest)
 func (b *scheduleBridge) Update(ctx context.Context, req *pb.UpdateScheduleRequest) (*pb.ScheduleResponse, error) {
 	sched, err := b.handler.Update(ctx, req)
 	if err != nil {
-		return &pb.ScheduleResponse{Error: err.Error()}, nil
+		return &pb.ScheduleResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
 	}
 	return &pb.ScheduleResponse{Schedule: sched}, nil
 }
 
 func (b *scheduleBridge) Delete(ctx context.Context, req *pb.DeleteScheduleRequest) (*pb.DeleteScheduleResponse, error) {
 	if err := b.handler.Delete(ctx, req.Name); err != nil {
-		return &pb.DeleteScheduleResponse{Error: err.Error()}, nil
+		return &pb.DeleteScheduleResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
 	}
 	return &pb.DeleteScheduleResponse{Success: true}, nil
 }
@@ -88,7 +125,7 @@ func (b *scheduleBridge) Delete(ctx context.Context, req *pb.DeleteScheduleReque
 func (b *scheduleBridge) Enable(ctx context.Context, req *pb.ScheduleNameRequest) (*pb.ScheduleResponse, error) {
 	sched, err := b.handler.Enable(ctx, req.Name)
 	if err != nil {
-		return &pb.ScheduleResponse{Error: err.Error()}, nil
+		return &pb.ScheduleResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
 	}
 	return &pb.ScheduleResponse{Schedule: sched}, nil
 }
@@ -96,7 +133,7 @@ func (b *scheduleBridge) Enable(ctx context.Context, req *pb.ScheduleNameRequest
 func (b *scheduleBridge) Disable(ctx context.Context, req *pb.ScheduleNameRequest) (*pb.ScheduleResponse, error) {
 	sched, err := b.handler.Disable(ctx, req.Name)
 	if err != nil {
-		return &pb.ScheduleResponse{Error: err.Error()}, nil
+		return &pb.ScheduleResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
 	}
 	return &pb.ScheduleResponse{Schedule: sched}, nil
 }
@@ -104,15 +141,22 @@ func (b *scheduleBridge) Disable(ctx context.Context, req *pb.ScheduleNameReques
 func (b *scheduleBridge) Trigger(ctx context.Context, req *pb.ScheduleNameRequest) (*pb.TriggerResponse, error) {
 	success, output, err := b.handler.Trigger(ctx, req.Name)
 	if err != nil {
-		return &pb.TriggerResponse{Error: err.Error()}, nil
+		return &pb.TriggerResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
 	}
 	return &pb.TriggerResponse{Success: success, Output: output}, nil
 }
 
 func (b *scheduleBridge) History(ctx context.Context, req *pb.ScheduleHistoryRequest) (*pb.ScheduleHistoryResponse, error) {
+	if h, ok := b.handler.(ScheduleHandlerWithCursor); ok {
+		entries, total, next, err := h.HistoryCursor(ctx, req.Name, req.Limit, req.Cursor)
+		if err != nil {
+			return &pb.ScheduleHistoryResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
+		}
+		return &pb.ScheduleHistoryResponse{Entries: entries, Total: total, NextCursor: next}, nil
+	}
 	entries, total, err := b.handler.History(ctx, req.Name, req.Limit, req.Offset)
 	if err != nil {
-		return &pb.ScheduleHistoryResponse{}, nil
+		return &pb.ScheduleHistoryResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
 	}
 	return &pb.ScheduleHistoryResponse{Entries: entries, Total: total}, nil
 }
@@ -137,6 +181,18 @@ func (b *scheduleBridge) Triggers(_ *pb.Empty, stream pb.ScheduleService_Trigger
 	}
 }
 
+func (b *scheduleBridge) ReportExecution(ctx context.Context, req *pb.ExecutionReport) (*pb.ExecutionReportResponse, error) {
+	h, ok := b.handler.(ScheduleHandlerWithReports)
+	if !ok {
+		err := NewError(CodeUnimplemented, "schedule handler does not record execution reports")
+		return &pb.ExecutionReportResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
+	}
+	if err := h.ReportExecution(ctx, req); err != nil {
+		return &pb.ExecutionReportResponse{Error: err.Error(), ErrorDetail: toProtoError(err)}, nil
+	}
+	return &pb.ExecutionReportResponse{}, nil
+}
+
 func (b *scheduleBridge) Configure(_ context.Context, req *pb.SettingsMap) (*pb.Empty, error) {
 	if b.onConfigure != nil {
 		b.onConfigure(req.Values)
@@ -0,0 +1,302 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a parsed cron expression with second resolution.
+//
+// Fields are: second minute hour day-of-month month day-of-week.
+// A 5-field expression (no seconds) is accepted and fires at second 0.
+// Each field supports *, ?, lists (1,2), ranges (1-5), steps (*/15, 10-40/5)
+// and, for month and day-of-week, three-letter names (JAN, MON).
+// The descriptors @yearly, @monthly, @weekly, @daily and @hourly are also accepted.
+//
+// A leading "CRON_TZ=Zone " or "TZ=Zone " pins the expression to an IANA time zone.
+type Cron struct {
+	second, minute, hour, dom, month, dow uint64
+
+	// Location is the zone the expression is evaluated in. Nil means the zone of
+	// the time passed to Next.
+	Location *time.Location
+
+	expr string
+}
+
+// starBit marks a day field that was written as * or ?, which changes how
+// day-of-month and day-of-week combine (see dayMatches).
+const starBit = 1 << 63
+
+type bounds struct {
+	min, max uint
+	names    map[string]uint
+}
+
+var (
+	secondBounds = bounds{0, 59, nil}
+	minuteBounds = bounds{0, 59, nil}
+	hourBounds   = bounds{0, 23, nil}
+	domBounds    = bounds{1, 31, nil}
+	monthBounds  = bounds{1, 12, map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowBounds = bounds{0, 7, map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// ParseCron parses a cron expression. See Cron for the accepted syntax.
+func ParseCron(expr string) (*Cron, error) {
+	c := &Cron{expr: expr}
+	spec := strings.TrimSpace(expr)
+
+	if strings.HasPrefix(spec, "CRON_TZ=") || strings.HasPrefix(spec, "TZ=") {
+		i := strings.IndexByte(spec, ' ')
+		if i < 0 {
+			return nil, fmt.Errorf("cron %q: missing fields after time zone", expr)
+		}
+		zone := spec[strings.IndexByte(spec, '=')+1 : i]
+		loc, err := time.LoadLocation(zone)
+		if err != nil {
+			return nil, fmt.Errorf("cron %q: time zone: %w", expr, err)
+		}
+		c.Location = loc
+		spec = strings.TrimSpace(spec[i:])
+	}
+
+	if strings.HasPrefix(spec, "@") {
+		d, ok := descriptors[strings.ToLower(spec)]
+		if !ok {
+			return nil, fmt.Errorf("cron %q: unknown descriptor %s", expr, spec)
+		}
+		spec = d
+	}
+
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("cron %q: expected 6 fields (sec min hour dom month dow), got %d", expr, len(fields))
+	}
+
+	var err error
+	parse := func(field string, b bounds) uint64 {
+		if err != nil {
+			return 0
+		}
+		var bits uint64
+		bits, err = parseField(field, b)
+		if err != nil {
+			err = fmt.Errorf("cron %q: %w", expr, err)
+		}
+		return bits
+	}
+	c.second = parse(fields[0], secondBounds)
+	c.minute = parse(fields[1], minuteBounds)
+	c.hour = parse(fields[2], hourBounds)
+	c.dom = parse(fields[3], domBounds)
+	c.month = parse(fields[4], monthBounds)
+	c.dow = parse(fields[5], dowBounds)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sunday may be written as 0 or 7.
+	if c.dow&(1<<7) != 0 {
+		c.dow = (c.dow | 1) &^ (1 << 7)
+	}
+	return c, nil
+}
+
+// String returns the expression the Cron was parsed from.
+func (c *Cron) String() string {
+	return c.expr
+}
+
+// parseField parses one comma-separated cron field into a bit set.
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		r, err := parseRange(part, b)
+		if err != nil {
+			return 0, err
+		}
+		bits |= r
+	}
+	return bits, nil
+}
+
+// parseRange parses a single term: *, ?, N, N-M, with an optional /step.
+func parseRange(term string, b bounds) (uint64, error) {
+	rangePart, stepPart, hasStep := strings.Cut(term, "/")
+
+	var lo, hi uint
+	var extra uint64
+	switch rangePart {
+	case "*", "?":
+		lo, hi = b.min, b.max
+		if !hasStep {
+			extra = starBit
+		}
+	default:
+		from, to, isRange := strings.Cut(rangePart, "-")
+		var err error
+		if lo, err = parseValue(from, b); err != nil {
+			return 0, err
+		}
+		hi = lo
+		if isRange {
+			if hi, err = parseValue(to, b); err != nil {
+				return 0, err
+			}
+		} else if hasStep {
+			hi = b.max
+		}
+	}
+
+	step := uint(1)
+	if hasStep {
+		n, err := strconv.ParseUint(stepPart, 10, 8)
+		if err != nil || n == 0 {
+			return 0, fmt.Errorf("invalid step %q", term)
+		}
+		step = uint(n)
+	}
+
+	if lo < b.min || hi > b.max || lo > hi {
+		return 0, fmt.Errorf("%q out of range [%d-%d]", term, b.min, b.max)
+	}
+
+	var bits uint64
+	for v := lo; v <= hi; v += step {
+		bits |= 1 << v
+	}
+	return bits | extra, nil
+}
+
+func parseValue(s string, b bounds) (uint, error) {
+	if v, ok := b.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	n, err := strconv.ParseUint(s, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	return uint(n), nil
+}
+
+// Next returns the first activation time strictly after t, or the zero time if
+// the expression can never fire (e.g. 30 February) within five years.
+// The result is in t's location.
+func (c *Cron) Next(t time.Time) time.Time {
+	origLoc := t.Location()
+	loc := origLoc
+	if c.Location != nil {
+		loc = c.Location
+		t = t.In(loc)
+	}
+
+	// Start at the next whole second.
+	t = t.Add(time.Second - time.Duration(t.Nanosecond()))
+
+	// Once a field has been advanced, all lower fields are reset to their minimum.
+	reset := false
+	yearLimit := t.Year() + 5
+
+wrap:
+	if t.Year() > yearLimit {
+		return time.Time{}
+	}
+
+	for c.month&(1<<uint(t.Month())) == 0 {
+		if !reset {
+			reset = true
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+		}
+		t = t.AddDate(0, 1, 0)
+		if t.Month() == time.January {
+			goto wrap
+		}
+	}
+
+	for !c.dayMatches(t) {
+		if !reset {
+			reset = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		}
+		t = t.AddDate(0, 0, 1)
+		// A DST transition at midnight can leave us off the start of the day.
+		if h := t.Hour(); h != 0 {
+			if h > 12 {
+				t = t.Add(time.Duration(24-h) * time.Hour)
+			} else {
+				t = t.Add(-time.Duration(h) * time.Hour)
+			}
+		}
+		if t.Day() == 1 {
+			goto wrap
+		}
+	}
+
+	for c.hour&(1<<uint(t.Hour())) == 0 {
+		if !reset {
+			reset = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc)
+		}
+		t = t.Add(time.Hour)
+		if t.Hour() == 0 {
+			goto wrap
+		}
+	}
+
+	for c.minute&(1<<uint(t.Minute())) == 0 {
+		if !reset {
+			reset = true
+			t = t.Truncate(time.Minute)
+		}
+		t = t.Add(time.Minute)
+		if t.Minute() == 0 {
+			goto wrap
+		}
+	}
+
+	for c.second&(1<<uint(t.Second())) == 0 {
+		if !reset {
+			reset = true
+			t = t.Truncate(time.Second)
+		}
+		t = t.Add(time.Second)
+		if t.Second() == 0 {
+			goto wrap
+		}
+	}
+
+	return t.In(origLoc)
+}
+
+// dayMatches follows traditional cron semantics: if both day-of-month and
+// day-of-week are restricted, a day matches when either does.
+func (c *Cron) dayMatches(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.dom&starBit != 0 || c.dow&starBit != 0 {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,108 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	base := time.Date(2026, 3, 14, 10, 30, 15, 0, time.UTC) // Saturday
+
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"* * * * * *", "2026-03-14T10:30:16Z"},
+		{"0 * * * * *", "2026-03-14T10:31:00Z"},
+		{"*/20 * * * * *", "2026-03-14T10:30:20Z"},
+		{"0 0 9 * * MON-FRI", "2026-03-16T09:00:00Z"},
+		{"0 0 0 1 JAN *", "2027-01-01T00:00:00Z"},
+		{"30 45 10 * * *", "2026-03-14T10:45:30Z"},
+		{"0 0 12 13,20 * *", "2026-03-20T12:00:00Z"},
+		{"0 0 0 29 2 *", "2028-02-29T00:00:00Z"},
+		{"0 0 0 * * 7", "2026-03-15T00:00:00Z"},
+		{"@hourly", "2026-03-14T11:00:00Z"},
+		{"15 8 * * *", "2026-03-15T08:15:00Z"},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Errorf("ParseCron(%q): %v", tt.expr, err)
+			continue
+		}
+		if got := c.Next(base).Format(time.RFC3339); got != tt.want {
+			t.Errorf("Next(%q) = %s, want %s", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestCronDayOfMonthOrWeek(t *testing.T) {
+	// Both restricted: fires on the 1st OR on any Monday.
+	c, err := ParseCron("0 0 0 1 * MON")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := c.Next(time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)) // Tuesday
+	if want := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Next = %v, want %v", got, want)
+	}
+}
+
+func TestCronTimeZone(t *testing.T) {
+	c, err := ParseCron("CRON_TZ=America/New_York 0 0 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := c.Next(time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC))
+	// 09:00 EDT is 13:00 UTC.
+	if want := time.Date(2026, 7, 1, 13, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Next = %v, want %v", got, want)
+	}
+	if got.Location() != time.UTC {
+		t.Errorf("location = %v, want UTC (caller's)", got.Location())
+	}
+}
+
+func TestCronDST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("tzdata unavailable")
+	}
+	// 2026-03-08 02:30 does not exist in New York; the next 02:30 is a day later.
+	c, _ := ParseCron("0 30 2 * * *")
+	got := c.Next(time.Date(2026, 3, 8, 0, 0, 0, 0, ny))
+	if want := time.Date(2026, 3, 9, 2, 30, 0, 0, ny); !got.Equal(want) {
+		t.Errorf("Next = %v, want %v", got, want)
+	}
+}
+
+func TestCronNever(t *testing.T) {
+	c, err := ParseCron("0 0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Next(time.Now()); !got.IsZero() {
+		t.Errorf("Next = %v, want zero", got)
+	}
+}
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * * *",
+		"* * 24 * * *",
+		"* * * 0 * *",
+		"* * * * 13 *",
+		"*/0 * * * * *",
+		"5-1 * * * * *",
+		"* * * * FOO *",
+		"@fortnightly",
+		"TZ=Nowhere/Special * * * * * *",
+	} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want error", expr)
+		}
+	}
+}
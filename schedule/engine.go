@@ -0,0 +1,875 @@
+// Package schedule provides a ready-made nebo.ScheduleHandler.
+//
+// Engine evaluates cron, interval, one-shot and RRULE triggers, computes
+// next_run, tracks run_count and last_error, and emits ScheduleTriggers to Nebo. State lives in a pluggable
+// Store, so an app only has to pick one:
+//
+//	engine := schedule.New(schedule.NewMemoryStore())
+//	defer engine.Close()
+//	app.RegisterSchedule(engine)
+package schedule
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	nebo "github.com/neboloop/nebo-sdk-go"
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+)
+
+// MissedRunPolicy decides what happens to runs that should have fired while
+// the app was stopped or the machine was asleep.
+type MissedRunPolicy int
+
+const (
+	// MissedRunOnce fires a single catch-up run no matter how many were missed.
+	MissedRunOnce MissedRunPolicy = iota
+	// MissedSkip drops missed runs and waits for the next scheduled time.
+	MissedSkip
+	// MissedRunAll fires one run per missed occurrence, up to maxCatchUp.
+	MissedRunAll
+)
+
+// maxCatchUp caps how many runs MissedRunAll fires for a single schedule.
+const maxCatchUp = 100
+
+// maxPending caps triggers queued while Nebo is not reading the Triggers stream.
+// The oldest are dropped first.
+const maxPending = 1024
+
+// storeRetry is how long the loop waits before retrying after a Store error.
+const storeRetry = 5 * time.Second
+
+// Option configures an Engine.
+type Option func(*Engine)
+
+// WithClock replaces the wall clock, typically with a ManualClock in tests.
+func WithClock(c Clock) Option {
+	return func(e *Engine) { e.clock = c }
+}
+
+// WithLocation sets the time zone expressions are evaluated in when they do not
+// carry their own CRON_TZ= prefix. Defaults to time.Local.
+func WithLocation(loc *time.Location) Option {
+	return func(e *Engine) { e.loc = loc }
+}
+
+// WithMissedRunPolicy sets how missed runs are handled. Defaults to MissedRunOnce.
+func WithMissedRunPolicy(p MissedRunPolicy) Option {
+	return func(e *Engine) { e.missed = p }
+}
+
+// WithMisfireThreshold sets how late a run may fire before it counts as missed.
+// Defaults to one minute.
+func WithMisfireThreshold(d time.Duration) Option {
+	return func(e *Engine) { e.misfire = d }
+}
+
+// Engine is a cron scheduler that implements nebo.ScheduleHandler.
+// The scheduling loop starts on the first call to Triggers (or Start) and runs
+// until Close, independent of whether Nebo is currently attached.
+type Engine struct {
+	store   Store
+	clock   Clock
+	loc     *time.Location
+	missed  MissedRunPolicy
+	misfire time.Duration
+
+	// mu serializes read-modify-write cycles on stored schedules and guards
+	// the in-memory run state below.
+	mu      sync.Mutex
+	runs    map[string]*runState
+	retries []retryRun
+	outbox  []*pb.ScheduleTrigger // fired but not yet saved; see commit
+
+	qmu     sync.Mutex
+	pending []*pb.ScheduleTrigger
+	ready   chan struct{} // closed and replaced whenever pending grows
+
+	wake      chan struct{}
+	stop      chan struct{}
+	done      chan struct{}
+	startOnce sync.Once
+	closeOnce sync.Once
+}
+
+var (
+	_ nebo.ScheduleHandlerWithCursor  = (*Engine)(nil)
+	_ nebo.ScheduleHandlerWithReports = (*Engine)(nil)
+)
+
+// New creates an Engine backed by store.
+func New(store Store, opts ...Option) *Engine {
+	e := &Engine{
+		store:   store,
+		clock:   SystemClock{},
+		loc:     time.Local,
+		misfire: time.Minute,
+		runs:    make(map[string]*runState),
+		ready:   make(chan struct{}),
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Start launches the scheduling loop. It is safe to call more than once.
+func (e *Engine) Start() {
+	e.startOnce.Do(func() { go e.run() })
+}
+
+// Close stops the scheduling loop and waits for it to exit.
+func (e *Engine) Close() error {
+	e.closeOnce.Do(func() { close(e.stop) })
+	started := true
+	e.startOnce.Do(func() { started = false })
+	if started {
+		<-e.done
+	}
+	return nil
+}
+
+func (e *Engine) run() {
+	defer close(e.done)
+	for {
+		now := e.clock.Now()
+		next := e.tick(now)
+
+		var timer <-chan time.Time
+		if !next.IsZero() {
+			timer = e.clock.After(next.Sub(now))
+		}
+		select {
+		case <-timer:
+		case <-e.wake:
+			e.stopTimer(timer)
+		case <-e.stop:
+			e.stopTimer(timer)
+			return
+		}
+	}
+}
+
+// stopTimer releases an abandoned timer if the clock supports it.
+func (e *Engine) stopTimer(timer <-chan time.Time) {
+	if s, ok := e.clock.(timerStopper); ok && timer != nil {
+		s.Stop(timer)
+	}
+}
+
+// poke wakes the loop so it recomputes the next fire time.
+func (e *Engine) poke() {
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+}
+
+// tick fires every due schedule and returns the earliest upcoming run time,
+// or the zero time if nothing is scheduled.
+func (e *Engine) tick(now time.Time) time.Time {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	ctx := context.Background()
+	schedules, err := e.store.List(ctx)
+	if err != nil {
+		return now.Add(storeRetry)
+	}
+
+	byName := make(map[string]*pb.Schedule, len(schedules))
+	for _, s := range schedules {
+		byName[s.Name] = s
+	}
+	earliest, err := e.runRetries(ctx, byName, now)
+	if err != nil {
+		return now.Add(storeRetry)
+	}
+	for _, s := range schedules {
+		if !s.Enabled || s.NextRun == "" {
+			continue
+		}
+		due, err := time.Parse(time.RFC3339, s.NextRun)
+		if err != nil {
+			s.LastError = "invalid next_run: " + err.Error()
+			s.NextRun = ""
+			if err := e.store.Save(ctx, s); err != nil {
+				return now.Add(storeRetry)
+			}
+			continue
+		}
+
+		if !due.After(now) {
+			if err := e.catchUp(ctx, s, due, now); err != nil {
+				return now.Add(storeRetry)
+			}
+			if s.NextRun == "" {
+				continue
+			}
+			due, _ = time.Parse(time.RFC3339, s.NextRun)
+		}
+		if earliest.IsZero() || due.Before(earliest) {
+			earliest = due
+		}
+	}
+	return earliest
+}
+
+// catchUp fires s for the run that was due at due, applying the missed-run
+// policy if now is past the misfire threshold, then advances next_run.
+func (e *Engine) catchUp(ctx context.Context, s *pb.Schedule, due, now time.Time) error {
+	sp, err := e.spec(s)
+	if err != nil {
+		s.LastError = err.Error()
+		s.NextRun = ""
+		return e.store.Save(ctx, s)
+	}
+
+	runs := 1
+	if now.Sub(due) > e.misfire {
+		switch e.missed {
+		case MissedSkip:
+			runs = 0
+		case MissedRunAll:
+			runs = 0
+			for t := due; !t.IsZero() && !t.After(now) && runs < maxCatchUp; t = sp.Next(t, s.RunCount+int64(runs)) {
+				runs++
+			}
+		}
+	}
+
+	for range runs {
+		if err := e.dispatch(ctx, s, now, 1); err != nil {
+			return e.commit(ctx, s, err)
+		}
+	}
+	e.advance(s, sp, now)
+	return e.commit(ctx, s, nil)
+}
+
+// fire records a pending history entry that Nebo's ExecutionReports fill in
+// and queues a trigger for s in the outbox. It returns the trigger ID. Only
+// first attempts count toward run_count, so retries do not use up max_runs.
+// The caller commits s, which sends the trigger.
+func (e *Engine) fire(ctx context.Context, s *pb.Schedule, now time.Time, attempt int32, replaces []string) (string, error) {
+	id := newID()
+	err := e.store.AppendHistory(ctx, &pb.ScheduleHistoryEntry{
+		Id:           id,
+		ScheduleName: s.Name,
+		StartedAt:    formatTime(now),
+		TriggerId:    id,
+		Attempt:      attempt,
+	})
+	if err != nil {
+		return "", err
+	}
+	e.outbox = append(e.outbox, &pb.ScheduleTrigger{
+		ScheduleId: s.Id,
+		Name:       s.Name,
+		TaskType:   s.TaskType,
+		Command:    s.Command,
+		Message:    s.Message,
+		Deliver:    s.Deliver,
+		Delivery:   s.Delivery,
+		FiredAt:    formatTime(now),
+		Metadata:   s.Metadata,
+		TriggerId:  id,
+		Attempt:    attempt,
+
+		ReplacesTriggerIds: replaces,
+	})
+	s.LastRun = formatTime(now)
+	if attempt <= 1 {
+		s.RunCount++
+	}
+	return id, nil
+}
+
+// commit saves s, unless err is already set, and then sends the triggers fired
+// since the last commit. If anything failed they are withdrawn instead: no
+// trigger goes out for a run whose next_run and run_count were not saved, so
+// the retry fires it afresh rather than a second time.
+func (e *Engine) commit(ctx context.Context, s *pb.Schedule, err error) error {
+	if err == nil {
+		err = e.store.Save(ctx, s)
+	}
+	out := e.outbox
+	e.outbox = nil
+	if err != nil {
+		now := e.clock.Now()
+		for _, t := range out {
+			delete(e.runState(t.Name).running, t.TriggerId)
+			// Best effort; the store is already failing.
+			e.finishEntry(ctx, t.Name, t.TriggerId, now, "not sent: "+err.Error())
+		}
+		return err
+	}
+	for _, t := range out {
+		e.enqueue(t)
+	}
+	return nil
+}
+
+func (e *Engine) enqueue(t *pb.ScheduleTrigger) {
+	e.qmu.Lock()
+	defer e.qmu.Unlock()
+	if len(e.pending) >= maxPending {
+		e.pending = e.pending[1:]
+	}
+	e.pending = append(e.pending, t)
+	close(e.ready)
+	e.ready = make(chan struct{})
+}
+
+// dequeue blocks until a trigger is pending or ctx is done.
+func (e *Engine) dequeue(ctx context.Context) (*pb.ScheduleTrigger, bool) {
+	for {
+		e.qmu.Lock()
+		if len(e.pending) > 0 {
+			t := e.pending[0]
+			e.pending = e.pending[1:]
+			e.qmu.Unlock()
+			return t, true
+		}
+		ready := e.ready
+		e.qmu.Unlock()
+
+		select {
+		case <-ready:
+		case <-ctx.Done():
+			return nil, false
+		case <-e.stop:
+			return nil, false
+		}
+	}
+}
+
+// requeue puts a trigger that could not be delivered back at the front.
+func (e *Engine) requeue(t *pb.ScheduleTrigger) {
+	e.qmu.Lock()
+	defer e.qmu.Unlock()
+	e.pending = append([]*pb.ScheduleTrigger{t}, e.pending...)
+}
+
+// spec parses the schedule's trigger, falling back to its cron expression for
+// schedules created without one.
+func (e *Engine) spec(s *pb.Schedule) (*Spec, error) {
+	trigger := s.Trigger
+	if trigger == nil {
+		trigger = CronTrigger(s.Expression)
+	}
+	created, _ := time.Parse(time.RFC3339, s.CreatedAt)
+	sp, err := ParseSpec(trigger, created, e.loc)
+	if err != nil {
+		return nil, nebo.WrapError(nebo.CodeInvalidArgument, err)
+	}
+	return sp, nil
+}
+
+// advance sets next_run to the first run after now, plus jitter, or clears it
+// if the schedule has no runs left.
+func (e *Engine) advance(s *pb.Schedule, sp *Spec, now time.Time) {
+	next := sp.Next(now, s.RunCount)
+	if next.IsZero() {
+		s.NextRun = ""
+		return
+	}
+	s.NextRun = formatTime(next.Add(sp.Delay()))
+}
+
+// reschedule validates s's trigger and computes its next run from now.
+// It fails if the trigger will never fire again.
+func (e *Engine) reschedule(s *pb.Schedule, now time.Time) error {
+	sp, err := e.spec(s)
+	if err != nil {
+		return err
+	}
+	e.advance(s, sp, now)
+	if s.NextRun == "" {
+		return nebo.Errorf(nebo.CodeInvalidArgument, "schedule %q has no future runs", s.Name)
+	}
+	return nil
+}
+
+// setTrigger stores trigger on s, or a cron trigger built from expression if
+// trigger is nil. Expression is kept as a human-readable description.
+func (e *Engine) setTrigger(s *pb.Schedule, trigger *pb.TriggerSpec, expression string) error {
+	if trigger == nil {
+		if expression == "" {
+			return nebo.NewError(nebo.CodeInvalidArgument, "expression or trigger is required")
+		}
+		trigger = CronTrigger(expression)
+	}
+	s.Trigger = trigger
+	sp, err := e.spec(s)
+	if err != nil {
+		return err
+	}
+	s.Expression = sp.String()
+	return nil
+}
+
+func validateTask(taskType, command, message string) error {
+	switch taskType {
+	case "bash":
+		if command == "" {
+			return nebo.NewError(nebo.CodeInvalidArgument, "bash schedules require a command")
+		}
+	case "agent":
+		if message == "" {
+			return nebo.NewError(nebo.CodeInvalidArgument, "agent schedules require a message")
+		}
+	default:
+		return nebo.Errorf(nebo.CodeInvalidArgument, "task_type must be \"bash\" or \"agent\", got %q", taskType)
+	}
+	return nil
+}
+
+// resolveDelivery validates typed delivery targets, or parses the legacy
+// deliver string when there are none, and returns both forms.
+func resolveDelivery(targets []*pb.DeliveryTarget, deliver string) ([]*pb.DeliveryTarget, string, error) {
+	if len(targets) == 0 {
+		parsed, err := nebo.ParseDeliver(deliver)
+		return parsed, deliver, err
+	}
+	for _, t := range targets {
+		if err := nebo.ValidateDeliveryTarget(t); err != nil {
+			return nil, "", err
+		}
+	}
+	return targets, nebo.FormatDeliver(targets), nil
+}
+
+// Create validates and stores a new, enabled schedule.
+func (e *Engine) Create(ctx context.Context, req *pb.CreateScheduleRequest) (*pb.Schedule, error) {
+	if req.Name == "" {
+		return nil, nebo.NewError(nebo.CodeInvalidArgument, "name is required")
+	}
+	if err := validateTask(req.TaskType, req.Command, req.Message); err != nil {
+		return nil, err
+	}
+	if err := validatePolicy(req.Policy); err != nil {
+		return nil, err
+	}
+	delivery, deliver, err := resolveDelivery(req.Delivery, req.Deliver)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, err := e.store.Get(ctx, req.Name); err == nil {
+		return nil, nebo.Errorf(nebo.CodeInvalidArgument, "schedule %q already exists", req.Name)
+	} else if !errors.Is(err, &nebo.Error{Code: nebo.CodeNotFound}) {
+		return nil, err
+	}
+	now := e.clock.Now()
+	s := &pb.Schedule{
+		Id:         newID(),
+		Name:       req.Name,
+		Expression: req.Expression,
+		TaskType:   req.TaskType,
+		Command:    req.Command,
+		Message:    req.Message,
+		Deliver:    deliver,
+		Delivery:   delivery,
+		Enabled:    true,
+		CreatedAt:  formatTime(now),
+		Metadata:   req.Metadata,
+		Policy:     req.Policy,
+	}
+	if err := e.setTrigger(s, req.Trigger, req.Expression); err != nil {
+		return nil, err
+	}
+	if err := e.reschedule(s, now); err != nil {
+		return nil, err
+	}
+	if err := e.store.Save(ctx, s); err != nil {
+		return nil, err
+	}
+	e.poke()
+	return s, nil
+}
+
+// Get returns a schedule by name.
+func (e *Engine) Get(ctx context.Context, name string) (*pb.Schedule, error) {
+	return e.store.Get(ctx, name)
+}
+
+// List returns schedules sorted by name.
+func (e *Engine) List(ctx context.Context, limit, offset int32, enabledOnly bool) ([]*pb.Schedule, int64, error) {
+	all, err := e.sorted(ctx, enabledOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+	return paginate(all, int(offset), int(limit)), int64(len(all)), nil
+}
+
+// ListCursor returns schedules sorted by name, starting after the schedule named by cursor.
+func (e *Engine) ListCursor(ctx context.Context, limit int32, cursor string, enabledOnly bool) ([]*pb.Schedule, int64, string, error) {
+	all, err := e.sorted(ctx, enabledOnly)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	start := sort.Search(len(all), func(i int) bool { return all[i].Name > cursor })
+	page := paginate(all, start, int(limit))
+	next := ""
+	if len(page) > 0 && start+len(page) < len(all) {
+		next = page[len(page)-1].Name
+	}
+	return page, int64(len(all)), next, nil
+}
+
+func (e *Engine) sorted(ctx context.Context, enabledOnly bool) ([]*pb.Schedule, error) {
+	all, err := e.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := all[:0]
+	for _, s := range all {
+		if !enabledOnly || s.Enabled {
+			out = append(out, s)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// Update applies the non-empty fields of req to an existing schedule.
+func (e *Engine) Update(ctx context.Context, req *pb.UpdateScheduleRequest) (*pb.Schedule, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	s, err := e.store.Get(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if req.TaskType != "" {
+		s.TaskType = req.TaskType
+	}
+	if req.Command != "" {
+		s.Command = req.Command
+	}
+	if req.Message != "" {
+		s.Message = req.Message
+	}
+	if len(req.Delivery) > 0 || req.Deliver != "" {
+		if s.Delivery, s.Deliver, err = resolveDelivery(req.Delivery, req.Deliver); err != nil {
+			return nil, err
+		}
+	}
+	if req.Metadata != nil {
+		s.Metadata = req.Metadata
+	}
+	if req.Policy != nil {
+		if err := validatePolicy(req.Policy); err != nil {
+			return nil, err
+		}
+		s.Policy = req.Policy
+	}
+	if err := validateTask(s.TaskType, s.Command, s.Message); err != nil {
+		return nil, err
+	}
+	if req.Trigger != nil || req.Expression != "" {
+		if err := e.setTrigger(s, req.Trigger, req.Expression); err != nil {
+			return nil, err
+		}
+		if s.Enabled {
+			if err := e.reschedule(s, e.clock.Now()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := e.store.Save(ctx, s); err != nil {
+		return nil, err
+	}
+	e.poke()
+	return s, nil
+}
+
+// Delete removes a schedule and its history.
+func (e *Engine) Delete(ctx context.Context, name string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := e.store.Delete(ctx, name); err != nil {
+		return err
+	}
+	e.forget(name)
+	e.poke()
+	return nil
+}
+
+// Enable activates a schedule; its next run is computed from now. Enabling a
+// dead-lettered schedule resets its failure count.
+func (e *Engine) Enable(ctx context.Context, name string) (*pb.Schedule, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	s, err := e.store.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.reschedule(s, e.clock.Now()); err != nil {
+		return nil, err
+	}
+	s.Enabled = true
+	s.DeadLettered = false
+	s.ConsecutiveFailures = 0
+	if err := e.store.Save(ctx, s); err != nil {
+		return nil, err
+	}
+	e.poke()
+	return s, nil
+}
+
+// Disable deactivates a schedule without deleting it.
+func (e *Engine) Disable(ctx context.Context, name string) (*pb.Schedule, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	s, err := e.store.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	s.Enabled = false
+	s.NextRun = ""
+	if err := e.store.Save(ctx, s); err != nil {
+		return nil, err
+	}
+	e.poke()
+	return s, nil
+}
+
+// Trigger fires a schedule immediately without changing its next run. Manual
+// runs bypass the overlap policy but count as in flight for scheduled ones.
+func (e *Engine) Trigger(ctx context.Context, name string) (bool, string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	s, err := e.store.Get(ctx, name)
+	if err != nil {
+		return false, "", err
+	}
+	now := e.clock.Now()
+	id, err := e.fire(ctx, s, now, 1, nil)
+	if err == nil {
+		e.runState(name).running[id] = now
+	}
+	if err := e.commit(ctx, s, err); err != nil {
+		return false, "", err
+	}
+	return true, "triggered", nil
+}
+
+// ReportExecution records Nebo's report for a triggered run in history. A
+// finished report also sets the schedule's last_error (cleared on success).
+// A history entry whose finished_at is empty has not been reported as done.
+// Finished reports also drive the schedule's retry, dead-letter and queue
+// policies. Reports for a run that already finished, whether duplicates or
+// late "started" reports, are ignored.
+func (e *Engine) ReportExecution(ctx context.Context, report *pb.ExecutionReport) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	s, err := e.store.Get(ctx, report.ScheduleName)
+	if err != nil {
+		return err
+	}
+	entry, err := e.historyEntry(ctx, report.ScheduleName, report.TriggerId)
+	if err != nil {
+		return err
+	}
+
+	switch report.State {
+	case "started", "succeeded", "failed":
+	default:
+		return nebo.Errorf(nebo.CodeInvalidArgument, "unknown execution state %q", report.State)
+	}
+	if entry.FinishedAt != "" {
+		return nil
+	}
+
+	at := e.clock.Now()
+	now := formatTime(at)
+	switch report.State {
+	case "started":
+		entry.StartedAt = orDefault(report.StartedAt, now)
+	case "succeeded", "failed":
+		if report.StartedAt != "" {
+			entry.StartedAt = report.StartedAt
+		}
+		entry.FinishedAt = orDefault(report.FinishedAt, now)
+		entry.Success = report.State == "succeeded"
+		entry.Output = report.Output
+		entry.Error = report.Error
+		if entry.Success {
+			s.LastError = ""
+		} else {
+			s.LastError = orDefault(report.Error, "failed")
+		}
+		if err := e.store.UpdateHistory(ctx, entry); err != nil {
+			return err
+		}
+		return e.commit(ctx, s, e.finishRun(ctx, s, entry, at))
+	}
+	return e.store.UpdateHistory(ctx, entry)
+}
+
+func (e *Engine) historyEntry(ctx context.Context, name, triggerID string) (*pb.ScheduleHistoryEntry, error) {
+	entries, err := e.store.History(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range entries {
+		if h.Id == triggerID {
+			return h, nil
+		}
+	}
+	return nil, nebo.Errorf(nebo.CodeNotFound, "no run %q for schedule %q", triggerID, name)
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// History returns a schedule's runs, newest first.
+func (e *Engine) History(ctx context.Context, name string, limit, offset int32) ([]*pb.ScheduleHistoryEntry, int64, error) {
+	entries, err := e.newestFirst(ctx, name)
+	if err != nil {
+		return nil, 0, err
+	}
+	return paginate(entries, int(offset), int(limit)), int64(len(entries)), nil
+}
+
+// HistoryCursor returns a schedule's runs, newest first, starting after the
+// entry the cursor points at. The cursor records that entry's position, so
+// pages are found without scanning; a cursor for an entry that is no longer
+// in the history returns CodeInvalidArgument.
+func (e *Engine) HistoryCursor(ctx context.Context, name string, limit int32, cursor string) ([]*pb.ScheduleHistoryEntry, int64, string, error) {
+	if _, err := e.store.Get(ctx, name); err != nil {
+		return nil, 0, "", err
+	}
+	entries, err := e.store.History(ctx, name) // oldest first
+	if err != nil {
+		return nil, 0, "", err
+	}
+	end := len(entries) // the page holds entries before end, newest first
+	if cursor != "" {
+		if end, err = seekCursor(entries, cursor); err != nil {
+			return nil, 0, "", err
+		}
+	}
+	n := int(limit)
+	if n <= 0 || n > end {
+		n = end
+	}
+	page := make([]*pb.ScheduleHistoryEntry, 0, n)
+	for i := end - 1; i >= end-n; i-- {
+		page = append(page, entries[i])
+	}
+	next := ""
+	if n > 0 && end-n > 0 {
+		next = strconv.Itoa(end-n) + ":" + entries[end-n].Id
+	}
+	return page, int64(len(entries)), next, nil
+}
+
+// seekCursor returns the oldest-first position of the entry a HistoryCursor
+// cursor ("<position>:<id>") points at. If older entries were trimmed since
+// the cursor was issued, the entry has moved towards the start, so the
+// search continues downwards from the recorded position.
+func seekCursor(entries []*pb.ScheduleHistoryEntry, cursor string) (int, error) {
+	posText, id, ok := strings.Cut(cursor, ":")
+	pos, err := strconv.Atoi(posText)
+	if !ok || err != nil || pos < 0 {
+		return 0, nebo.Errorf(nebo.CodeInvalidArgument, "invalid history cursor %q", cursor)
+	}
+	for i := min(pos, len(entries)-1); i >= 0; i-- {
+		if entries[i].Id == id {
+			return i, nil
+		}
+	}
+	return 0, nebo.Errorf(nebo.CodeInvalidArgument, "history cursor %q is stale", cursor)
+}
+
+func (e *Engine) newestFirst(ctx context.Context, name string) ([]*pb.ScheduleHistoryEntry, error) {
+	if _, err := e.store.Get(ctx, name); err != nil {
+		return nil, err
+	}
+	entries, err := e.store.History(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
+
+// Triggers streams fired schedules to Nebo. Triggers that fire while no stream
+// is attached are queued and delivered on the next call.
+func (e *Engine) Triggers(ctx context.Context) (<-chan *pb.ScheduleTrigger, error) {
+	e.Start()
+	out := make(chan *pb.ScheduleTrigger)
+	go func() {
+		defer close(out)
+		for {
+			t, ok := e.dequeue(ctx)
+			if !ok {
+				return
+			}
+			select {
+			case out <- t:
+			case <-ctx.Done():
+				e.requeue(t)
+				return
+			case <-e.stop:
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// paginate returns items[offset:offset+limit], clamped. A limit <= 0 means no limit.
+func paginate[T any](items []T, offset, limit int) []T {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(items) {
+		return nil
+	}
+	items = items[offset:]
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func newID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,447 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	nebo "github.com/neboloop/nebo-sdk-go"
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+)
+
+var epoch = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestEngine(t *testing.T, opts ...Option) (*Engine, *ManualClock) {
+	t.Helper()
+	clock := NewManualClock(epoch)
+	e := New(NewMemoryStore(), append([]Option{WithClock(clock), WithLocation(time.UTC)}, opts...)...)
+	t.Cleanup(func() { e.Close() })
+	return e, clock
+}
+
+func createEveryMinute(t *testing.T, e *Engine, name string) *pb.Schedule {
+	t.Helper()
+	s, err := e.Create(context.Background(), &pb.CreateScheduleRequest{
+		Name:       name,
+		Expression: "0 * * * * *",
+		TaskType:   "agent",
+		Message:    "check the news",
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	return s
+}
+
+func TestEngineCreateValidation(t *testing.T) {
+	e, _ := newTestEngine(t)
+	ctx := context.Background()
+
+	tests := []*pb.CreateScheduleRequest{
+		{Expression: "* * * * * *", TaskType: "agent", Message: "x"},
+		{Name: "a", Expression: "nope", TaskType: "agent", Message: "x"},
+		{Name: "a", Expression: "* * * * * *", TaskType: "bash"},
+		{Name: "a", Expression: "* * * * * *", TaskType: "python", Command: "x"},
+	}
+	for _, req := range tests {
+		_, err := e.Create(ctx, req)
+		if !errors.Is(err, &nebo.Error{Code: nebo.CodeInvalidArgument}) {
+			t.Errorf("Create(%+v) err = %v, want invalid_argument", req, err)
+		}
+	}
+
+	createEveryMinute(t, e, "dup")
+	if _, err := e.Create(ctx, &pb.CreateScheduleRequest{Name: "dup", Expression: "* * * * * *", TaskType: "agent", Message: "x"}); err == nil {
+		t.Error("duplicate Create succeeded")
+	}
+	if _, err := e.Get(ctx, "missing"); !errors.Is(err, &nebo.Error{Code: nebo.CodeNotFound}) {
+		t.Errorf("Get(missing) err = %v, want not_found", err)
+	}
+}
+
+func TestEngineFiresTriggers(t *testing.T) {
+	e, clock := newTestEngine(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := createEveryMinute(t, e, "news")
+	if s.NextRun != "2026-01-01T00:01:00Z" {
+		t.Fatalf("NextRun = %s", s.NextRun)
+	}
+
+	ch, err := e.Triggers(ctx)
+	if err != nil {
+		t.Fatalf("Triggers: %v", err)
+	}
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+
+	select {
+	case trig := <-ch:
+		if trig.Name != "news" || trig.Message != "check the news" || trig.FiredAt != "2026-01-01T00:01:00Z" {
+			t.Errorf("trigger = %+v", trig)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no trigger received")
+	}
+
+	got, _ := e.Get(ctx, "news")
+	if got.RunCount != 1 || got.LastRun != "2026-01-01T00:01:00Z" || got.NextRun != "2026-01-01T00:02:00Z" {
+		t.Errorf("after fire: run_count=%d last_run=%s next_run=%s", got.RunCount, got.LastRun, got.NextRun)
+	}
+	hist, total, _ := e.History(ctx, "news", 10, 0)
+	if total != 1 || len(hist) != 1 {
+		t.Errorf("history total = %d", total)
+	}
+}
+
+func TestEngineMissedRunPolicies(t *testing.T) {
+	tests := []struct {
+		policy MissedRunPolicy
+		want   int64
+	}{
+		{MissedRunOnce, 1},
+		{MissedSkip, 0},
+		{MissedRunAll, 10},
+	}
+	for _, tt := range tests {
+		e, _ := newTestEngine(t, WithMissedRunPolicy(tt.policy))
+		createEveryMinute(t, e, "m")
+
+		// Wake up ten minutes late.
+		next := e.tick(epoch.Add(10 * time.Minute))
+
+		s, _ := e.Get(context.Background(), "m")
+		if s.RunCount != tt.want {
+			t.Errorf("policy %d: run_count = %d, want %d", tt.policy, s.RunCount, tt.want)
+		}
+		if want := epoch.Add(11 * time.Minute); !next.Equal(want) {
+			t.Errorf("policy %d: next = %v, want %v", tt.policy, next, want)
+		}
+	}
+}
+
+func TestEngineEnableDisable(t *testing.T) {
+	e, _ := newTestEngine(t)
+	ctx := context.Background()
+	createEveryMinute(t, e, "x")
+
+	s, err := e.Disable(ctx, "x")
+	if err != nil || s.Enabled || s.NextRun != "" {
+		t.Fatalf("Disable = %+v, %v", s, err)
+	}
+	if next := e.tick(epoch.Add(5 * time.Minute)); !next.IsZero() {
+		t.Errorf("disabled schedule still scheduled at %v", next)
+	}
+
+	s, err = e.Enable(ctx, "x")
+	if err != nil || !s.Enabled || s.NextRun != "2026-01-01T00:01:00Z" {
+		t.Fatalf("Enable = %+v, %v", s, err)
+	}
+
+	list, total, _ := e.List(ctx, 0, 0, true)
+	if total != 1 || len(list) != 1 {
+		t.Errorf("List enabled total = %d", total)
+	}
+}
+
+func TestEngineManualTrigger(t *testing.T) {
+	e, _ := newTestEngine(t)
+	ctx := context.Background()
+	createEveryMinute(t, e, "x")
+
+	ok, _, err := e.Trigger(ctx, "x")
+	if err != nil || !ok {
+		t.Fatalf("Trigger = %v, %v", ok, err)
+	}
+	s, _ := e.Get(ctx, "x")
+	if s.RunCount != 1 || s.NextRun != "2026-01-01T00:01:00Z" {
+		t.Errorf("after Trigger: run_count=%d next_run=%s", s.RunCount, s.NextRun)
+	}
+
+	// The trigger was queued while no stream was attached.
+	ch, _ := e.Triggers(ctx)
+	select {
+	case trig := <-ch:
+		if trig.Name != "x" {
+			t.Errorf("trigger = %+v", trig)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("queued trigger not delivered")
+	}
+}
+
+func TestEngineOneShotTrigger(t *testing.T) {
+	e, clock := newTestEngine(t)
+	ctx := context.Background()
+
+	s, err := e.Create(ctx, &pb.CreateScheduleRequest{
+		Name:     "launch",
+		Trigger:  OnceTrigger(epoch.Add(time.Hour)),
+		TaskType: "agent",
+		Message:  "announce the launch",
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if s.Expression != "at 2026-01-01T01:00:00Z" || s.NextRun != "2026-01-01T01:00:00Z" {
+		t.Errorf("created = expression %q next_run %q", s.Expression, s.NextRun)
+	}
+
+	clock.Advance(time.Hour)
+	if next := e.tick(clock.Now()); !next.IsZero() {
+		t.Errorf("one-shot rescheduled at %v", next)
+	}
+	s, _ = e.Get(ctx, "launch")
+	if s.RunCount != 1 || s.NextRun != "" {
+		t.Errorf("after firing: run_count=%d next_run=%q", s.RunCount, s.NextRun)
+	}
+
+	if _, err := e.Enable(ctx, "launch"); err == nil {
+		t.Error("re-enabling a finished one-shot should fail")
+	}
+}
+
+func TestEngineReportExecution(t *testing.T) {
+	e, clock := newTestEngine(t)
+	ctx := context.Background()
+	createEveryMinute(t, e, "x")
+
+	clock.Advance(time.Minute)
+	e.tick(clock.Now())
+	ch, _ := e.Triggers(ctx)
+	trig := <-ch
+	if trig.TriggerId == "" {
+		t.Fatal("trigger has no TriggerId")
+	}
+
+	hist, _, _ := e.History(ctx, "x", 1, 0)
+	if hist[0].TriggerId != trig.TriggerId || hist[0].FinishedAt != "" {
+		t.Fatalf("pending entry = %+v", hist[0])
+	}
+
+	err := e.ReportExecution(ctx, &pb.ExecutionReport{TriggerId: trig.TriggerId, ScheduleName: "x", State: "started", StartedAt: "2026-01-01T00:01:02Z"})
+	if err != nil {
+		t.Fatalf("report started: %v", err)
+	}
+	err = e.ReportExecution(ctx, &pb.ExecutionReport{TriggerId: trig.TriggerId, ScheduleName: "x", State: "failed", Error: "agent timed out"})
+	if err != nil {
+		t.Fatalf("report failed: %v", err)
+	}
+
+	hist, _, _ = e.History(ctx, "x", 1, 0)
+	h := hist[0]
+	if h.Success || h.Error != "agent timed out" || h.StartedAt != "2026-01-01T00:01:02Z" || h.FinishedAt == "" {
+		t.Errorf("finished entry = %+v", h)
+	}
+	s, _ := e.Get(ctx, "x")
+	if s.LastError != "agent timed out" {
+		t.Errorf("LastError = %q", s.LastError)
+	}
+
+	if err := e.ReportExecution(ctx, &pb.ExecutionReport{TriggerId: "nope", ScheduleName: "x", State: "started"}); !errors.Is(err, &nebo.Error{Code: nebo.CodeNotFound}) {
+		t.Errorf("unknown trigger err = %v, want not_found", err)
+	}
+}
+
+func TestEngineDelivery(t *testing.T) {
+	e, _ := newTestEngine(t)
+	ctx := context.Background()
+
+	s, err := e.Create(ctx, &pb.CreateScheduleRequest{
+		Name:       "legacy",
+		Expression: "0 * * * * *",
+		TaskType:   "agent",
+		Message:    "m",
+		Deliver:    `{"channel":"telegram","to":"123"}`,
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if len(s.Delivery) != 1 || s.Delivery[0].Channel != "telegram" {
+		t.Errorf("parsed delivery = %+v", s.Delivery)
+	}
+
+	s, err = e.Update(ctx, &pb.UpdateScheduleRequest{
+		Name:     "legacy",
+		Delivery: []*pb.DeliveryTarget{{Channel: "slack", To: "C1"}, {Channel: "email", To: "a@b.c"}},
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if s.Deliver != `{"channel":"slack","to":"C1"}` {
+		t.Errorf("legacy deliver = %s", s.Deliver)
+	}
+
+	e.Trigger(ctx, "legacy")
+	ch, _ := e.Triggers(ctx)
+	if trig := <-ch; len(trig.Delivery) != 2 {
+		t.Errorf("trigger delivery = %+v", trig.Delivery)
+	}
+
+	_, err = e.Update(ctx, &pb.UpdateScheduleRequest{Name: "legacy", Deliver: "telegram"})
+	if !errors.Is(err, &nebo.Error{Code: nebo.CodeInvalidArgument}) {
+		t.Errorf("Update with bad deliver err = %v, want invalid_argument", err)
+	}
+}
+
+func TestEngineHistoryCursor(t *testing.T) {
+	ctx := context.Background()
+	store, err := OpenFileStore(t.TempDir(), WithHistoryLimit(5), WithSync(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := New(store, WithClock(NewManualClock(epoch)), WithLocation(time.UTC))
+	defer e.Close()
+	createEveryMinute(t, e, "x")
+	add := func(ids ...string) {
+		for _, id := range ids {
+			store.AppendHistory(ctx, &pb.ScheduleHistoryEntry{Id: id, ScheduleName: "x"})
+		}
+	}
+	ids := func(entries []*pb.ScheduleHistoryEntry) string {
+		var s []string
+		for _, h := range entries {
+			s = append(s, h.Id)
+		}
+		return strings.Join(s, ",")
+	}
+
+	add("h0", "h1", "h2", "h3", "h4")
+	page, total, next, err := e.HistoryCursor(ctx, "x", 2, "")
+	if err != nil || ids(page) != "h4,h3" || total != 5 || next == "" {
+		t.Fatalf("page 1 = %s, %d, %q, %v", ids(page), total, next, err)
+	}
+
+	// A new run trims h0; the cursor still finds h3.
+	add("h5")
+	page, _, next, err = e.HistoryCursor(ctx, "x", 2, next)
+	if err != nil || ids(page) != "h2,h1" || next != "" {
+		t.Errorf("page 2 = %s, %q, %v; want h2,h1 and no next page", ids(page), next, err)
+	}
+
+	_, _, next, _ = e.HistoryCursor(ctx, "x", 1, "")
+	add("h6", "h7", "h8", "h9", "h10") // trims the cursor's entry
+	for _, cursor := range []string{next, "bogus", "99:nope"} {
+		if _, _, _, err := e.HistoryCursor(ctx, "x", 2, cursor); !errors.Is(err, &nebo.Error{Code: nebo.CodeInvalidArgument}) {
+			t.Errorf("cursor %q: err = %v, want invalid_argument", cursor, err)
+		}
+	}
+}
+
+// failingSaveStore is a MemoryStore whose Save fails once fail is set.
+type failingSaveStore struct {
+	*MemoryStore
+	fail bool
+}
+
+func (s *failingSaveStore) Save(ctx context.Context, sched *pb.Schedule) error {
+	if s.fail {
+		return errors.New("disk full")
+	}
+	return s.MemoryStore.Save(ctx, sched)
+}
+
+func TestEngineTickRetriesFailedSave(t *testing.T) {
+	store := &failingSaveStore{MemoryStore: NewMemoryStore()}
+	store.Save(context.Background(), &pb.Schedule{Name: "x", Enabled: true, NextRun: "garbage"})
+	store.fail = true
+	e := New(store, WithClock(NewManualClock(epoch)), WithLocation(time.UTC))
+	defer e.Close()
+
+	if next := e.tick(epoch); !next.Equal(epoch.Add(storeRetry)) {
+		t.Errorf("tick = %v, want a store retry at %v", next, epoch.Add(storeRetry))
+	}
+}
+
+func TestEngineRejectsRRuleCount(t *testing.T) {
+	e, _ := newTestEngine(t)
+	_, err := e.Create(context.Background(), &pb.CreateScheduleRequest{Name: "x", Trigger: RRuleTrigger("FREQ=DAILY;COUNT=0"), TaskType: "agent", Message: "m"})
+	if !errors.Is(err, &nebo.Error{Code: nebo.CodeInvalidArgument}) {
+		t.Errorf("Create with COUNT=0 err = %v, want invalid_argument", err)
+	}
+}
+
+func TestEngineSendsTriggersOnlyAfterSave(t *testing.T) {
+	store := &failingSaveStore{MemoryStore: NewMemoryStore()}
+	e := New(store, WithClock(NewManualClock(epoch)), WithLocation(time.UTC))
+	defer e.Close()
+	createEveryMinute(t, e, "x")
+	ctx := context.Background()
+
+	store.fail = true
+	e.tick(epoch.Add(time.Minute))
+	if got := pendingIDs(e); len(got) != 0 {
+		t.Errorf("%d triggers sent although the schedule was not saved", len(got))
+	}
+	hist, _, _ := e.History(ctx, "x", 0, 0)
+	if len(hist) != 1 || !strings.HasPrefix(hist[0].Error, "not sent:") {
+		t.Errorf("history = %+v, want one withdrawn run", hist)
+	}
+
+	store.fail = false
+	e.tick(epoch.Add(time.Minute))
+	if got := pendingIDs(e); len(got) != 1 {
+		t.Errorf("%d triggers after the store recovered, want 1", len(got))
+	}
+	if s, _ := e.Get(ctx, "x"); s.RunCount != 1 {
+		t.Errorf("RunCount = %d, want 1", s.RunCount)
+	}
+}
+
+// brokenStore is a MemoryStore whose Get fails with a non-not-found error.
+type brokenStore struct{ *MemoryStore }
+
+func (brokenStore) Get(context.Context, string) (*pb.Schedule, error) {
+	return nil, errors.New("disk unreadable")
+}
+
+func TestEngineCreateStoreError(t *testing.T) {
+	e := New(brokenStore{NewMemoryStore()}, WithClock(NewManualClock(epoch)), WithLocation(time.UTC))
+	defer e.Close()
+	_, err := e.Create(context.Background(), &pb.CreateScheduleRequest{Name: "x", Expression: "@every 1m", TaskType: "agent", Message: "m"})
+	if err == nil || err.Error() != "disk unreadable" {
+		t.Errorf("Create err = %v, want the store error", err)
+	}
+}
+
+func TestEngineReleasesAbandonedTimers(t *testing.T) {
+	e, clock := newTestEngine(t)
+	createEveryMinute(t, e, "a")
+	e.Start()
+	clock.BlockUntil(1)
+
+	// Each change wakes the loop, which abandons its timer and sleeps again.
+	for _, name := range []string{"b", "c", "d"} {
+		createEveryMinute(t, e, name)
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		clock.mu.Lock()
+		n := len(clock.waiters)
+		clock.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d timers pending, want 1", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestManualClockStop(t *testing.T) {
+	c := NewManualClock(epoch)
+	ch := c.After(time.Minute)
+	if !c.Stop(ch) || c.Stop(ch) {
+		t.Error("Stop should report the timer pending only once")
+	}
+	c.Advance(time.Hour)
+	select {
+	case <-ch:
+		t.Error("stopped timer fired")
+	default:
+	}
+}
@@ -0,0 +1,455 @@
+package schedule
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// logName is the append-only log inside a FileStore directory.
+const logName = "schedules.log"
+
+// compactMinRecords is the log length below which FileStore never compacts.
+const compactMinRecords = 1024
+
+// FileOption configures a FileStore.
+type FileOption func(*FileStore)
+
+// WithHistoryLimit keeps at most n history entries per schedule (default 100).
+// Zero or less keeps everything.
+func WithHistoryLimit(n int) FileOption {
+	return func(s *FileStore) { s.historyLimit = n }
+}
+
+// WithHistoryMaxAge drops history entries that started more than d ago.
+// Zero (the default) keeps entries regardless of age.
+func WithHistoryMaxAge(d time.Duration) FileOption {
+	return func(s *FileStore) { s.historyMaxAge = d }
+}
+
+// WithSync controls whether every write is fsynced before returning (default true).
+// Disabling it trades durability on power loss for throughput.
+func WithSync(sync bool) FileOption {
+	return func(s *FileStore) { s.sync = sync }
+}
+
+// FileStore is a durable Store backed by an append-only log in a directory,
+// typically under AppEnv.DataDir:
+//
+//	store, err := schedule.OpenFileStore(filepath.Join(app.Env().DataDir, "schedules"))
+//
+// Every change is appended as one JSON line. On open the log is replayed;
+// a torn final line left by a crash is discarded. When the log grows to more
+// than twice the live data it is compacted by writing a snapshot to a
+// temporary file and atomically renaming it over the log.
+type FileStore struct {
+	mu   sync.Mutex
+	dir  string
+	f    *os.File
+	size int64 // bytes of complete records in the log
+	// records counts lines in the log, used to decide when to compact.
+	records int
+
+	schedules map[string]*pb.Schedule
+	history   map[string][]*pb.ScheduleHistoryEntry
+
+	historyLimit  int
+	historyMaxAge time.Duration
+	sync          bool
+	now           func() time.Time
+}
+
+var _ Store = (*FileStore)(nil)
+
+// logRecord is one line of the log.
+type logRecord struct {
+	Op       string          `json:"op"` // "put", "delete", "history"
+	Name     string          `json:"name,omitempty"`
+	Schedule json.RawMessage `json:"schedule,omitempty"`
+	Entry    json.RawMessage `json:"entry,omitempty"`
+}
+
+// OpenFileStore opens or creates a FileStore in dir.
+func OpenFileStore(dir string, opts ...FileOption) (*FileStore, error) {
+	s := &FileStore{
+		dir:          dir,
+		schedules:    make(map[string]*pb.Schedule),
+		history:      make(map[string][]*pb.ScheduleHistoryEntry),
+		historyLimit: 100,
+		sync:         true,
+		now:          time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create store dir: %w", err)
+	}
+	// A leftover temp file means a compaction died before its rename; the log is intact.
+	os.Remove(s.logPath() + ".tmp")
+
+	f, err := os.OpenFile(s.logPath(), os.O_RDWR|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open store log: %w", err)
+	}
+	if err := s.replay(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+	s.f = f
+	return s, nil
+}
+
+func (s *FileStore) logPath() string {
+	return filepath.Join(s.dir, logName)
+}
+
+// replay rebuilds state from the log. A final line that is missing its
+// newline or does not parse is treated as a torn write and truncated;
+// corruption anywhere else is an error.
+func (s *FileStore) replay(f *os.File) error {
+	r := bufio.NewReader(f)
+	var offset int64
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			if len(line) > 0 {
+				if err := f.Truncate(offset); err != nil {
+					return fmt.Errorf("truncate torn log record: %w", err)
+				}
+			}
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read store log: %w", err)
+		}
+		if err := s.applyLine(line); err != nil {
+			if _, peekErr := r.Peek(1); peekErr == io.EOF {
+				if err := f.Truncate(offset); err != nil {
+					return fmt.Errorf("truncate torn log record: %w", err)
+				}
+				break
+			}
+			return fmt.Errorf("corrupt store log at byte %d: %w", offset, err)
+		}
+		offset += int64(len(line))
+		s.records++
+	}
+	s.size = offset
+	return nil
+}
+
+func (s *FileStore) applyLine(line []byte) error {
+	var rec logRecord
+	if err := json.Unmarshal(bytes.TrimSpace(line), &rec); err != nil {
+		return err
+	}
+	switch rec.Op {
+	case "put":
+		sched := &pb.Schedule{}
+		if err := protojson.Unmarshal(rec.Schedule, sched); err != nil {
+			return err
+		}
+		s.schedules[sched.Name] = sched
+	case "delete":
+		delete(s.schedules, rec.Name)
+		delete(s.history, rec.Name)
+	case "history":
+		entry := &pb.ScheduleHistoryEntry{}
+		if err := protojson.Unmarshal(rec.Entry, entry); err != nil {
+			return err
+		}
+		s.putEntry(entry)
+	default:
+		return fmt.Errorf("unknown op %q", rec.Op)
+	}
+	return nil
+}
+
+// putEntry adds an entry in memory, or replaces the one with the same ID, and
+// applies retention. Must hold s.mu (or be replaying).
+func (s *FileStore) putEntry(entry *pb.ScheduleHistoryEntry) {
+	entries := s.history[entry.ScheduleName]
+	if i := entryIndex(entries, entry.Id); i >= 0 {
+		entries[i] = entry
+		return
+	}
+	entries = append(entries, entry)
+	if s.historyMaxAge > 0 {
+		cutoff := s.now().Add(-s.historyMaxAge)
+		keep := entries[:0]
+		for _, e := range entries {
+			if t, err := time.Parse(time.RFC3339, e.StartedAt); err == nil && t.Before(cutoff) {
+				continue
+			}
+			keep = append(keep, e)
+		}
+		entries = keep
+	}
+	if s.historyLimit > 0 && len(entries) > s.historyLimit {
+		entries = entries[len(entries)-s.historyLimit:]
+	}
+	s.history[entry.ScheduleName] = entries
+}
+
+// write appends one record to the log. Must hold s.mu.
+func (s *FileStore) write(rec logRecord) error {
+	if s.f == nil {
+		return errors.New("schedule store is closed")
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+	if _, err := s.f.Write(line); err != nil {
+		// Drop any partial write so the next record starts on a clean line.
+		s.f.Truncate(s.size)
+		return fmt.Errorf("append store log: %w", err)
+	}
+	if s.sync {
+		if err := s.f.Sync(); err != nil {
+			return fmt.Errorf("sync store log: %w", err)
+		}
+	}
+	s.size += int64(len(line))
+	s.records++
+	return nil
+}
+
+// maybeCompact compacts the log once it holds more than twice the live records.
+// Called after in-memory state reflects the last write. A failed compaction
+// leaves the log intact, so the error is not reported to the caller of Save.
+// Must hold s.mu.
+func (s *FileStore) maybeCompact() {
+	if s.records > compactMinRecords && s.records > 2*s.live() {
+		s.compact()
+	}
+}
+
+// live is the number of records a fresh snapshot would contain.
+func (s *FileStore) live() int {
+	n := len(s.schedules)
+	for _, entries := range s.history {
+		n += len(entries)
+	}
+	return n
+}
+
+// Compact rewrites the log as a snapshot of the current state.
+func (s *FileStore) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return errors.New("schedule store is closed")
+	}
+	return s.compact()
+}
+
+func (s *FileStore) compact() error {
+	tmpPath := s.logPath() + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("compact store: %w", err)
+	}
+	w := bufio.NewWriter(tmp)
+	var size int64
+	records := 0
+	emit := func(rec logRecord) error {
+		line, err := json.Marshal(rec)
+		if err != nil {
+			return err
+		}
+		n, err := w.Write(append(line, '\n'))
+		size += int64(n)
+		records++
+		return err
+	}
+
+	err = func() error {
+		for _, sched := range s.schedules {
+			data, err := protojson.Marshal(sched)
+			if err != nil {
+				return err
+			}
+			if err := emit(logRecord{Op: "put", Schedule: data}); err != nil {
+				return err
+			}
+		}
+		for _, entries := range s.history {
+			for _, e := range entries {
+				data, err := protojson.Marshal(e)
+				if err != nil {
+					return err
+				}
+				if err := emit(logRecord{Op: "history", Entry: data}); err != nil {
+					return err
+				}
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		return tmp.Sync()
+	}()
+	tmp.Close()
+	if err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("compact store: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, s.logPath()); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("compact store: %w", err)
+	}
+	syncDir(s.dir)
+
+	f, err := os.OpenFile(s.logPath(), os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		s.f.Close()
+		s.f = nil
+		return fmt.Errorf("reopen store log: %w", err)
+	}
+	s.f.Close()
+	s.f = f
+	s.size = size
+	s.records = records
+	return nil
+}
+
+// syncDir fsyncs a directory so a rename inside it is durable. Best effort:
+// not every platform supports it.
+func syncDir(dir string) {
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+}
+
+// Close flushes and closes the log.
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return nil
+	}
+	err := s.f.Close()
+	s.f = nil
+	return err
+}
+
+func (s *FileStore) Save(_ context.Context, sched *pb.Schedule) error {
+	data, err := protojson.Marshal(sched)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.write(logRecord{Op: "put", Schedule: data}); err != nil {
+		return err
+	}
+	s.schedules[sched.Name] = proto.Clone(sched).(*pb.Schedule)
+	s.maybeCompact()
+	return nil
+}
+
+func (s *FileStore) Get(_ context.Context, name string) (*pb.Schedule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sched, ok := s.schedules[name]
+	if !ok {
+		return nil, errNotFound(name)
+	}
+	return proto.Clone(sched).(*pb.Schedule), nil
+}
+
+func (s *FileStore) List(_ context.Context) ([]*pb.Schedule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]*pb.Schedule, 0, len(s.schedules))
+	for _, sched := range s.schedules {
+		out = append(out, proto.Clone(sched).(*pb.Schedule))
+	}
+	return out, nil
+}
+
+func (s *FileStore) Delete(_ context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.schedules[name]; !ok {
+		return errNotFound(name)
+	}
+	if err := s.write(logRecord{Op: "delete", Name: name}); err != nil {
+		return err
+	}
+	delete(s.schedules, name)
+	delete(s.history, name)
+	s.maybeCompact()
+	return nil
+}
+
+func (s *FileStore) AppendHistory(_ context.Context, entry *pb.ScheduleHistoryEntry) error {
+	data, err := protojson.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.write(logRecord{Op: "history", Entry: data}); err != nil {
+		return err
+	}
+	s.putEntry(proto.Clone(entry).(*pb.ScheduleHistoryEntry))
+	s.maybeCompact()
+	return nil
+}
+
+func (s *FileStore) UpdateHistory(_ context.Context, entry *pb.ScheduleHistoryEntry) error {
+	data, err := protojson.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entryIndex(s.history[entry.ScheduleName], entry.Id) < 0 {
+		return errHistoryNotFound(entry)
+	}
+	if err := s.write(logRecord{Op: "history", Entry: data}); err != nil {
+		return err
+	}
+	s.putEntry(proto.Clone(entry).(*pb.ScheduleHistoryEntry))
+	s.maybeCompact()
+	return nil
+}
+
+func entryIndex(entries []*pb.ScheduleHistoryEntry, id string) int {
+	for i, e := range entries {
+		if e.Id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *FileStore) History(_ context.Context, name string) ([]*pb.ScheduleHistoryEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := s.history[name]
+	out := make([]*pb.ScheduleHistoryEntry, len(entries))
+	for i, e := range entries {
+		out[i] = proto.Clone(e).(*pb.ScheduleHistoryEntry)
+	}
+	return out, nil
+}
@@ -0,0 +1,123 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+)
+
+func TestFileStoreReopen(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	s, err := OpenFileStore(dir)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	s.Save(ctx, &pb.Schedule{Name: "a", Expression: "* * * * * *", RunCount: 1})
+	s.Save(ctx, &pb.Schedule{Name: "b"})
+	s.Save(ctx, &pb.Schedule{Name: "a", Expression: "* * * * * *", RunCount: 2})
+	s.AppendHistory(ctx, &pb.ScheduleHistoryEntry{Id: "h1", ScheduleName: "a"})
+	s.Delete(ctx, "b")
+	s.Close()
+
+	s, err = OpenFileStore(dir)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer s.Close()
+
+	a, err := s.Get(ctx, "a")
+	if err != nil || a.RunCount != 2 {
+		t.Errorf("Get(a) = %+v, %v", a, err)
+	}
+	if _, err := s.Get(ctx, "b"); err == nil {
+		t.Error("deleted schedule b survived reopen")
+	}
+	hist, _ := s.History(ctx, "a")
+	if len(hist) != 1 || hist[0].Id != "h1" {
+		t.Errorf("History = %v", hist)
+	}
+}
+
+func TestFileStoreTornWrite(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	s, _ := OpenFileStore(dir)
+	s.Save(ctx, &pb.Schedule{Name: "a"})
+	s.Close()
+
+	// Simulate a crash halfway through appending a record.
+	f, _ := os.OpenFile(filepath.Join(dir, logName), os.O_WRONLY|os.O_APPEND, 0o600)
+	f.WriteString(`{"op":"put","schedule":{"name":"b"`)
+	f.Close()
+
+	s, err := OpenFileStore(dir)
+	if err != nil {
+		t.Fatalf("reopen after torn write: %v", err)
+	}
+	if _, err := s.Get(ctx, "a"); err != nil {
+		t.Errorf("Get(a): %v", err)
+	}
+	if err := s.Save(ctx, &pb.Schedule{Name: "c"}); err != nil {
+		t.Fatalf("Save after recovery: %v", err)
+	}
+	s.Close()
+
+	s, err = OpenFileStore(dir)
+	if err != nil {
+		t.Fatalf("second reopen: %v", err)
+	}
+	defer s.Close()
+	list, _ := s.List(ctx)
+	if len(list) != 2 {
+		t.Errorf("List = %d schedules, want 2", len(list))
+	}
+}
+
+func TestFileStoreCorruptMiddle(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, logName), []byte("garbage\n{\"op\":\"delete\",\"name\":\"x\"}\n"), 0o600)
+
+	if _, err := OpenFileStore(dir); err == nil {
+		t.Fatal("expected error for corruption before the last record")
+	}
+}
+
+func TestFileStoreHistoryLimitAndCompaction(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	s, err := OpenFileStore(dir, WithHistoryLimit(5), WithSync(false))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	s.Save(ctx, &pb.Schedule{Name: "a"})
+	for i := range 2000 {
+		s.AppendHistory(ctx, &pb.ScheduleHistoryEntry{Id: fmt.Sprint(i), ScheduleName: "a"})
+	}
+
+	hist, _ := s.History(ctx, "a")
+	if len(hist) != 5 || hist[4].Id != "1999" {
+		t.Fatalf("History = %d entries (last %s), want 5 ending at 1999", len(hist), hist[len(hist)-1].Id)
+	}
+	if s.records > compactMinRecords+1 {
+		t.Errorf("log has %d records; expected compaction", s.records)
+	}
+	s.Close()
+
+	s, err = OpenFileStore(dir, WithHistoryLimit(5))
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer s.Close()
+	hist, _ = s.History(ctx, "a")
+	if len(hist) != 5 || hist[0].Id != "1995" {
+		t.Errorf("History after reopen = %v", hist)
+	}
+}
@@ -0,0 +1,261 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	nebo "github.com/neboloop/nebo-sdk-go"
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+)
+
+// Overlap policies for pb.ExecutionPolicy.Overlap.
+const (
+	OverlapAllow   = "allow"
+	OverlapSkip    = "skip"
+	OverlapQueue   = "queue"
+	OverlapReplace = "replace"
+)
+
+// defaultRunTimeout is how long a run counts as in flight without a finish report.
+const defaultRunTimeout = time.Hour
+
+// maxQueued caps runs waiting under OverlapQueue for a single schedule.
+const maxQueued = 100
+
+// runState tracks a schedule's in-flight and queued runs. It lives in memory
+// only: after a restart nothing is considered in flight.
+type runState struct {
+	running map[string]time.Time // trigger ID -> fired at
+	queued  int
+}
+
+// retryRun is a failed run waiting to be retried.
+type retryRun struct {
+	name    string
+	attempt int32
+	at      time.Time
+}
+
+func validatePolicy(p *pb.ExecutionPolicy) error {
+	if p == nil {
+		return nil
+	}
+	switch p.Overlap {
+	case "", OverlapAllow, OverlapSkip, OverlapQueue, OverlapReplace:
+	default:
+		return nebo.Errorf(nebo.CodeInvalidArgument, "overlap must be allow, skip, queue or replace, got %q", p.Overlap)
+	}
+	if p.MaxConcurrent < 0 || p.MaxRetries < 0 || p.RetryBackoffSeconds < 0 ||
+		p.MaxBackoffSeconds < 0 || p.DeadLetterAfter < 0 || p.RunTimeoutSeconds < 0 {
+		return nebo.NewError(nebo.CodeInvalidArgument, "execution policy values must not be negative")
+	}
+	return nil
+}
+
+// backoff returns the delay before retry number attempt-1 (attempt >= 2).
+func backoff(p *pb.ExecutionPolicy, attempt int32) time.Duration {
+	d := time.Duration(p.GetRetryBackoffSeconds()) * time.Second
+	for i := int32(2); i < attempt && d < 24*time.Hour; i++ {
+		d *= 2
+	}
+	if limit := time.Duration(p.GetMaxBackoffSeconds()) * time.Second; limit > 0 && d > limit {
+		d = limit
+	}
+	return d
+}
+
+func (e *Engine) runState(name string) *runState {
+	rs, ok := e.runs[name]
+	if !ok {
+		rs = &runState{running: make(map[string]time.Time)}
+		e.runs[name] = rs
+	}
+	return rs
+}
+
+// expireRuns stops counting runs that never reported back as in flight.
+func (e *Engine) expireRuns(s *pb.Schedule, rs *runState, now time.Time) {
+	timeout := time.Duration(s.Policy.GetRunTimeoutSeconds()) * time.Second
+	if timeout == 0 {
+		timeout = defaultRunTimeout
+	}
+	for id, fired := range rs.running {
+		if now.Sub(fired) >= timeout {
+			delete(rs.running, id)
+		}
+	}
+}
+
+// dispatch fires s unless its overlap policy says otherwise. The caller saves s.
+func (e *Engine) dispatch(ctx context.Context, s *pb.Schedule, now time.Time, attempt int32) error {
+	rs := e.runState(s.Name)
+	e.expireRuns(s, rs, now)
+	inFlight := len(rs.running)
+
+	// MaxConcurrent bounds every policy; skip, queue and replace default to
+	// one run in flight, allow to unlimited.
+	limit := int(s.Policy.GetMaxConcurrent())
+	var replaces []string
+	switch s.Policy.GetOverlap() {
+	case OverlapSkip:
+		if inFlight >= max(limit, 1) {
+			return e.recordSkipped(ctx, s, now, "previous run still in progress")
+		}
+	case OverlapQueue:
+		if inFlight >= max(limit, 1) {
+			if rs.queued < maxQueued {
+				rs.queued++
+			}
+			return nil
+		}
+	case OverlapReplace:
+		if excess := inFlight - max(limit, 1) + 1; excess > 0 {
+			ids := make([]string, 0, inFlight)
+			for id := range rs.running {
+				ids = append(ids, id)
+			}
+			// Replace the oldest runs first.
+			sort.Slice(ids, func(i, j int) bool {
+				a, b := rs.running[ids[i]], rs.running[ids[j]]
+				if !a.Equal(b) {
+					return a.Before(b)
+				}
+				return ids[i] < ids[j]
+			})
+			for _, id := range ids[:excess] {
+				replaces = append(replaces, id)
+				delete(rs.running, id)
+				if err := e.finishEntry(ctx, s.Name, id, now, "replaced by a newer run"); err != nil {
+					return err
+				}
+			}
+		}
+	default:
+		if limit > 0 && inFlight >= limit {
+			return e.recordSkipped(ctx, s, now, fmt.Sprintf("%d runs already in progress", inFlight))
+		}
+	}
+
+	id, err := e.fire(ctx, s, now, attempt, replaces)
+	if err != nil {
+		return err
+	}
+	rs.running[id] = now
+	return nil
+}
+
+// recordSkipped adds a history entry for a run the overlap policy did not start.
+func (e *Engine) recordSkipped(ctx context.Context, s *pb.Schedule, now time.Time, reason string) error {
+	return e.store.AppendHistory(ctx, &pb.ScheduleHistoryEntry{
+		Id:           newID(),
+		ScheduleName: s.Name,
+		StartedAt:    formatTime(now),
+		FinishedAt:   formatTime(now),
+		Error:        "skipped: " + reason,
+	})
+}
+
+// finishEntry marks a pending history entry as failed with reason.
+func (e *Engine) finishEntry(ctx context.Context, name, id string, now time.Time, reason string) error {
+	entry, err := e.historyEntry(ctx, name, id)
+	if err != nil {
+		// Trimmed by retention; nothing to update.
+		return nil
+	}
+	if entry.FinishedAt != "" {
+		return nil
+	}
+	entry.FinishedAt = formatTime(now)
+	entry.Error = reason
+	return e.store.UpdateHistory(ctx, entry)
+}
+
+// finishRun applies retry, dead-letter and queue policies once a run of s has
+// finished. The caller saves s.
+func (e *Engine) finishRun(ctx context.Context, s *pb.Schedule, entry *pb.ScheduleHistoryEntry, now time.Time) error {
+	rs := e.runState(s.Name)
+	delete(rs.running, entry.Id)
+
+	if entry.Success {
+		s.ConsecutiveFailures = 0
+	} else {
+		attempt := max(entry.Attempt, 1)
+		if attempt <= s.Policy.GetMaxRetries() {
+			next := attempt + 1
+			e.retries = append(e.retries, retryRun{name: s.Name, attempt: next, at: now.Add(backoff(s.Policy, next))})
+			e.poke()
+			return nil
+		}
+		s.ConsecutiveFailures++
+		if limit := s.Policy.GetDeadLetterAfter(); limit > 0 && s.ConsecutiveFailures >= limit {
+			s.Enabled = false
+			s.NextRun = ""
+			s.DeadLettered = true
+			s.LastError = fmt.Sprintf("dead-lettered after %d consecutive failures: %s", s.ConsecutiveFailures, s.LastError)
+			rs.queued = 0
+			return nil
+		}
+	}
+
+	if rs.queued > 0 && s.Enabled {
+		rs.queued--
+		return e.dispatch(ctx, s, now, 1)
+	}
+	return nil
+}
+
+// runRetries fires retries that are due and returns the earliest pending
+// retry time, or the zero time if none are pending. A retry that cannot be
+// fired stays pending.
+func (e *Engine) runRetries(ctx context.Context, byName map[string]*pb.Schedule, now time.Time) (time.Time, error) {
+	var earliest time.Time
+	pending := e.retries[:0]
+	keep := func(r retryRun) {
+		pending = append(pending, r)
+		if earliest.IsZero() || r.at.Before(earliest) {
+			earliest = r.at
+		}
+	}
+	var err error
+	for _, r := range e.retries {
+		if err != nil || r.at.After(now) {
+			keep(r)
+			continue
+		}
+		s, ok := byName[r.name]
+		if !ok || !s.Enabled {
+			continue
+		}
+		if err = e.commit(ctx, s, e.retry(ctx, s, now, r.attempt)); err != nil {
+			keep(r)
+		}
+	}
+	e.retries = pending
+	return earliest, err
+}
+
+// retry fires another attempt of a failed run. It bypasses the overlap
+// policy, since it takes the failed run's place rather than starting a new
+// one, but is in flight like any other run. The caller commits s.
+func (e *Engine) retry(ctx context.Context, s *pb.Schedule, now time.Time, attempt int32) error {
+	id, err := e.fire(ctx, s, now, attempt, nil)
+	if err != nil {
+		return err
+	}
+	e.runState(s.Name).running[id] = now
+	return nil
+}
+
+// forget drops in-memory run state for a deleted schedule.
+func (e *Engine) forget(name string) {
+	delete(e.runs, name)
+	pending := e.retries[:0]
+	for _, r := range e.retries {
+		if r.name != name {
+			pending = append(pending, r)
+		}
+	}
+	e.retries = pending
+}
@@ -0,0 +1,279 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	nebo "github.com/neboloop/nebo-sdk-go"
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+)
+
+func createWithPolicy(t *testing.T, e *Engine, name string, p *pb.ExecutionPolicy) {
+	t.Helper()
+	_, err := e.Create(context.Background(), &pb.CreateScheduleRequest{
+		Name:       name,
+		Expression: "0 * * * * *",
+		TaskType:   "agent",
+		Message:    "sync",
+		Policy:     p,
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+}
+
+// pendingIDs drains the engine's trigger queue.
+func pendingIDs(e *Engine) []*pb.ScheduleTrigger {
+	e.qmu.Lock()
+	defer e.qmu.Unlock()
+	out := e.pending
+	e.pending = nil
+	return out
+}
+
+func report(t *testing.T, e *Engine, trig *pb.ScheduleTrigger, state string) {
+	t.Helper()
+	err := e.ReportExecution(context.Background(), &pb.ExecutionReport{TriggerId: trig.TriggerId, ScheduleName: trig.Name, State: state, Error: "boom"})
+	if err != nil {
+		t.Fatalf("ReportExecution(%s): %v", state, err)
+	}
+}
+
+func TestPolicyOverlap(t *testing.T) {
+	tests := []struct {
+		overlap  string
+		triggers int
+		history  int
+	}{
+		{OverlapAllow, 2, 2},
+		{OverlapSkip, 1, 2},
+		{OverlapQueue, 1, 1},
+		{OverlapReplace, 2, 2},
+	}
+	for _, tt := range tests {
+		e, _ := newTestEngine(t)
+		createWithPolicy(t, e, "x", &pb.ExecutionPolicy{Overlap: tt.overlap})
+
+		e.tick(epoch.Add(time.Minute))
+		e.tick(epoch.Add(2 * time.Minute))
+
+		trigs := pendingIDs(e)
+		if len(trigs) != tt.triggers {
+			t.Errorf("%s: %d triggers, want %d", tt.overlap, len(trigs), tt.triggers)
+		}
+		_, total, _ := e.History(context.Background(), "x", 0, 0)
+		if total != int64(tt.history) {
+			t.Errorf("%s: %d history entries, want %d", tt.overlap, total, tt.history)
+		}
+
+		switch tt.overlap {
+		case OverlapReplace:
+			if got := trigs[1].ReplacesTriggerIds; len(got) != 1 || got[0] != trigs[0].TriggerId {
+				t.Errorf("replace: ReplacesTriggerIds = %v", got)
+			}
+			hist, _, _ := e.History(context.Background(), "x", 0, 0)
+			if hist[1].FinishedAt == "" || hist[1].Error == "" {
+				t.Errorf("replaced run not finished: %+v", hist[1])
+			}
+		case OverlapQueue:
+			// Finishing the first run releases the queued one.
+			report(t, e, trigs[0], "succeeded")
+			if got := pendingIDs(e); len(got) != 1 {
+				t.Errorf("queue: %d triggers after finish, want 1", len(got))
+			}
+		}
+	}
+}
+
+func TestPolicyMaxConcurrent(t *testing.T) {
+	e, _ := newTestEngine(t)
+	createWithPolicy(t, e, "x", &pb.ExecutionPolicy{MaxConcurrent: 2})
+
+	for i := 1; i <= 3; i++ {
+		e.tick(epoch.Add(time.Duration(i) * time.Minute))
+	}
+	if got := len(pendingIDs(e)); got != 2 {
+		t.Errorf("%d triggers, want 2", got)
+	}
+	hist, _, _ := e.History(context.Background(), "x", 1, 0)
+	if !strings.HasPrefix(hist[0].Error, "skipped:") {
+		t.Errorf("newest entry = %+v, want skipped", hist[0])
+	}
+}
+
+func TestPolicyMaxConcurrentOverlap(t *testing.T) {
+	tests := []struct {
+		overlap  string
+		triggers int
+		replaced int
+	}{
+		{OverlapSkip, 2, 0},
+		{OverlapQueue, 2, 0},
+		{OverlapReplace, 3, 1},
+	}
+	for _, tt := range tests {
+		e, _ := newTestEngine(t)
+		createWithPolicy(t, e, "x", &pb.ExecutionPolicy{Overlap: tt.overlap, MaxConcurrent: 2})
+
+		for i := 1; i <= 3; i++ {
+			e.tick(epoch.Add(time.Duration(i) * time.Minute))
+		}
+		trigs := pendingIDs(e)
+		if len(trigs) != tt.triggers {
+			t.Fatalf("%s: %d triggers, want %d", tt.overlap, len(trigs), tt.triggers)
+		}
+
+		switch tt.overlap {
+		case OverlapQueue:
+			// One run is queued behind the two in flight.
+			report(t, e, trigs[0], "succeeded")
+			if got := pendingIDs(e); len(got) != 1 {
+				t.Errorf("queue: %d triggers after finish, want 1", len(got))
+			}
+		case OverlapReplace:
+			// Only the oldest run makes room for the new one.
+			if got := trigs[2].ReplacesTriggerIds; len(got) != tt.replaced || got[0] != trigs[0].TriggerId {
+				t.Errorf("replace: ReplacesTriggerIds = %v, want [%s]", got, trigs[0].TriggerId)
+			}
+		}
+	}
+}
+
+func TestPolicyRetryBackoff(t *testing.T) {
+	e, clock := newTestEngine(t)
+	createWithPolicy(t, e, "x", &pb.ExecutionPolicy{MaxRetries: 2, RetryBackoffSeconds: 10, MaxBackoffSeconds: 15, DeadLetterAfter: 1})
+	ctx := context.Background()
+
+	e.Trigger(ctx, "x")
+	trig := pendingIDs(e)[0]
+
+	for attempt, wait := range []time.Duration{10 * time.Second, 15 * time.Second} {
+		report(t, e, trig, "failed")
+		if next, want := e.tick(clock.Now()), clock.Now().Add(wait); !next.Equal(want) {
+			t.Fatalf("attempt %d due at %v, want %v", attempt+2, next, want)
+		}
+		clock.Advance(wait)
+		e.tick(clock.Now())
+		trigs := pendingIDs(e)
+		if len(trigs) != 1 || trigs[0].Attempt != int32(attempt+2) {
+			t.Fatalf("after %v: triggers = %+v, want attempt %d", wait, trigs, attempt+2)
+		}
+		trig = trigs[0]
+	}
+
+	report(t, e, trig, "failed")
+	s, _ := e.Get(ctx, "x")
+	if !s.DeadLettered || s.Enabled || s.ConsecutiveFailures != 1 || !strings.HasPrefix(s.LastError, "dead-lettered") {
+		t.Errorf("after retries exhausted: %+v", s)
+	}
+
+	s, err := e.Enable(ctx, "x")
+	if err != nil || s.DeadLettered || s.ConsecutiveFailures != 0 {
+		t.Errorf("Enable = %+v, %v", s, err)
+	}
+}
+
+func TestPolicyRunTimeout(t *testing.T) {
+	e, _ := newTestEngine(t)
+	createWithPolicy(t, e, "x", &pb.ExecutionPolicy{Overlap: OverlapSkip, RunTimeoutSeconds: 90})
+
+	e.tick(epoch.Add(time.Minute))
+	e.tick(epoch.Add(2 * time.Minute)) // first run still in flight
+	e.tick(epoch.Add(3 * time.Minute)) // first run timed out
+	if got := len(pendingIDs(e)); got != 2 {
+		t.Errorf("%d triggers, want 2", got)
+	}
+}
+
+func TestPolicyValidation(t *testing.T) {
+	e, _ := newTestEngine(t)
+	for _, p := range []*pb.ExecutionPolicy{{Overlap: "later"}, {MaxRetries: -1}} {
+		_, err := e.Create(context.Background(), &pb.CreateScheduleRequest{Name: "x", Expression: "* * * * * *", TaskType: "agent", Message: "m", Policy: p})
+		if !errors.Is(err, &nebo.Error{Code: nebo.CodeInvalidArgument}) {
+			t.Errorf("Create with %+v err = %v, want invalid_argument", p, err)
+		}
+	}
+}
+
+func TestPolicyIgnoresRepeatedReports(t *testing.T) {
+	ctx := context.Background()
+	for _, retries := range []int32{0, 1} {
+		e, clock := newTestEngine(t)
+		createWithPolicy(t, e, "x", &pb.ExecutionPolicy{MaxRetries: retries, RetryBackoffSeconds: 10})
+
+		e.Trigger(ctx, "x")
+		trig := pendingIDs(e)[0]
+		for range 3 {
+			report(t, e, trig, "failed")
+		}
+		// A late "started" must not reopen the run.
+		err := e.ReportExecution(ctx, &pb.ExecutionReport{TriggerId: trig.TriggerId, ScheduleName: "x", State: "started", StartedAt: "2030-01-01T00:00:00Z"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		hist, _, _ := e.History(ctx, "x", 0, 0)
+		if hist[0].StartedAt == "2030-01-01T00:00:00Z" {
+			t.Errorf("late started report overwrote StartedAt: %+v", hist[0])
+		}
+
+		if retries == 0 {
+			if s, _ := e.Get(ctx, "x"); s.ConsecutiveFailures != 1 {
+				t.Errorf("ConsecutiveFailures = %d, want 1", s.ConsecutiveFailures)
+			}
+			continue
+		}
+		clock.Advance(10 * time.Second)
+		e.tick(clock.Now())
+		if got := len(pendingIDs(e)); got != 1 {
+			t.Errorf("%d retries queued, want 1", got)
+		}
+	}
+}
+
+func TestPolicyRetryBypassesOverlap(t *testing.T) {
+	ctx := context.Background()
+	e, clock := newTestEngine(t)
+	createWithPolicy(t, e, "x", &pb.ExecutionPolicy{Overlap: OverlapSkip, MaxRetries: 1, RetryBackoffSeconds: 10})
+
+	e.Trigger(ctx, "x")
+	report(t, e, pendingIDs(e)[0], "failed")
+	e.Trigger(ctx, "x") // in flight when the retry is due
+	pendingIDs(e)
+
+	clock.Advance(10 * time.Second)
+	e.tick(clock.Now())
+	if trigs := pendingIDs(e); len(trigs) != 1 || trigs[0].Attempt != 2 {
+		t.Fatalf("triggers = %+v, want the retry despite skip", trigs)
+	}
+	// Two manual runs; the retry is not a new run.
+	if s, _ := e.Get(ctx, "x"); s.RunCount != 2 {
+		t.Errorf("RunCount = %d, want 2", s.RunCount)
+	}
+}
+
+func TestPolicyKeepsRetryWhenSaveFails(t *testing.T) {
+	ctx := context.Background()
+	store := &failingSaveStore{MemoryStore: NewMemoryStore()}
+	clock := NewManualClock(epoch)
+	e := New(store, WithClock(clock), WithLocation(time.UTC))
+	defer e.Close()
+	createWithPolicy(t, e, "x", &pb.ExecutionPolicy{MaxRetries: 1, RetryBackoffSeconds: 10})
+
+	e.Trigger(ctx, "x")
+	report(t, e, pendingIDs(e)[0], "failed")
+	clock.Advance(10 * time.Second)
+
+	store.fail = true
+	e.tick(clock.Now())
+	if trigs := pendingIDs(e); len(trigs) != 0 {
+		t.Fatalf("triggers = %+v while the store fails, want none", trigs)
+	}
+	store.fail = false
+	e.tick(clock.Now())
+	if trigs := pendingIDs(e); len(trigs) != 1 || trigs[0].Attempt != 2 {
+		t.Errorf("triggers = %+v, want the retry once the store recovers", trigs)
+	}
+}
@@ -0,0 +1,493 @@
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rruleFreq is the FREQ part of a recurrence rule.
+type rruleFreq int
+
+const (
+	freqSecondly rruleFreq = iota
+	freqMinutely
+	freqHourly
+	freqDaily
+	freqWeekly
+	freqMonthly
+	freqYearly
+)
+
+var freqNames = map[string]rruleFreq{
+	"SECONDLY": freqSecondly,
+	"MINUTELY": freqMinutely,
+	"HOURLY":   freqHourly,
+	"DAILY":    freqDaily,
+	"WEEKLY":   freqWeekly,
+	"MONTHLY":  freqMonthly,
+	"YEARLY":   freqYearly,
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// byDay is one BYDAY term: a weekday with an optional ordinal (1MO, -1FR).
+type byDay struct {
+	n       int // 0 means every such weekday in the period
+	weekday time.Weekday
+}
+
+// RRule is a parsed RFC 5545 recurrence rule.
+//
+// Supported parts: FREQ, INTERVAL, COUNT, UNTIL, BYMONTH, BYMONTHDAY (including
+// negative days), BYDAY (with ordinals for MONTHLY and YEARLY, counted within
+// the month), BYHOUR, BYMINUTE, BYSECOND and BYSETPOS. Weeks start on Monday.
+// Fields not given in the rule are taken from dtstart.
+//
+// "Last business day of the month" is:
+//
+//	FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1
+type RRule struct {
+	freq     rruleFreq
+	interval int
+	count    int
+	until    time.Time
+	dtstart  time.Time
+
+	byMonth    []int
+	byMonthDay []int
+	byDay      []byDay
+	byHour     []int
+	byMinute   []int
+	bySecond   []int
+	bySetPos   []int
+
+	text string
+}
+
+// maxRRulePeriods bounds the search in Next so impossible rules terminate.
+const maxRRulePeriods = 100000
+
+// ParseRRule parses a recurrence rule anchored at dtstart, whose location is
+// used for all calendar arithmetic. A leading "RRULE:" is accepted.
+func ParseRRule(rule string, dtstart time.Time) (*RRule, error) {
+	r := &RRule{interval: 1, dtstart: dtstart.Truncate(time.Second), text: rule}
+	body := strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if body == "" {
+		return nil, fmt.Errorf("rrule: empty rule")
+	}
+
+	hasFreq := false
+	for _, part := range strings.Split(body, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("rrule %q: malformed part %q", rule, part)
+		}
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			f, ok := freqNames[strings.ToUpper(value)]
+			if !ok {
+				return nil, fmt.Errorf("rrule %q: unknown FREQ %q", rule, value)
+			}
+			r.freq = f
+			hasFreq = true
+		case "INTERVAL":
+			r.interval, err = strconv.Atoi(value)
+			if err == nil && r.interval < 1 {
+				err = fmt.Errorf("must be positive")
+			}
+		case "COUNT":
+			r.count, err = strconv.Atoi(value)
+			if err == nil && r.count < 1 {
+				err = fmt.Errorf("must be positive")
+			}
+		case "UNTIL":
+			r.until, err = parseRRuleTime(value, dtstart.Location())
+		case "BYMONTH":
+			r.byMonth, err = parseInts(value, 1, 12, false)
+		case "BYMONTHDAY":
+			r.byMonthDay, err = parseInts(value, 1, 31, true)
+		case "BYDAY":
+			r.byDay, err = parseByDay(value)
+		case "BYHOUR":
+			r.byHour, err = parseInts(value, 0, 23, false)
+		case "BYMINUTE":
+			r.byMinute, err = parseInts(value, 0, 59, false)
+		case "BYSECOND":
+			r.bySecond, err = parseInts(value, 0, 59, false)
+		case "BYSETPOS":
+			r.bySetPos, err = parseInts(value, 1, 366, true)
+		case "WKST":
+			// Weeks always start on Monday.
+		default:
+			return nil, fmt.Errorf("rrule %q: unsupported part %s", rule, key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("rrule %q: %s: %w", rule, key, err)
+		}
+	}
+	if !hasFreq {
+		return nil, fmt.Errorf("rrule %q: FREQ is required", rule)
+	}
+	return r, nil
+}
+
+// String returns the rule text.
+func (r *RRule) String() string {
+	return r.text
+}
+
+// parseRRuleTime parses an UNTIL value. The "Z" and RFC 3339 forms carry
+// their own zone; the floating forms are in loc, DTSTART's location.
+func parseRRuleTime(s string, loc *time.Location) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	for _, layout := range []string{"20060102T150405", "20060102"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", s)
+}
+
+// parseInts parses a comma-separated list of ints in [lo, hi], or in
+// [-hi, -lo] too when negative is set.
+func parseInts(s string, lo, hi int, negative bool) ([]int, error) {
+	var out []int
+	for _, f := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q", f)
+		}
+		abs := n
+		if negative && n < 0 {
+			abs = -n
+		}
+		if abs < lo || abs > hi {
+			return nil, fmt.Errorf("%d out of range", n)
+		}
+		out = append(out, n)
+	}
+	return out, nil
+}
+
+func parseByDay(s string) ([]byDay, error) {
+	var out []byDay
+	for _, f := range strings.Split(s, ",") {
+		f = strings.ToUpper(f)
+		if len(f) < 2 {
+			return nil, fmt.Errorf("invalid day %q", f)
+		}
+		wd, ok := weekdayNames[f[len(f)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid day %q", f)
+		}
+		d := byDay{weekday: wd}
+		if prefix := f[:len(f)-2]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 || n > 53 || n < -53 {
+				return nil, fmt.Errorf("invalid day %q", f)
+			}
+			d.n = n
+		}
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+// Next returns the first occurrence strictly after t, or the zero time if
+// the rule has no more occurrences.
+func (r *RRule) Next(t time.Time) time.Time {
+	loc := r.dtstart.Location()
+	after := t.In(loc)
+
+	// With COUNT every occurrence from dtstart has to be counted; otherwise we
+	// can jump to just before the period that contains t. Backing off one
+	// period absorbs DST differences between wall-clock and elapsed time.
+	period := 0
+	if r.count == 0 && after.After(r.dtstart) {
+		period = max(r.periodsBetween(r.dtstart, after)-1, 0)
+		period -= period % r.interval
+	}
+
+	seen := 0
+	for i := 0; i < maxRRulePeriods; i++ {
+		for _, occ := range r.occurrences(r.periodStart(period)) {
+			if occ.Before(r.dtstart) {
+				continue
+			}
+			if !r.until.IsZero() && occ.After(r.until) {
+				return time.Time{}
+			}
+			seen++
+			if r.count > 0 && seen > r.count {
+				return time.Time{}
+			}
+			if occ.After(after) {
+				return occ.In(t.Location())
+			}
+		}
+		period += r.interval
+	}
+	return time.Time{}
+}
+
+// periodStart returns the start of the n-th period after dtstart's period.
+func (r *RRule) periodStart(n int) time.Time {
+	d := r.dtstart
+	loc := d.Location()
+	switch r.freq {
+	case freqYearly:
+		return time.Date(d.Year()+n, 1, 1, 0, 0, 0, 0, loc)
+	case freqMonthly:
+		return time.Date(d.Year(), d.Month()+time.Month(n), 1, 0, 0, 0, 0, loc)
+	case freqWeekly:
+		monday := d.AddDate(0, 0, -int((d.Weekday()+6)%7))
+		return time.Date(monday.Year(), monday.Month(), monday.Day()+7*n, 0, 0, 0, 0, loc)
+	case freqDaily:
+		return time.Date(d.Year(), d.Month(), d.Day()+n, 0, 0, 0, 0, loc)
+	case freqHourly:
+		return time.Date(d.Year(), d.Month(), d.Day(), d.Hour()+n, 0, 0, 0, loc)
+	case freqMinutely:
+		return time.Date(d.Year(), d.Month(), d.Day(), d.Hour(), d.Minute()+n, 0, 0, loc)
+	default:
+		return time.Date(d.Year(), d.Month(), d.Day(), d.Hour(), d.Minute(), d.Second()+n, 0, loc)
+	}
+}
+
+// periodsBetween returns how many whole periods lie between dtstart's period and t's.
+func (r *RRule) periodsBetween(from, t time.Time) int {
+	switch r.freq {
+	case freqYearly:
+		return t.Year() - from.Year()
+	case freqMonthly:
+		return (t.Year()-from.Year())*12 + int(t.Month()) - int(from.Month())
+	case freqWeekly:
+		return daysBetween(r.periodStart(0), t) / 7
+	case freqDaily:
+		return daysBetween(from, t)
+	case freqHourly:
+		return int(t.Sub(r.periodStart(0)) / time.Hour)
+	case freqMinutely:
+		return int(t.Sub(r.periodStart(0)) / time.Minute)
+	default:
+		return int(t.Sub(r.periodStart(0)) / time.Second)
+	}
+}
+
+// daysBetween counts calendar days from a to b, ignoring DST shifts.
+func daysBetween(a, b time.Time) int {
+	da := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	db := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(db.Sub(da).Hours() / 24)
+}
+
+// occurrences expands the period starting at start into sorted candidate times,
+// then applies BYSETPOS.
+func (r *RRule) occurrences(start time.Time) []time.Time {
+	var days []time.Time
+	switch r.freq {
+	case freqYearly:
+		months := r.byMonth
+		if len(months) == 0 {
+			if len(r.byMonthDay) == 0 && len(r.byDay) == 0 {
+				months = []int{int(r.dtstart.Month())}
+			} else {
+				months = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+			}
+		}
+		for _, m := range months {
+			days = append(days, r.monthDays(time.Date(start.Year(), time.Month(m), 1, 0, 0, 0, 0, start.Location()))...)
+		}
+	case freqMonthly:
+		if r.monthMatches(start) {
+			days = r.monthDays(start)
+		}
+	case freqWeekly:
+		for i := range 7 {
+			d := start.AddDate(0, 0, i)
+			if !r.monthMatches(d) {
+				continue
+			}
+			if len(r.byDay) == 0 && d.Weekday() != r.dtstart.Weekday() {
+				continue
+			}
+			if len(r.byDay) > 0 && !r.weekdayMatches(d) {
+				continue
+			}
+			days = append(days, d)
+		}
+	default:
+		day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+		if r.monthMatches(day) && r.monthDayMatches(day) && (len(r.byDay) == 0 || r.weekdayMatches(day)) {
+			days = append(days, day)
+		}
+	}
+
+	var out []time.Time
+	for _, day := range days {
+		out = append(out, r.times(day, start)...)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+	return r.applySetPos(out)
+}
+
+// monthDays returns the days of the month starting at first that match
+// BYMONTHDAY and BYDAY, defaulting to dtstart's day of month.
+func (r *RRule) monthDays(first time.Time) []time.Time {
+	n := daysIn(first)
+	var out []time.Time
+	for d := 1; d <= n; d++ {
+		day := time.Date(first.Year(), first.Month(), d, 0, 0, 0, 0, first.Location())
+		switch {
+		case len(r.byMonthDay) == 0 && len(r.byDay) == 0:
+			if d != r.dtstart.Day() {
+				continue
+			}
+		case len(r.byMonthDay) > 0 && !r.monthDayMatches(day):
+			continue
+		case len(r.byDay) > 0 && !r.ordinalDayMatches(day, n):
+			continue
+		}
+		out = append(out, day)
+	}
+	return out
+}
+
+// times expands a matching day into times of day. Parts finer than FREQ come
+// from BYHOUR/BYMINUTE/BYSECOND or dtstart; parts at or above FREQ come from the period.
+func (r *RRule) times(day, period time.Time) []time.Time {
+	hours := r.byHour
+	if r.freq <= freqHourly {
+		hours = filterInts([]int{period.Hour()}, r.byHour)
+	} else if len(hours) == 0 {
+		hours = []int{r.dtstart.Hour()}
+	}
+	minutes := r.byMinute
+	if r.freq <= freqMinutely {
+		minutes = filterInts([]int{period.Minute()}, r.byMinute)
+	} else if len(minutes) == 0 {
+		minutes = []int{r.dtstart.Minute()}
+	}
+	seconds := r.bySecond
+	if r.freq == freqSecondly {
+		seconds = filterInts([]int{period.Second()}, r.bySecond)
+	} else if len(seconds) == 0 {
+		seconds = []int{r.dtstart.Second()}
+	}
+
+	var out []time.Time
+	for _, h := range hours {
+		for _, m := range minutes {
+			for _, s := range seconds {
+				t := time.Date(day.Year(), day.Month(), day.Day(), h, m, s, 0, day.Location())
+				// Skip wall-clock times that do not exist because of a DST gap.
+				if t.Hour() == h && t.Day() == day.Day() {
+					out = append(out, t)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// filterInts returns values restricted to allowed, or values unchanged if allowed is empty.
+func filterInts(values, allowed []int) []int {
+	if len(allowed) == 0 {
+		return values
+	}
+	var out []int
+	for _, v := range values {
+		for _, a := range allowed {
+			if v == a {
+				out = append(out, v)
+				break
+			}
+		}
+	}
+	return out
+}
+
+func (r *RRule) applySetPos(occ []time.Time) []time.Time {
+	if len(r.bySetPos) == 0 || len(occ) == 0 {
+		return occ
+	}
+	var out []time.Time
+	for _, pos := range r.bySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(occ) + pos
+		}
+		if i >= 0 && i < len(occ) {
+			out = append(out, occ[i])
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+	return out
+}
+
+func (r *RRule) monthMatches(t time.Time) bool {
+	if len(r.byMonth) == 0 {
+		return true
+	}
+	for _, m := range r.byMonth {
+		if time.Month(m) == t.Month() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RRule) monthDayMatches(t time.Time) bool {
+	if len(r.byMonthDay) == 0 {
+		return true
+	}
+	n := daysIn(t)
+	for _, d := range r.byMonthDay {
+		if d == t.Day() || (d < 0 && n+d+1 == t.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RRule) weekdayMatches(t time.Time) bool {
+	for _, d := range r.byDay {
+		if d.weekday == t.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// ordinalDayMatches checks BYDAY terms with ordinals counted within the month
+// (1MO is the first Monday, -1FR the last Friday).
+func (r *RRule) ordinalDayMatches(t time.Time, daysInMonth int) bool {
+	for _, d := range r.byDay {
+		if d.weekday != t.Weekday() {
+			continue
+		}
+		switch {
+		case d.n == 0:
+			return true
+		case d.n > 0 && (t.Day()-1)/7+1 == d.n:
+			return true
+		case d.n < 0 && (daysInMonth-t.Day())/7+1 == -d.n:
+			return true
+		}
+	}
+	return false
+}
+
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,175 @@
+package schedule
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+)
+
+// Spec is a parsed pb.TriggerSpec: a recurrence plus the window, jitter and
+// run limit that bound it.
+type Spec struct {
+	// Location is the zone the trigger is evaluated in.
+	Location *time.Location
+	Jitter   time.Duration
+	Start    time.Time // zero means no lower bound
+	End      time.Time // zero means no upper bound
+	MaxRuns  int64     // zero means unlimited
+
+	next func(after time.Time) time.Time
+	desc string
+}
+
+// CronTrigger returns a TriggerSpec for a cron expression.
+func CronTrigger(expr string) *pb.TriggerSpec {
+	return &pb.TriggerSpec{Kind: &pb.TriggerSpec_Cron{Cron: expr}}
+}
+
+// IntervalTrigger returns a TriggerSpec that fires every d (rounded down to whole seconds).
+func IntervalTrigger(d time.Duration) *pb.TriggerSpec {
+	return &pb.TriggerSpec{Kind: &pb.TriggerSpec_IntervalSeconds{IntervalSeconds: int64(d / time.Second)}}
+}
+
+// OnceTrigger returns a TriggerSpec that fires once at t.
+func OnceTrigger(t time.Time) *pb.TriggerSpec {
+	return &pb.TriggerSpec{Kind: &pb.TriggerSpec_At{At: t.Format(time.RFC3339)}}
+}
+
+// RRuleTrigger returns a TriggerSpec for an RFC 5545 recurrence rule.
+func RRuleTrigger(rule string) *pb.TriggerSpec {
+	return &pb.TriggerSpec{Kind: &pb.TriggerSpec_Rrule{Rrule: rule}}
+}
+
+// ParseSpec parses a TriggerSpec. anchor is the schedule's creation time: it
+// anchors intervals and RRULE DTSTART when start_at is not set. loc is used
+// when the spec has no timezone.
+func ParseSpec(spec *pb.TriggerSpec, anchor time.Time, loc *time.Location) (*Spec, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("trigger spec is required")
+	}
+	s := &Spec{
+		Location: loc,
+		Jitter:   time.Duration(spec.JitterSeconds) * time.Second,
+		MaxRuns:  spec.MaxRuns,
+	}
+	if spec.Timezone != "" {
+		l, err := time.LoadLocation(spec.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("timezone: %w", err)
+		}
+		s.Location = l
+	}
+	if s.Location == nil {
+		s.Location = time.Local
+	}
+	var err error
+	if s.Start, err = parseOptionalTime(spec.StartAt, "start_at"); err != nil {
+		return nil, err
+	}
+	if s.End, err = parseOptionalTime(spec.EndAt, "end_at"); err != nil {
+		return nil, err
+	}
+	if !s.Start.IsZero() && !s.End.IsZero() && s.End.Before(s.Start) {
+		return nil, fmt.Errorf("end_at is before start_at")
+	}
+	if spec.JitterSeconds < 0 || spec.MaxRuns < 0 {
+		return nil, fmt.Errorf("jitter_seconds and max_runs must not be negative")
+	}
+	if !s.Start.IsZero() {
+		anchor = s.Start
+	}
+	anchor = anchor.In(s.Location)
+
+	switch kind := spec.Kind.(type) {
+	case *pb.TriggerSpec_Cron:
+		c, err := ParseCron(kind.Cron)
+		if err != nil {
+			return nil, err
+		}
+		if c.Location == nil {
+			c.Location = s.Location
+		}
+		s.next = c.Next
+		s.desc = kind.Cron
+	case *pb.TriggerSpec_IntervalSeconds:
+		if kind.IntervalSeconds <= 0 {
+			return nil, fmt.Errorf("interval_seconds must be positive")
+		}
+		interval := time.Duration(kind.IntervalSeconds) * time.Second
+		s.next = func(after time.Time) time.Time {
+			if after.Before(anchor) {
+				return anchor
+			}
+			n := after.Sub(anchor)/interval + 1
+			return anchor.Add(n * interval)
+		}
+		s.desc = "every " + interval.String()
+	case *pb.TriggerSpec_At:
+		at, err := time.Parse(time.RFC3339, kind.At)
+		if err != nil {
+			return nil, fmt.Errorf("at: %w", err)
+		}
+		s.next = func(after time.Time) time.Time {
+			if at.After(after) {
+				return at
+			}
+			return time.Time{}
+		}
+		s.desc = "at " + kind.At
+	case *pb.TriggerSpec_Rrule:
+		r, err := ParseRRule(kind.Rrule, anchor)
+		if err != nil {
+			return nil, err
+		}
+		s.next = r.Next
+		s.desc = "RRULE:" + r.String()
+	default:
+		return nil, fmt.Errorf("trigger spec has no cron, interval_seconds, at or rrule")
+	}
+	return s, nil
+}
+
+func parseOptionalTime(value, field string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", field, err)
+	}
+	return t, nil
+}
+
+// Next returns the first fire time strictly after t for a schedule that has
+// already run runs times, or the zero time when the schedule is finished
+// (past End, MaxRuns reached, or a one-shot that has fired). Jitter is not
+// included; see Delay.
+func (s *Spec) Next(t time.Time, runs int64) time.Time {
+	if s.MaxRuns > 0 && runs >= s.MaxRuns {
+		return time.Time{}
+	}
+	if !s.Start.IsZero() && t.Before(s.Start) {
+		// Allow a run exactly at Start.
+		t = s.Start.Add(-time.Second)
+	}
+	next := s.next(t.In(s.Location))
+	if next.IsZero() || (!s.End.IsZero() && next.After(s.End)) {
+		return time.Time{}
+	}
+	return next
+}
+
+// Delay returns a random jitter in [0, Jitter), to be added to a fire time.
+func (s *Spec) Delay() time.Duration {
+	if s.Jitter <= 0 {
+		return 0
+	}
+	return rand.N(s.Jitter)
+}
+
+// String describes the trigger, e.g. "0 0 9 * * *", "every 1h30m0s" or "RRULE:FREQ=DAILY".
+func (s *Spec) String() string {
+	return s.desc
+}
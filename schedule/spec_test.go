@@ -0,0 +1,177 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+)
+
+func mustTime(t *testing.T, s string) time.Time {
+	t.Helper()
+	v, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestRRuleNext(t *testing.T) {
+	dtstart := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		rule  string
+		after string
+		want  string
+	}{
+		// Last business day of the month (Jan 31 2026 is a Saturday).
+		{"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", "2026-01-15T00:00:00Z", "2026-01-30T09:00:00Z"},
+		{"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", "2026-01-30T09:00:00Z", "2026-02-27T09:00:00Z"},
+		// Every other week on Monday and Thursday.
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", "2026-01-01T09:00:00Z", "2026-01-12T09:00:00Z"},
+		// First Monday of each month at 08:30.
+		{"FREQ=MONTHLY;BYDAY=1MO;BYHOUR=8;BYMINUTE=30", "2026-02-03T00:00:00Z", "2026-03-02T08:30:00Z"},
+		// Last day of the month.
+		{"FREQ=MONTHLY;BYMONTHDAY=-1", "2026-02-01T00:00:00Z", "2026-02-28T09:00:00Z"},
+		// Yearly on dtstart's date.
+		{"FREQ=YEARLY", "2026-06-01T00:00:00Z", "2027-01-01T09:00:00Z"},
+		// Every 90 minutes.
+		{"FREQ=MINUTELY;INTERVAL=90", "2026-01-01T10:00:00Z", "2026-01-01T10:30:00Z"},
+		// COUNT exhausts.
+		{"FREQ=DAILY;COUNT=3", "2026-01-03T09:00:00Z", ""},
+		{"FREQ=DAILY;UNTIL=20260105T000000Z", "2026-01-04T09:00:00Z", ""},
+	}
+
+	for _, tt := range tests {
+		r, err := ParseRRule(tt.rule, dtstart)
+		if err != nil {
+			t.Errorf("ParseRRule(%q): %v", tt.rule, err)
+			continue
+		}
+		got := formatTime(r.Next(mustTime(t, tt.after)))
+		if got != tt.want {
+			t.Errorf("%s after %s = %q, want %q", tt.rule, tt.after, got, tt.want)
+		}
+	}
+}
+
+func TestParseRRuleInvalid(t *testing.T) {
+	for _, rule := range []string{"", "INTERVAL=2", "FREQ=FORTNIGHTLY", "FREQ=DAILY;BYDAY=XX", "FREQ=DAILY;BYHOUR=25", "FREQ=DAILY;FOO=1", "FREQ=DAILY;COUNT=0", "FREQ=DAILY;COUNT=-2"} {
+		if _, err := ParseRRule(rule, time.Now()); err == nil {
+			t.Errorf("ParseRRule(%q) succeeded, want error", rule)
+		}
+	}
+}
+
+func TestSpecInterval(t *testing.T) {
+	anchor := mustTime(t, "2026-01-01T00:00:00Z")
+	sp, err := ParseSpec(IntervalTrigger(90*time.Minute), anchor, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := formatTime(sp.Next(mustTime(t, "2026-01-01T02:00:00Z"), 0)); got != "2026-01-01T03:00:00Z" {
+		t.Errorf("Next = %s, want 03:00", got)
+	}
+	if sp.String() != "every 1h30m0s" {
+		t.Errorf("String = %q", sp.String())
+	}
+}
+
+func TestSpecOneShot(t *testing.T) {
+	sp, err := ParseSpec(OnceTrigger(mustTime(t, "2026-11-01T09:00:00Z")), time.Time{}, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := formatTime(sp.Next(mustTime(t, "2026-10-01T00:00:00Z"), 0)); got != "2026-11-01T09:00:00Z" {
+		t.Errorf("Next = %s", got)
+	}
+	if got := sp.Next(mustTime(t, "2026-11-01T09:00:00Z"), 1); !got.IsZero() {
+		t.Errorf("Next after firing = %v, want zero", got)
+	}
+}
+
+func TestSpecWindowAndMaxRuns(t *testing.T) {
+	spec := CronTrigger("0 0 * * * *")
+	spec.StartAt = "2026-01-01T05:00:00Z"
+	spec.EndAt = "2026-01-01T07:00:00Z"
+	spec.MaxRuns = 2
+	spec.Timezone = "UTC"
+
+	sp, err := ParseSpec(spec, time.Time{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := formatTime(sp.Next(mustTime(t, "2026-01-01T00:00:00Z"), 0)); got != "2026-01-01T05:00:00Z" {
+		t.Errorf("first = %s, want start_at", got)
+	}
+	if got := formatTime(sp.Next(mustTime(t, "2026-01-01T06:00:00Z"), 1)); got != "2026-01-01T07:00:00Z" {
+		t.Errorf("second = %s", got)
+	}
+	if got := sp.Next(mustTime(t, "2026-01-01T06:00:00Z"), 2); !got.IsZero() {
+		t.Errorf("after max_runs = %v, want zero", got)
+	}
+	if got := sp.Next(mustTime(t, "2026-01-01T07:00:00Z"), 1); !got.IsZero() {
+		t.Errorf("after end_at = %v, want zero", got)
+	}
+}
+
+func TestSpecJitter(t *testing.T) {
+	spec := IntervalTrigger(time.Hour)
+	spec.JitterSeconds = 30
+	sp, err := ParseSpec(spec, time.Now(), time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 100 {
+		if d := sp.Delay(); d < 0 || d >= 30*time.Second {
+			t.Fatalf("Delay = %v, want [0, 30s)", d)
+		}
+	}
+}
+
+func TestParseSpecInvalid(t *testing.T) {
+	for _, spec := range []*pb.TriggerSpec{
+		nil,
+		{},
+		IntervalTrigger(0),
+		{Kind: &pb.TriggerSpec_At{At: "tomorrow"}},
+		{Kind: &pb.TriggerSpec_Cron{Cron: "* * * * * *"}, Timezone: "Mars/Olympus"},
+		{Kind: &pb.TriggerSpec_Cron{Cron: "* * * * * *"}, StartAt: "2026-02-01T00:00:00Z", EndAt: "2026-01-01T00:00:00Z"},
+	} {
+		if _, err := ParseSpec(spec, time.Now(), time.UTC); err == nil {
+			t.Errorf("ParseSpec(%v) succeeded, want error", spec)
+		}
+	}
+}
+
+func TestRRuleUntilZone(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no tzdata:", err)
+	}
+	dtstart := time.Date(2026, 1, 1, 12, 0, 0, 0, ny) // 17:00Z
+
+	tests := []struct {
+		rule string
+		want string // next run after Jan 2 noon New York time
+	}{
+		// UTC until: Jan 3 15:00Z is before Jan 3 noon in New York (17:00Z).
+		{"FREQ=DAILY;UNTIL=20260103T150000Z", ""},
+		// Floating until is in DTSTART's zone: Jan 3 15:00 New York time.
+		{"FREQ=DAILY;UNTIL=20260103T150000", "2026-01-03T12:00:00-05:00"},
+		{"FREQ=DAILY;UNTIL=20260103", ""},
+	}
+	for _, tt := range tests {
+		r, err := ParseRRule(tt.rule, dtstart)
+		if err != nil {
+			t.Fatalf("ParseRRule(%q): %v", tt.rule, err)
+		}
+		got := ""
+		if next := r.Next(time.Date(2026, 1, 2, 12, 0, 0, 0, ny)); !next.IsZero() {
+			got = next.In(ny).Format(time.RFC3339)
+		}
+		if got != tt.want {
+			t.Errorf("%s: next = %q, want %q", tt.rule, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package schedule
+
+import (
+	"context"
+	"sync"
+
+	nebo "github.com/neboloop/nebo-sdk-go"
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// Store persists schedules and their execution history for an Engine.
+// Implementations must be safe for concurrent use. Get and Delete return a
+// nebo.Error with CodeNotFound for unknown names.
+type Store interface {
+	// Save inserts or replaces the schedule with the same name.
+	Save(ctx context.Context, s *pb.Schedule) error
+	Get(ctx context.Context, name string) (*pb.Schedule, error)
+	// List returns every schedule in no particular order.
+	List(ctx context.Context) ([]*pb.Schedule, error)
+	// Delete removes a schedule and its history.
+	Delete(ctx context.Context, name string) error
+
+	AppendHistory(ctx context.Context, entry *pb.ScheduleHistoryEntry) error
+	// UpdateHistory replaces the entry with the same ID and schedule name.
+	UpdateHistory(ctx context.Context, entry *pb.ScheduleHistoryEntry) error
+	// History returns a schedule's entries, oldest first.
+	History(ctx context.Context, name string) ([]*pb.ScheduleHistoryEntry, error)
+}
+
+// MemoryStore is an in-memory Store. State is lost when the app exits.
+type MemoryStore struct {
+	mu        sync.RWMutex
+	schedules map[string]*pb.Schedule
+	history   map[string][]*pb.ScheduleHistoryEntry
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		schedules: make(map[string]*pb.Schedule),
+		history:   make(map[string][]*pb.ScheduleHistoryEntry),
+	}
+}
+
+func (m *MemoryStore) Save(_ context.Context, s *pb.Schedule) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.schedules[s.Name] = proto.Clone(s).(*pb.Schedule)
+	return nil
+}
+
+func (m *MemoryStore) Get(_ context.Context, name string) (*pb.Schedule, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, ok := m.schedules[name]
+	if !ok {
+		return nil, errNotFound(name)
+	}
+	return proto.Clone(s).(*pb.Schedule), nil
+}
+
+func (m *MemoryStore) List(_ context.Context) ([]*pb.Schedule, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make([]*pb.Schedule, 0, len(m.schedules))
+	for _, s := range m.schedules {
+		out = append(out, proto.Clone(s).(*pb.Schedule))
+	}
+	return out, nil
+}
+
+func (m *MemoryStore) Delete(_ context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.schedules[name]; !ok {
+		return errNotFound(name)
+	}
+	delete(m.schedules, name)
+	delete(m.history, name)
+	return nil
+}
+
+func (m *MemoryStore) AppendHistory(_ context.Context, entry *pb.ScheduleHistoryEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.history[entry.ScheduleName] = append(m.history[entry.ScheduleName], proto.Clone(entry).(*pb.ScheduleHistoryEntry))
+	return nil
+}
+
+func (m *MemoryStore) UpdateHistory(_ context.Context, entry *pb.ScheduleHistoryEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, e := range m.history[entry.ScheduleName] {
+		if e.Id == entry.Id {
+			m.history[entry.ScheduleName][i] = proto.Clone(entry).(*pb.ScheduleHistoryEntry)
+			return nil
+		}
+	}
+	return errHistoryNotFound(entry)
+}
+
+func (m *MemoryStore) History(_ context.Context, name string) ([]*pb.ScheduleHistoryEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	entries := m.history[name]
+	out := make([]*pb.ScheduleHistoryEntry, len(entries))
+	for i, e := range entries {
+		out[i] = proto.Clone(e).(*pb.ScheduleHistoryEntry)
+	}
+	return out, nil
+}
+
+func errNotFound(name string) error {
+	return nebo.Errorf(nebo.CodeNotFound, "schedule %q not found", name)
+}
+
+func errHistoryNotFound(entry *pb.ScheduleHistoryEntry) error {
+	return nebo.Errorf(nebo.CodeNotFound, "history entry %q for schedule %q not found", entry.Id, entry.ScheduleName)
+}
@@ -0,0 +1,127 @@
+package nebo
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+)
+
+// stubSchedules implements ScheduleHandler with canned List/History results.
+type stubSchedules struct {
+	err error
+}
+
+func (s *stubSchedules) Create(context.Context, *pb.CreateScheduleRequest) (*pb.Schedule, error) {
+	return nil, s.err
+}
+func (s *stubSchedules) Get(context.Context, string) (*pb.Schedule, error) { return nil, s.err }
+func (s *stubSchedules) List(context.Context, int32, int32, bool) ([]*pb.Schedule, int64, error) {
+	if s.err != nil {
+		return nil, 0, s.err
+	}
+	return []*pb.Schedule{{Name: "a"}}, 1, nil
+}
+func (s *stubSchedules) Update(context.Context, *pb.UpdateScheduleRequest) (*pb.Schedule, error) {
+	return nil, s.err
+}
+func (s *stubSchedules) Delete(context.Context, string) error                  { return s.err }
+func (s *stubSchedules) Enable(context.Context, string) (*pb.Schedule, error)  { return nil, s.err }
+func (s *stubSchedules) Disable(context.Context, string) (*pb.Schedule, error) { return nil, s.err }
+func (s *stubSchedules) Trigger(context.Context, string) (bool, string, error) {
+	return false, "", s.err
+}
+func (s *stubSchedules) History(context.Context, string, int32, int32) ([]*pb.ScheduleHistoryEntry, int64, error) {
+	if s.err != nil {
+		return nil, 0, s.err
+	}
+	return []*pb.ScheduleHistoryEntry{{Id: "h1"}}, 1, nil
+}
+func (s *stubSchedules) Triggers(context.Context) (<-chan *pb.ScheduleTrigger, error) {
+	return nil, s.err
+}
+
+// cursorSchedules pages through a fixed list of names two at a time.
+type cursorSchedules struct {
+	stubSchedules
+	names []string
+}
+
+func (c *cursorSchedules) ListCursor(_ context.Context, limit int32, cursor string, _ bool) ([]*pb.Schedule, int64, string, error) {
+	start := 0
+	for i, n := range c.names {
+		if n == cursor {
+			start = i + 1
+		}
+	}
+	end := min(start+int(limit), len(c.names))
+	var out []*pb.Schedule
+	for _, n := range c.names[start:end] {
+		out = append(out, &pb.Schedule{Name: n})
+	}
+	next := ""
+	if end < len(c.names) {
+		next = c.names[end-1]
+	}
+	return out, int64(len(c.names)), next, nil
+}
+
+func (c *cursorSchedules) HistoryCursor(context.Context, string, int32, string) ([]*pb.ScheduleHistoryEntry, int64, string, error) {
+	return nil, 0, "", nil
+}
+
+func TestScheduleBridgeListSurfacesError(t *testing.T) {
+	b := &scheduleBridge{handler: &stubSchedules{err: NewError(CodeUnavailable, "store down")}, env: &AppEnv{}}
+
+	resp, err := b.List(context.Background(), &pb.ListSchedulesRequest{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if resp.Error != "store down" {
+		t.Errorf("Error = %q, want store down", resp.Error)
+	}
+	if resp.ErrorDetail.GetCode() != "unavailable" || !resp.ErrorDetail.GetRetryable() {
+		t.Errorf("ErrorDetail = %+v", resp.ErrorDetail)
+	}
+
+	hist, err := b.History(context.Background(), &pb.ScheduleHistoryRequest{Name: "a"})
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	if hist.Error != "store down" {
+		t.Errorf("History Error = %q, want store down", hist.Error)
+	}
+}
+
+func TestScheduleBridgeListCursor(t *testing.T) {
+	b := &scheduleBridge{handler: &cursorSchedules{names: []string{"a", "b", "c"}}, env: &AppEnv{}}
+
+	var got []string
+	cursor := ""
+	for range 5 {
+		resp, err := b.List(context.Background(), &pb.ListSchedulesRequest{Limit: 2, Cursor: cursor})
+		if err != nil || resp.Error != "" {
+			t.Fatalf("List: %v %s", err, resp.Error)
+		}
+		for _, s := range resp.Schedules {
+			got = append(got, s.Name)
+		}
+		if resp.NextCursor == "" {
+			break
+		}
+		cursor = resp.NextCursor
+	}
+
+	if len(got) != 3 || got[0] != "a" || got[2] != "c" {
+		t.Errorf("paged names = %v, want [a b c]", got)
+	}
+}
+
+func TestScheduleBridgeReportExecutionOptional(t *testing.T) {
+	b := &scheduleBridge{handler: &stubSchedules{}, env: &AppEnv{}}
+
+	resp, err := b.ReportExecution(context.Background(), &pb.ExecutionReport{TriggerId: "t1", State: "started"})
+	if err != nil || resp.ErrorDetail.GetCode() != "unimplemented" {
+		t.Errorf("ReportExecution without extension = %+v, %v; want unimplemented", resp, err)
+	}
+}
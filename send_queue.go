@@ -0,0 +1,305 @@
+package nebo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// defaultSendRetries is how often SendQueue retries a rate-limited send.
+const defaultSendRetries = 3
+
+// defaultRateLimitPause is how long a conversation is paused after a
+// rate-limited send whose Error has no RetryAfter.
+const defaultRateLimitPause = time.Second
+
+// SendQueueOption configures a SendQueue.
+type SendQueueOption func(*SendQueue)
+
+// WithGlobalRate allows n sends per period across all conversations, in
+// bursts of up to n. Unlimited by default.
+func WithGlobalRate(n int, per time.Duration) SendQueueOption {
+	return func(q *SendQueue) { q.global = newRateSpec(n, per) }
+}
+
+// WithChannelRate allows n sends per period to each channel ID, in bursts of
+// up to n. Unlimited by default.
+func WithChannelRate(n int, per time.Duration) SendQueueOption {
+	return func(q *SendQueue) { q.perChannel = newRateSpec(n, per) }
+}
+
+// WithSendRetries sets how many times a send that fails with CodeRateLimited
+// is retried. Defaults to 3.
+func WithSendRetries(n int) SendQueueOption {
+	return func(q *SendQueue) { q.retries = n }
+}
+
+// SendQueue paces outbound sends to stay within a platform's limits. Sends to
+// the same conversation run one at a time in the order they were queued;
+// different conversations proceed in parallel, sharing the global rate. A send
+// that fails with CodeRateLimited pauses its conversation for the Error's
+// RetryAfter and is tried again.
+//
+//	q := nebo.NewSendQueue(nebo.WithGlobalRate(30, time.Second), nebo.WithChannelRate(1, time.Second))
+//	app.RegisterChannel(t, nebo.WithSendQueue(q))
+type SendQueue struct {
+	global     rateSpec
+	perChannel rateSpec
+	retries    int
+
+	mu          sync.Mutex
+	globalB     *tokenBucket
+	buckets     map[string]*tokenBucket
+	tails       map[string]chan struct{} // done channel of the last queued send per conversation
+	depth       map[string]int
+	total       int
+	sent        uint64
+	retried     uint64
+	rateLimited uint64
+}
+
+// NewSendQueue creates a SendQueue. Without options it only orders sends.
+func NewSendQueue(opts ...SendQueueOption) *SendQueue {
+	q := &SendQueue{
+		retries: defaultSendRetries,
+		buckets: make(map[string]*tokenBucket),
+		tails:   make(map[string]chan struct{}),
+		depth:   make(map[string]int),
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+	q.globalB = q.global.bucket(time.Now())
+	return q
+}
+
+// WithSendQueue passes every Send through q. Streamed replies are not queued;
+// EditStreamer paces those itself.
+func WithSendQueue(q *SendQueue) ChannelRegisterOption {
+	return func(b *channelBridge) { b.sendQueue = q }
+}
+
+// SendQueueStats is a snapshot of a SendQueue.
+type SendQueueStats struct {
+	Depth       int            // sends queued or in flight
+	Channels    map[string]int // Depth by conversation
+	Sent        uint64         // sends that completed, successfully or not
+	Retried     uint64         // retries after CodeRateLimited
+	RateLimited uint64         // sends that failed with CodeRateLimited after all retries
+}
+
+// Stats returns the current queue depth and counters.
+func (q *SendQueue) Stats() SendQueueStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	s := SendQueueStats{
+		Depth:       q.total,
+		Channels:    make(map[string]int, len(q.depth)),
+		Sent:        q.sent,
+		Retried:     q.retried,
+		RateLimited: q.rateLimited,
+	}
+	for k, n := range q.depth {
+		s.Channels[k] = n
+	}
+	return s
+}
+
+// Do runs send once every earlier send to channelID has finished and the rate
+// limits allow, retrying it while it fails with CodeRateLimited. It returns
+// send's result, or ctx's error if ctx is done first.
+func (q *SendQueue) Do(ctx context.Context, channelID string, send func(ctx context.Context) (string, error)) (string, error) {
+	ids, err := q.run(ctx, channelID, []func(context.Context) (string, error){send})
+	if len(ids) == 0 {
+		return "", err
+	}
+	return ids[0], err
+}
+
+// run is Do for several sends that must go out back to back, such as the
+// parts of a split message. Each is paced and retried on its own, and no
+// other send to channelID runs between them. It returns the IDs of the sends
+// that succeeded, stopping at the first error.
+func (q *SendQueue) run(ctx context.Context, channelID string, sends []func(context.Context) (string, error)) ([]string, error) {
+	q.mu.Lock()
+	prev := q.tails[channelID]
+	done := make(chan struct{})
+	q.tails[channelID] = done
+	q.depth[channelID]++
+	q.total++
+	q.mu.Unlock()
+
+	finish := func() {
+		q.mu.Lock()
+		q.total--
+		if q.depth[channelID]--; q.depth[channelID] == 0 {
+			delete(q.depth, channelID)
+		}
+		if q.tails[channelID] == done {
+			delete(q.tails, channelID)
+			if b := q.buckets[channelID]; b != nil && b.full(time.Now()) {
+				delete(q.buckets, channelID)
+			}
+		}
+		q.mu.Unlock()
+	}
+
+	if prev != nil {
+		select {
+		case <-prev:
+		case <-ctx.Done():
+			// Keep later sends behind the ones still ahead of us.
+			go func() {
+				<-prev
+				finish()
+				close(done)
+			}()
+			return nil, ctx.Err()
+		}
+	}
+	defer close(done)
+	defer finish()
+
+	var ids []string
+	for _, send := range sends {
+		id, err := q.attempt(ctx, channelID, send)
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// attempt paces and retries one send.
+func (q *SendQueue) attempt(ctx context.Context, channelID string, send func(context.Context) (string, error)) (string, error) {
+	for attempt := 0; ; attempt++ {
+		if err := sleepCtx(ctx, q.reserve(channelID)); err != nil {
+			return "", err
+		}
+		id, err := send(ctx)
+		var e *Error
+		if err == nil || !errors.As(err, &e) || e.Code != CodeRateLimited {
+			q.count(&q.sent)
+			return id, err
+		}
+		if attempt >= q.retries {
+			q.count(&q.sent)
+			q.count(&q.rateLimited)
+			return id, err
+		}
+		q.count(&q.retried)
+		q.pause(channelID, e.RetryAfter)
+	}
+}
+
+func (q *SendQueue) count(n *uint64) {
+	q.mu.Lock()
+	*n++
+	q.mu.Unlock()
+}
+
+// reserve takes a token from the global and channel buckets and returns how
+// long to wait before using it.
+func (q *SendQueue) reserve(channelID string) time.Duration {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := time.Now()
+	b := q.buckets[channelID]
+	if b == nil {
+		b = q.perChannel.bucket(now)
+		q.buckets[channelID] = b
+	}
+	return max(q.globalB.reserve(now), b.reserve(now))
+}
+
+// pause holds back channelID's sends for d after the platform rate-limited it.
+func (q *SendQueue) pause(channelID string, d time.Duration) {
+	if d <= 0 {
+		d = defaultRateLimitPause
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if b := q.buckets[channelID]; b != nil {
+		b.until = time.Now().Add(d)
+	}
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// rateSpec is n tokens per period; zero means unlimited.
+type rateSpec struct {
+	n   int
+	per time.Duration
+}
+
+func newRateSpec(n int, per time.Duration) rateSpec {
+	if n <= 0 || per <= 0 {
+		return rateSpec{}
+	}
+	return rateSpec{n: n, per: per}
+}
+
+func (r rateSpec) bucket(now time.Time) *tokenBucket {
+	b := &tokenBucket{last: now}
+	if r.n > 0 {
+		b.burst = float64(r.n)
+		b.tokens = b.burst
+		b.perToken = r.per / time.Duration(r.n)
+	}
+	return b
+}
+
+// tokenBucket hands out reservations: tokens may go negative, and the caller
+// waits until its token would have been refilled.
+type tokenBucket struct {
+	burst    float64 // 0 means unlimited
+	perToken time.Duration
+	tokens   float64
+	last     time.Time
+	until    time.Time // no tokens before this, after a rate-limit response
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if now.After(b.last) {
+		b.tokens = min(b.burst, b.tokens+float64(now.Sub(b.last))/float64(b.perToken))
+		b.last = now
+	}
+}
+
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	wait := b.until.Sub(now)
+	if b.burst == 0 {
+		return wait
+	}
+	b.refill(now)
+	b.tokens--
+	if b.tokens < 0 {
+		wait = max(wait, time.Duration(-b.tokens*float64(b.perToken)))
+	}
+	return wait
+}
+
+func (b *tokenBucket) full(now time.Time) bool {
+	if now.Before(b.until) {
+		return false
+	}
+	if b.burst == 0 {
+		return true
+	}
+	b.refill(now)
+	return b.tokens >= b.burst
+}
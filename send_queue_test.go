@@ -0,0 +1,180 @@
+package nebo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+)
+
+// waitDepth waits until q holds n sends.
+func waitDepth(t *testing.T, q *SendQueue, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for q.Stats().Depth != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("depth = %d, want %d", q.Stats().Depth, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSendQueueOrdersPerConversation(t *testing.T) {
+	q := NewSendQueue()
+	ctx := context.Background()
+	release := make(chan struct{})
+
+	var mu sync.Mutex
+	var order []string
+	record := func(s string) func(context.Context) (string, error) {
+		return func(context.Context) (string, error) {
+			mu.Lock()
+			order = append(order, s)
+			mu.Unlock()
+			if s == "a1" {
+				<-release
+			}
+			return s, nil
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i, s := range []string{"a1", "a2", "a3"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.Do(ctx, "a", record(s))
+		}()
+		waitDepth(t, q, i+1)
+	}
+	if stats := q.Stats(); stats.Channels["a"] != 3 {
+		t.Errorf("depth of a = %d, want 3", stats.Channels["a"])
+	}
+
+	// Another conversation is not held up by a.
+	if id, _ := q.Do(ctx, "b", record("b1")); id != "b1" {
+		t.Errorf("b1 = %q", id)
+	}
+	close(release)
+	wg.Wait()
+
+	want := []string{"a1", "b1", "a2", "a3"}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order = %v, want %v", order, want)
+		}
+	}
+	if stats := q.Stats(); stats.Depth != 0 || stats.Sent != 4 || len(stats.Channels) != 0 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestSendQueueRateLimits(t *testing.T) {
+	ok := func(context.Context) (string, error) { return "", nil }
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		opts     []SendQueueOption
+		channels []string
+		min, max time.Duration
+	}{
+		{"per channel", []SendQueueOption{WithChannelRate(1, 30*time.Millisecond)}, []string{"a", "a", "a"}, 60 * time.Millisecond, time.Second},
+		{"other channels unaffected", []SendQueueOption{WithChannelRate(1, time.Second)}, []string{"a", "b", "c"}, 0, 500 * time.Millisecond},
+		{"global", []SendQueueOption{WithGlobalRate(1, 30*time.Millisecond)}, []string{"a", "b", "c"}, 60 * time.Millisecond, time.Second},
+		{"burst", []SendQueueOption{WithGlobalRate(3, time.Second)}, []string{"a", "b", "c"}, 0, 500 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := NewSendQueue(tt.opts...)
+			start := time.Now()
+			for _, ch := range tt.channels {
+				q.Do(ctx, ch, ok)
+			}
+			if d := time.Since(start); d < tt.min || d > tt.max {
+				t.Errorf("took %v, want between %v and %v", d, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestSendQueueRetriesRateLimited(t *testing.T) {
+	limited := NewError(CodeRateLimited, "slow down").WithRetryAfter(20 * time.Millisecond)
+	ctx := context.Background()
+
+	calls := 0
+	q := NewSendQueue()
+	start := time.Now()
+	id, err := q.Do(ctx, "a", func(context.Context) (string, error) {
+		if calls++; calls < 3 {
+			return "", limited
+		}
+		return "m1", nil
+	})
+	if err != nil || id != "m1" {
+		t.Fatalf("Do = %q, %v", id, err)
+	}
+	if d := time.Since(start); d < 40*time.Millisecond {
+		t.Errorf("retried after %v, want RetryAfter respected", d)
+	}
+	if stats := q.Stats(); stats.Retried != 2 || stats.RateLimited != 0 {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	q = NewSendQueue(WithSendRetries(1))
+	_, err = q.Do(ctx, "a", func(context.Context) (string, error) { return "", limited })
+	if !errors.Is(err, &Error{Code: CodeRateLimited}) {
+		t.Errorf("err = %v, want rate_limited after retries", err)
+	}
+	if stats := q.Stats(); stats.Retried != 1 || stats.RateLimited != 1 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestSendQueueCancelKeepsOrder(t *testing.T) {
+	q := NewSendQueue()
+	release := make(chan struct{})
+	first := make(chan struct{})
+	go q.Do(context.Background(), "a", func(context.Context) (string, error) {
+		close(first)
+		<-release
+		return "", nil
+	})
+	<-first
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := q.Do(ctx, "a", func(context.Context) (string, error) { return "", nil }); err != context.Canceled {
+		t.Errorf("cancelled Do err = %v", err)
+	}
+
+	ran := make(chan struct{})
+	go q.Do(context.Background(), "a", func(context.Context) (string, error) {
+		close(ran)
+		return "", nil
+	})
+	select {
+	case <-ran:
+		t.Fatal("send ran before the one ahead of it finished")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	<-ran
+}
+
+func TestChannelBridgeSendQueue(t *testing.T) {
+	h := &workspaceChannel{}
+	q := NewSendQueue(WithChannelRate(1, time.Second))
+	b := &channelBridge{handler: h, env: &AppEnv{}, sendQueue: q}
+
+	resp, _ := b.Send(context.Background(), &pb.ChannelSendRequest{ChannelId: "c1", Text: "hi"})
+	if resp.MessageId != "m1" || len(h.sent) != 1 {
+		t.Errorf("Send = %+v, sent = %v", resp, h.sent)
+	}
+	if stats := q.Stats(); stats.Sent != 1 {
+		t.Errorf("queue sent = %d, want 1", stats.Sent)
+	}
+}
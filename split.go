@@ -0,0 +1,182 @@
+package nebo
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/neboloop/nebo-sdk-go/markdown"
+)
+
+// ChannelSplitter is an optional extension for channels whose platform caps
+// message length. The bridge splits longer outbound text with SplitEnvelope
+// and sends the parts in order, returning every part's ID. Text is rendered and
+// split in the ChannelFormatter dialect if the channel has one; otherwise it is
+// sent as written and split with markdown.SplitText.
+type ChannelSplitter interface {
+	ChannelHandler
+	// MaxTextLength is the longest Text, in characters, one message can hold.
+	MaxTextLength() int
+}
+
+// SplitEnvelope renders env's Markdown text in dialect d and splits it into
+// envelopes whose Text is at most limit characters, breaking between blocks,
+// list items, lines and words rather than inside code blocks or formatting.
+// Every part keeps env's ChannelID, Sender, ReplyTo and PlatformData so it
+// lands in the same thread; attachments go with the first part and actions
+// with the last. Parts after the first get MessageID "<id>:<n>".
+func SplitEnvelope(env ChannelEnvelope, d markdown.Dialect, limit int) []ChannelEnvelope {
+	return splitParts(env, markdown.Split(markdown.Parse(env.Text), d, limit))
+}
+
+// splitParts spreads env over one envelope per text, as SplitEnvelope describes.
+func splitParts(env ChannelEnvelope, texts []string) []ChannelEnvelope {
+	if len(texts) == 1 {
+		env.Text = texts[0]
+		return []ChannelEnvelope{env}
+	}
+	parts := make([]ChannelEnvelope, len(texts))
+	for i, text := range texts {
+		part := env
+		part.Text = text
+		if i > 0 {
+			part.Attachments = nil
+			if env.MessageID != "" {
+				part.MessageID = fmt.Sprintf("%s:%d", env.MessageID, i+1)
+			}
+		}
+		if i < len(texts)-1 {
+			part.Actions = nil
+		}
+		parts[i] = part
+	}
+	return parts
+}
+
+// prepare formats env's Markdown for h and splits it if h caps its length.
+// Without a ChannelFormatter the text is never rendered, only split if it is
+// too long.
+func prepare(h ChannelHandler, env ChannelEnvelope) []ChannelEnvelope {
+	s, ok := h.(ChannelSplitter)
+	if !ok || s.MaxTextLength() <= 0 {
+		return []ChannelEnvelope{formatText(h, env)}
+	}
+	if f, ok := h.(ChannelFormatter); ok {
+		return SplitEnvelope(env, f.TextFormat(), s.MaxTextLength())
+	}
+	return splitParts(env, markdown.SplitText(env.Text, s.MaxTextLength()))
+}
+
+// send prepares env for the connection's handler and sends its parts in order,
+// through the bridge's send queue if it has one. Conversations on different
+// connections are queued separately. It returns the IDs of the parts sent.
+func (b *channelBridge) send(ctx context.Context, c *channelConn, env ChannelEnvelope) ([]string, error) {
+	parts := prepare(c.handler, env)
+	sends := make([]func(context.Context) (string, error), len(parts))
+	for i, part := range parts {
+		sends[i] = func(ctx context.Context) (string, error) { return c.handler.Send(ctx, part) }
+	}
+	if b.sendQueue != nil {
+		key := env.ChannelID
+		if c.id != "" {
+			key = c.id + "/" + key
+		}
+		return b.sendQueue.run(ctx, key, sends)
+	}
+	var ids []string
+	for _, send := range sends {
+		id, err := send(ctx)
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// WithInboundReassembly joins inbound messages that the platform split into
+// parts; see Reassemble.
+func WithInboundReassembly(window time.Duration, minPartLength int) ChannelRegisterOption {
+	return func(b *channelBridge) {
+		b.reassembleWindow = window
+		b.reassembleMinPart = minPartLength
+	}
+}
+
+// Reassemble joins consecutive messages from the same sender in the same
+// conversation that arrive within window of each other, as platforms do when
+// a user sends text longer than the message limit. Only a message at least
+// minPartLength characters long is held back in case a continuation follows;
+// shorter ones, and other event kinds, pass straight through. Joined parts are
+// separated by a newline and keep the first part's IDs and timestamp. The
+// returned channel closes after in does.
+func Reassemble(in <-chan ChannelEnvelope, window time.Duration, minPartLength int) <-chan ChannelEnvelope {
+	out := make(chan ChannelEnvelope)
+	go func() {
+		defer close(out)
+		// pending holds messages waiting for a continuation, by sender.
+		type partial struct {
+			env      ChannelEnvelope
+			deadline time.Time
+		}
+		var (
+			keys    []string // pending keys in arrival order
+			pending = map[string]*partial{}
+		)
+		flush := func(match func(key string, p *partial) bool) {
+			kept := keys[:0]
+			for _, k := range keys {
+				if p := pending[k]; match(k, p) {
+					out <- p.env
+					delete(pending, k)
+				} else {
+					kept = append(kept, k)
+				}
+			}
+			keys = kept
+		}
+
+		for {
+			var timer <-chan time.Time
+			if len(keys) > 0 {
+				next := pending[keys[0]].deadline
+				for _, k := range keys[1:] {
+					if d := pending[k].deadline; d.Before(next) {
+						next = d
+					}
+				}
+				timer = time.After(time.Until(next))
+			}
+
+			select {
+			case env, ok := <-in:
+				if !ok {
+					flush(func(string, *partial) bool { return true })
+					return
+				}
+				if env.Kind != "" && env.Kind != EventMessage {
+					flush(func(_ string, p *partial) bool { return p.env.ChannelID == env.ChannelID })
+					out <- env
+					continue
+				}
+				key := env.ChannelID + "\x00" + env.UserID + "\x00" + env.Sender.Name + "\x00" + env.Sender.BotID
+				if p := pending[key]; p != nil {
+					p.env.Text += "\n" + env.Text
+					p.env.Attachments = append(p.env.Attachments, env.Attachments...)
+				} else {
+					pending[key] = &partial{env: env}
+					keys = append(keys, key)
+				}
+				pending[key].deadline = time.Now().Add(window)
+				if utf8.RuneCountInString(env.Text) < minPartLength {
+					flush(func(k string, _ *partial) bool { return k == key })
+				}
+			case <-timer:
+				now := time.Now()
+				flush(func(_ string, p *partial) bool { return !p.deadline.After(now) })
+			}
+		}
+	}()
+	return out
+}
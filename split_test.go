@@ -0,0 +1,148 @@
+package nebo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neboloop/nebo-sdk-go/markdown"
+	pb "github.com/neboloop/nebo-sdk-go/pb"
+)
+
+func TestSplitEnvelope(t *testing.T) {
+	env := ChannelEnvelope{
+		MessageID:   "m",
+		ChannelID:   "c",
+		ReplyTo:     "r",
+		Text:        "first paragraph here\n\n**second** paragraph\n\nthird one",
+		Attachments: []Attachment{{Filename: "a.png"}},
+		Actions:     []MessageAction{NewButton("OK", "ok")},
+	}
+	parts := SplitEnvelope(env, markdown.Telegram, 25)
+	if len(parts) != 3 {
+		t.Fatalf("parts = %d, want 3: %+v", len(parts), parts)
+	}
+	for i, want := range []struct{ id, text string }{{"m", "first paragraph here"}, {"m:2", "*second* paragraph"}, {"m:3", "third one"}} {
+		p := parts[i]
+		if p.MessageID != want.id || p.Text != want.text || p.ReplyTo != "r" || p.ChannelID != "c" {
+			t.Errorf("part %d = %s %q reply %q", i, p.MessageID, p.Text, p.ReplyTo)
+		}
+		if (len(p.Attachments) == 1) != (i == 0) || (len(p.Actions) == 1) != (i == 2) {
+			t.Errorf("part %d has %d attachments and %d actions", i, len(p.Attachments), len(p.Actions))
+		}
+	}
+
+	if parts := SplitEnvelope(env, markdown.Markdown, 1000); len(parts) != 1 || parts[0].Text != env.Text || len(parts[0].Actions) != 1 {
+		t.Errorf("short envelope = %+v", parts)
+	}
+}
+
+// limitedChannel accepts at most limit characters per message and fails the
+// send numbered failAt.
+type limitedChannel struct {
+	chanChannel
+	limit  int
+	failAt int
+	sent   []string
+}
+
+func (c *limitedChannel) MaxTextLength() int { return c.limit }
+func (c *limitedChannel) Send(_ context.Context, env ChannelEnvelope) (string, error) {
+	if len(c.sent)+1 == c.failAt {
+		return "", errors.New("boom")
+	}
+	c.sent = append(c.sent, env.Text)
+	return "id" + string(rune('0'+len(c.sent))), nil
+}
+
+func TestChannelBridgeSplitsLongSends(t *testing.T) {
+	h := &limitedChannel{limit: 20}
+	b := &channelBridge{handler: h, env: &AppEnv{}}
+	ctx := context.Background()
+
+	resp, _ := b.Send(ctx, &pb.ChannelSendRequest{ChannelId: "c", Text: "**bold** opener\n\nsecond paragraph\n\nthird"})
+	if resp.Error != "" || resp.MessageId != "id1" || strings.Join(resp.MessageIds, ",") != "id1,id2,id3" {
+		t.Errorf("resp = %+v", resp)
+	}
+	// Without a ChannelFormatter, parts stay Markdown.
+	if h.sent[0] != "**bold** opener" {
+		t.Errorf("first part = %q", h.sent[0])
+	}
+
+	// Nor is plain text escaped, whether or not it needs splitting.
+	for text, want := range map[string][]string{
+		"if 5 < 6 then [x] * y_z":              {"if 5 < 6 then [x] * y_z"},
+		"if 5 < 6 then [x] * y_z and so\n\non": {"if 5 < 6 then [x] * y_z", "and so\n\non"},
+	} {
+		h = &limitedChannel{limit: 25}
+		b = &channelBridge{handler: h, env: &AppEnv{}}
+		b.Send(ctx, &pb.ChannelSendRequest{ChannelId: "c", Text: text})
+		if strings.Join(h.sent, "|") != strings.Join(want, "|") {
+			t.Errorf("sent %q for %q, want %q", h.sent, text, want)
+		}
+	}
+
+	h = &limitedChannel{limit: 20, failAt: 2}
+	b = &channelBridge{handler: h, env: &AppEnv{}, sendQueue: NewSendQueue()}
+	resp, _ = b.Send(ctx, &pb.ChannelSendRequest{ChannelId: "c", Text: "one paragraph here\n\ntwo paragraph here"})
+	if resp.Error != "boom" || strings.Join(resp.MessageIds, ",") != "id1" {
+		t.Errorf("failed part resp = %+v", resp)
+	}
+}
+
+func TestReassemble(t *testing.T) {
+	in := make(chan ChannelEnvelope)
+	out := Reassemble(in, 50*time.Millisecond, 10)
+	next := func() ChannelEnvelope {
+		t.Helper()
+		select {
+		case env := <-out:
+			return env
+		case <-time.After(time.Second):
+			t.Fatal("no envelope")
+			return ChannelEnvelope{}
+		}
+	}
+
+	alice := MessageSender{Name: "alice"}
+	in <- ChannelEnvelope{MessageID: "1", ChannelID: "c", Sender: alice, Text: "long part one"}
+	in <- ChannelEnvelope{MessageID: "2", ChannelID: "c", Sender: alice, Text: "long part two"}
+	in <- ChannelEnvelope{MessageID: "3", ChannelID: "c", Sender: alice, Text: "end"}
+	if env := next(); env.MessageID != "1" || env.Text != "long part one\nlong part two\nend" {
+		t.Errorf("joined = %s %q", env.MessageID, env.Text)
+	}
+
+	// Short messages pass straight through.
+	in <- ChannelEnvelope{ChannelID: "c", Sender: alice, Text: "hi"}
+	if env := next(); env.Text != "hi" {
+		t.Errorf("short = %q", env.Text)
+	}
+
+	// A long message with no continuation is released after the window.
+	start := time.Now()
+	in <- ChannelEnvelope{ChannelID: "c", Sender: alice, Text: "long but alone"}
+	if env := next(); env.Text != "long but alone" || time.Since(start) < 50*time.Millisecond {
+		t.Errorf("alone = %q after %v", env.Text, time.Since(start))
+	}
+
+	// Other senders and other event kinds are not merged in.
+	in <- ChannelEnvelope{ChannelID: "c", Sender: alice, Text: "alice's long text"}
+	in <- ChannelEnvelope{ChannelID: "c", Sender: MessageSender{Name: "bob"}, Text: "ok"}
+	if env := next(); env.Text != "ok" {
+		t.Errorf("bob = %q", env.Text)
+	}
+	in <- ChannelEnvelope{Kind: EventReaction, ChannelID: "c", Reaction: "👍"}
+	if env := next(); env.Text != "alice's long text" {
+		t.Errorf("flushed before reaction = %q", env.Text)
+	}
+	if env := next(); env.Kind != EventReaction {
+		t.Errorf("kind = %q, want reaction", env.Kind)
+	}
+
+	close(in)
+	if _, ok := <-out; ok {
+		t.Error("out not closed")
+	}
+}
@@ -53,7 +53,7 @@ func (b *toolBridge) Schema(_ context.Context, _ *pb.Empty) (*pb.SchemaResponse,
 func (b *toolBridge) Execute(ctx context.Context, req *pb.ExecuteRequest) (*pb.ExecuteResponse, error) {
 	content, err := b.handler.Execute(ctx, req.Input)
 	if err != nil {
-		return &pb.ExecuteResponse{Content: err.Error(), IsError: true}, nil
+		return &pb.ExecuteResponse{Content: err.Error(), IsError: true, ErrorDetail: toProtoError(err)}, nil
 	}
 	return &pb.ExecuteResponse{Content: content}, nil
 }
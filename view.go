@@ -0,0 +1,84 @@
+package nebo
+
+import "strconv"
+
+// View is a structured UI view built with NewView. Apps serve it as JSON from
+// their HTTP handlers for Nebo to render.
+type View struct {
+	ViewID string      `json:"view_id"`
+	Title  string      `json:"title"`
+	Blocks []ViewBlock `json:"blocks"`
+}
+
+// ViewBlock is one element of a View.
+type ViewBlock struct {
+	BlockID     string         `json:"block_id"`
+	Type        string         `json:"type"` // "heading", "text", "button", "input", "select", "toggle", "divider", "image"
+	Text        string         `json:"text,omitempty"`
+	Value       string         `json:"value,omitempty"`
+	Placeholder string         `json:"placeholder,omitempty"`
+	Variant     string         `json:"variant,omitempty"`
+	Options     []SelectOption `json:"options,omitempty"`
+	Src         string         `json:"src,omitempty"`
+	Alt         string         `json:"alt,omitempty"`
+}
+
+// ViewBuilder builds a View block by block.
+type ViewBuilder struct {
+	view View
+}
+
+// NewView starts a view with the given ID and title.
+func NewView(viewID, title string) *ViewBuilder {
+	return &ViewBuilder{view: View{ViewID: viewID, Title: title, Blocks: []ViewBlock{}}}
+}
+
+func (b *ViewBuilder) add(block ViewBlock) *ViewBuilder {
+	b.view.Blocks = append(b.view.Blocks, block)
+	return b
+}
+
+// Heading adds a heading; variant is "h1", "h2" or "h3".
+func (b *ViewBuilder) Heading(id, text, variant string) *ViewBuilder {
+	return b.add(ViewBlock{BlockID: id, Type: "heading", Text: text, Variant: variant})
+}
+
+// Text adds a paragraph of text.
+func (b *ViewBuilder) Text(id, text string) *ViewBuilder {
+	return b.add(ViewBlock{BlockID: id, Type: "text", Text: text})
+}
+
+// Button adds a button; variant is "primary", "secondary" or "ghost".
+func (b *ViewBuilder) Button(id, label, variant string) *ViewBuilder {
+	return b.add(ViewBlock{BlockID: id, Type: "button", Text: label, Variant: variant})
+}
+
+// Input adds a text input with an initial value.
+func (b *ViewBuilder) Input(id, value, placeholder string) *ViewBuilder {
+	return b.add(ViewBlock{BlockID: id, Type: "input", Value: value, Placeholder: placeholder})
+}
+
+// Select adds a select menu with value chosen.
+func (b *ViewBuilder) Select(id, value string, options []SelectOption) *ViewBuilder {
+	return b.add(ViewBlock{BlockID: id, Type: "select", Value: value, Options: options})
+}
+
+// Toggle adds an on/off switch; its Value is "true" or "false".
+func (b *ViewBuilder) Toggle(id, label string, on bool) *ViewBuilder {
+	return b.add(ViewBlock{BlockID: id, Type: "toggle", Text: label, Value: strconv.FormatBool(on)})
+}
+
+// Divider adds a horizontal rule.
+func (b *ViewBuilder) Divider(id string) *ViewBuilder {
+	return b.add(ViewBlock{BlockID: id, Type: "divider"})
+}
+
+// Image adds an image.
+func (b *ViewBuilder) Image(id, src, alt string) *ViewBuilder {
+	return b.add(ViewBlock{BlockID: id, Type: "image", Src: src, Alt: alt})
+}
+
+// Build returns the view.
+func (b *ViewBuilder) Build() View {
+	return b.view
+}
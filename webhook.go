@@ -0,0 +1,346 @@
+package nebo
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultWebhookMaxBody is the largest webhook body accepted by default.
+const defaultWebhookMaxBody = 1 << 20
+
+// defaultWebhookDedupe is how long delivered webhook messages are remembered
+// to drop platform retries.
+const defaultWebhookDedupe = 10 * time.Minute
+
+// webhookMaxSkew is how far a signed timestamp may be from now.
+const webhookMaxSkew = 5 * time.Minute
+
+// WebhookVerifier checks the signature of a webhook request. body is the raw
+// request body. Returning an error rejects the request with 401.
+type WebhookVerifier func(r *http.Request, body []byte) error
+
+// WebhookParser converts a verified webhook request into inbound envelopes.
+// It may write its own response, as for Slack's URL verification challenge;
+// otherwise the request is answered with 200 once the envelopes are queued.
+// Returning an Error with CodeInvalidArgument answers 400; other errors 500.
+type WebhookParser func(w http.ResponseWriter, r *http.Request, body []byte) ([]ChannelEnvelope, error)
+
+// WebhookOption configures a WebhookChannel.
+type WebhookOption func(*WebhookChannel)
+
+// WithWebhookVerifier checks every request's signature before parsing it.
+func WithWebhookVerifier(v WebhookVerifier) WebhookOption {
+	return func(w *WebhookChannel) { w.verify = v }
+}
+
+// WithWebhookDedupe sets how long delivered messages are remembered so that
+// platform retries are acknowledged without being delivered again. Messages
+// are recognised by ChannelID and MessageID. Defaults to 10 minutes.
+func WithWebhookDedupe(ttl time.Duration) WebhookOption {
+	return func(w *WebhookChannel) { w.seen = newIdempotencyCache(ttl) }
+}
+
+// WithWebhookDedupeKey also recognises retried requests by key, such as
+// Slack's event_id, before they are parsed. An empty key is not deduped.
+func WithWebhookDedupeKey(key func(r *http.Request, body []byte) string) WebhookOption {
+	return func(w *WebhookChannel) { w.requestKey = key }
+}
+
+// WithWebhookMaxBody sets the largest accepted request body. Defaults to 1 MiB.
+func WithWebhookMaxBody(n int64) WebhookOption {
+	return func(w *WebhookChannel) { w.maxBody = n }
+}
+
+// WebhookChannel implements Connect, Disconnect, Receive and State for
+// channel apps whose platform pushes messages to a webhook, such as Slack
+// events, WhatsApp or Twilio. It serves the webhook on the app's HTTP mux,
+// verifies signatures, drops retried deliveries and passes the parsed
+// envelopes to Receive. Embed a *WebhookChannel and implement ID and Send:
+//
+//	type WhatsApp struct{ *nebo.WebhookChannel }
+//
+//	w := &WhatsApp{}
+//	w.WebhookChannel = nebo.NewWebhookChannel("/webhook", w.parse,
+//		nebo.WithWebhookVerifier(nebo.HMACVerifier(appSecret, "X-Hub-Signature-256")))
+//	w.Mount(app)
+//	app.RegisterChannel(w)
+//
+// Requests that arrive while disconnected are answered with 503 so the
+// platform retries them later.
+type WebhookChannel struct {
+	path       string
+	parse      WebhookParser
+	verify     WebhookVerifier
+	requestKey func(r *http.Request, body []byte) string
+	maxBody    int64
+	seen       *idempotencyCache
+
+	inbound chan ChannelEnvelope
+
+	mu        sync.Mutex
+	connected bool
+	config    map[string]string
+}
+
+// NewWebhookChannel creates a WebhookChannel that serves path with parse.
+func NewWebhookChannel(path string, parse WebhookParser, opts ...WebhookOption) *WebhookChannel {
+	w := &WebhookChannel{
+		path:    path,
+		parse:   parse,
+		maxBody: defaultWebhookMaxBody,
+		seen:    newIdempotencyCache(defaultWebhookDedupe),
+		inbound: make(chan ChannelEnvelope),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Mount registers the webhook endpoint on app's HTTP mux.
+func (w *WebhookChannel) Mount(app *App) {
+	app.Handle(w.path, w)
+}
+
+// Connect starts accepting webhook deliveries. config is kept for Config.
+func (w *WebhookChannel) Connect(_ context.Context, config map[string]string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.connected = true
+	w.config = config
+	return nil
+}
+
+// Disconnect stops accepting webhook deliveries.
+func (w *WebhookChannel) Disconnect(context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.connected = false
+	return nil
+}
+
+// Config returns the config passed to the last Connect.
+func (w *WebhookChannel) Config() map[string]string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.config
+}
+
+// Receive returns envelopes parsed from webhook deliveries until ctx is done.
+func (w *WebhookChannel) Receive(ctx context.Context) (<-chan ChannelEnvelope, error) {
+	out := make(chan ChannelEnvelope)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case env := <-w.inbound:
+				select {
+				case out <- env:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// State reports whether the channel is accepting deliveries.
+func (w *WebhookChannel) State() (ChannelState, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.connected {
+		return ChannelConnected, nil
+	}
+	return ChannelDisconnected, nil
+}
+
+func (w *WebhookChannel) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(rw, r.Body, w.maxBody))
+	if err != nil {
+		http.Error(rw, "request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if w.verify != nil {
+		if err := w.verify(r, body); err != nil {
+			http.Error(rw, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+	if state, _ := w.State(); state != ChannelConnected {
+		http.Error(rw, "channel not connected", http.StatusServiceUnavailable)
+		return
+	}
+
+	// Keys are claimed before delivery, so a retry that arrives while the
+	// first request is still being delivered is recognised, and released
+	// again if delivery fails.
+	var reqKey string
+	if w.requestKey != nil {
+		if k := w.requestKey(r, body); k != "" {
+			if !w.seen.claim("req:" + k) {
+				rw.WriteHeader(http.StatusOK)
+				return
+			}
+			reqKey = "req:" + k
+		}
+	}
+
+	tw := &trackingWriter{ResponseWriter: rw}
+	envs, err := w.parse(tw, r, body)
+	if err != nil {
+		w.seen.release(reqKey)
+		code := http.StatusInternalServerError
+		if e := (*Error)(nil); errors.As(err, &e) && e.Code == CodeInvalidArgument {
+			code = http.StatusBadRequest
+		}
+		if !tw.written {
+			http.Error(rw, err.Error(), code)
+		}
+		return
+	}
+
+	for _, env := range envs {
+		key := ""
+		if env.MessageID != "" {
+			key = "msg:" + env.ChannelID + "/" + string(env.Kind) + "/" + env.MessageID
+			if !w.seen.claim(key) {
+				continue
+			}
+		}
+		select {
+		case w.inbound <- env:
+		case <-r.Context().Done():
+			w.seen.release(key)
+			w.seen.release(reqKey)
+			if !tw.written {
+				http.Error(rw, "not delivered", http.StatusServiceUnavailable)
+			}
+			return
+		}
+	}
+	if !tw.written {
+		rw.WriteHeader(http.StatusOK)
+	}
+}
+
+// trackingWriter records whether a WebhookParser wrote a response.
+type trackingWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (t *trackingWriter) WriteHeader(code int) {
+	t.written = true
+	t.ResponseWriter.WriteHeader(code)
+}
+
+func (t *trackingWriter) Write(p []byte) (int, error) {
+	t.written = true
+	return t.ResponseWriter.Write(p)
+}
+
+// errBadSignature is returned by verifiers when the signature does not match.
+var errBadSignature = errors.New("invalid webhook signature")
+
+// HMACVerifier verifies a hex HMAC-SHA256 of the body in header, with or
+// without a "sha256=" prefix, as sent by WhatsApp, Meta and many others.
+func HMACVerifier(secret, header string) WebhookVerifier {
+	return func(r *http.Request, body []byte) error {
+		sig := strings.TrimPrefix(r.Header.Get(header), "sha256=")
+		if !validHMAC(sha256HMAC(secret, body), sig) {
+			return errBadSignature
+		}
+		return nil
+	}
+}
+
+// SlackVerifier verifies Slack's v0 request signature and rejects requests
+// whose X-Slack-Request-Timestamp is more than five minutes off, which stops
+// replays.
+func SlackVerifier(signingSecret string) WebhookVerifier {
+	return func(r *http.Request, body []byte) error {
+		ts := r.Header.Get("X-Slack-Request-Timestamp")
+		if err := checkTimestamp(ts); err != nil {
+			return err
+		}
+		base := append([]byte("v0:"+ts+":"), body...)
+		sig := strings.TrimPrefix(r.Header.Get("X-Slack-Signature"), "v0=")
+		if !validHMAC(sha256HMAC(signingSecret, base), sig) {
+			return errBadSignature
+		}
+		return nil
+	}
+}
+
+// TwilioVerifier verifies X-Twilio-Signature for a webhook whose public URL,
+// as configured in Twilio, is webhookURL.
+func TwilioVerifier(authToken, webhookURL string) WebhookVerifier {
+	return func(r *http.Request, body []byte) error {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return err
+		}
+		keys := make([]string, 0, len(form))
+		for k := range form {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		data := webhookURL
+		for _, k := range keys {
+			for _, v := range form[k] {
+				data += k + v
+			}
+		}
+		mac := hmac.New(sha1.New, []byte(authToken))
+		mac.Write([]byte(data))
+		want := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+		if !hmac.Equal([]byte(want), []byte(r.Header.Get("X-Twilio-Signature"))) {
+			return errBadSignature
+		}
+		return nil
+	}
+}
+
+func sha256HMAC(secret string, data []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+func validHMAC(want []byte, sigHex string) bool {
+	got, err := hex.DecodeString(sigHex)
+	return err == nil && hmac.Equal(want, got)
+}
+
+// checkTimestamp rejects a Unix timestamp more than webhookMaxSkew from now.
+func checkTimestamp(ts string) error {
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid webhook timestamp %q", ts)
+	}
+	if d := time.Since(time.Unix(sec, 0)); d > webhookMaxSkew || d < -webhookMaxSkew {
+		return fmt.Errorf("webhook timestamp %s is outside the allowed window", ts)
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package nebo
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type webhookEvent struct {
+	ID   string `json:"id"`
+	Chat string `json:"chat"`
+	Text string `json:"text"`
+}
+
+func parseWebhookEvent(_ http.ResponseWriter, _ *http.Request, body []byte) ([]ChannelEnvelope, error) {
+	var ev webhookEvent
+	if err := json.Unmarshal(body, &ev); err != nil {
+		return nil, Errorf(CodeInvalidArgument, "bad payload: %v", err)
+	}
+	return []ChannelEnvelope{{ChannelID: ev.Chat, MessageID: ev.ID, Text: ev.Text}}, nil
+}
+
+func signHex(secret, data string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(data))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newTestWebhook(t *testing.T, opts ...WebhookOption) (*WebhookChannel, <-chan ChannelEnvelope) {
+	t.Helper()
+	w := NewWebhookChannel("/hook", parseWebhookEvent, opts...)
+	if err := w.Connect(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	ch, err := w.Receive(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w, ch
+}
+
+func postWebhook(w http.Handler, body string, header http.Header) int {
+	r := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(body))
+	for k, v := range header {
+		r.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	w.ServeHTTP(rec, r)
+	return rec.Code
+}
+
+func TestWebhookChannelDeliversAndDedupes(t *testing.T) {
+	w, ch := newTestWebhook(t)
+	body := `{"id":"m1","chat":"c1","text":"hi"}`
+
+	done := make(chan int, 2)
+	go func() { done <- postWebhook(w, body, nil) }()
+	var env ChannelEnvelope
+	select {
+	case env = <-ch:
+	case <-time.After(time.Second):
+		t.Fatal("no envelope delivered")
+	}
+	if env.ChannelID != "c1" || env.MessageID != "m1" || env.Text != "hi" {
+		t.Errorf("envelope = %+v", env)
+	}
+	if code := <-done; code != http.StatusOK {
+		t.Errorf("status = %d, want 200", code)
+	}
+
+	// A platform retry is acknowledged without a second delivery.
+	if code := postWebhook(w, body, nil); code != http.StatusOK {
+		t.Errorf("retry status = %d, want 200", code)
+	}
+	select {
+	case env := <-ch:
+		t.Errorf("retry delivered %+v", env)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestWebhookChannelDedupesRetryDuringDelivery(t *testing.T) {
+	w, ch := newTestWebhook(t)
+	body := `{"id":"m1","chat":"c1","text":"hi"}`
+
+	// Nothing reads ch, so the receiver holds the first message and m1 is
+	// stuck delivering.
+	postWebhook(w, `{"id":"fill1","chat":"c1"}`, nil)
+	done := make(chan int, 1)
+	go func() { done <- postWebhook(w, body, nil) }()
+	retry := make(chan int, 1)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		retry <- postWebhook(w, body, nil)
+	}()
+	select {
+	case code := <-retry:
+		if code != http.StatusOK {
+			t.Errorf("retry status = %d, want 200", code)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("retry blocked behind the first delivery")
+	}
+	<-ch // fill1
+	if env := <-ch; env.MessageID != "m1" {
+		t.Errorf("delivered %+v, want m1", env)
+	}
+	<-done
+	select {
+	case env := <-ch:
+		t.Errorf("retry delivered %+v", env)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// A delivery that fails releases its claim, so the retry gets through.
+	postWebhook(w, `{"id":"fill2","chat":"c1"}`, nil)
+	body = `{"id":"m2","chat":"c1","text":"again"}`
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(body)).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	w.ServeHTTP(rec, r)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("cancelled delivery status = %d, want 503", rec.Code)
+	}
+	<-ch // fill2
+	go postWebhook(w, body, nil)
+	select {
+	case env := <-ch:
+		if env.MessageID != "m2" {
+			t.Errorf("retry delivered %+v, want m2", env)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("retry after a failed delivery was dropped")
+	}
+}
+
+func TestWebhookChannelRejects(t *testing.T) {
+	w, _ := newTestWebhook(t, WithWebhookVerifier(HMACVerifier("secret", "X-Signature")), WithWebhookMaxBody(64))
+	body := `{"id":"m1","chat":"c1","text":"hi"}`
+
+	tests := []struct {
+		name   string
+		body   string
+		header http.Header
+		want   int
+	}{
+		{"missing signature", body, nil, http.StatusUnauthorized},
+		{"wrong signature", body, http.Header{"X-Signature": {"sha256=" + signHex("other", body)}}, http.StatusUnauthorized},
+		{"too large", strings.Repeat("x", 65), nil, http.StatusRequestEntityTooLarge},
+		{"bad payload", "nope", http.Header{"X-Signature": {signHex("secret", "nope")}}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := postWebhook(w, tt.body, tt.header); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/hook", nil)
+	rec := httptest.NewRecorder()
+	w.ServeHTTP(rec, r)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET status = %d, want 405", rec.Code)
+	}
+}
+
+func TestWebhookChannelDisconnected(t *testing.T) {
+	w, _ := newTestWebhook(t)
+	w.Disconnect(context.Background())
+	if code := postWebhook(w, `{"id":"m1"}`, nil); code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", code)
+	}
+	if state, _ := w.State(); state != ChannelDisconnected {
+		t.Errorf("state = %s, want %s", state, ChannelDisconnected)
+	}
+}
+
+func TestWebhookChannelParserResponds(t *testing.T) {
+	parse := func(rw http.ResponseWriter, _ *http.Request, body []byte) ([]ChannelEnvelope, error) {
+		fmt.Fprint(rw, "challenge")
+		return nil, nil
+	}
+	w := NewWebhookChannel("/hook", parse)
+	w.Connect(context.Background(), nil)
+	r := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader("{}"))
+	rec := httptest.NewRecorder()
+	w.ServeHTTP(rec, r)
+	if rec.Body.String() != "challenge" {
+		t.Errorf("body = %q, want %q", rec.Body.String(), "challenge")
+	}
+}
+
+func TestWebhookChannelDedupeKey(t *testing.T) {
+	parsed := 0
+	parse := func(http.ResponseWriter, *http.Request, []byte) ([]ChannelEnvelope, error) {
+		parsed++
+		return nil, nil
+	}
+	key := func(r *http.Request, _ []byte) string { return r.Header.Get("X-Event-Id") }
+	w := NewWebhookChannel("/hook", parse, WithWebhookDedupeKey(key))
+	w.Connect(context.Background(), nil)
+	h := http.Header{"X-Event-Id": {"e1"}}
+	postWebhook(w, "{}", h)
+	postWebhook(w, "{}", h)
+	if parsed != 1 {
+		t.Errorf("parsed %d times, want 1", parsed)
+	}
+}
+
+func TestSlackVerifier(t *testing.T) {
+	body := "token=x&text=hi"
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	old := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	verify := SlackVerifier("shh")
+
+	tests := []struct {
+		name string
+		ts   string
+		sig  string
+		ok   bool
+	}{
+		{"valid", now, "v0=" + signHex("shh", "v0:"+now+":"+body), true},
+		{"wrong secret", now, "v0=" + signHex("other", "v0:"+now+":"+body), false},
+		{"replayed", old, "v0=" + signHex("shh", "v0:"+old+":"+body), false},
+		{"no timestamp", "", "v0=" + signHex("shh", "v0::"+body), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/slack", nil)
+			r.Header.Set("X-Slack-Request-Timestamp", tt.ts)
+			r.Header.Set("X-Slack-Signature", tt.sig)
+			if err := verify(r, []byte(body)); (err == nil) != tt.ok {
+				t.Errorf("verify = %v, want ok %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestTwilioVerifier(t *testing.T) {
+	const url = "https://example.com/sms"
+	body := "To=%2B1555&Body=hi&From=%2B1666"
+	mac := hmac.New(sha1.New, []byte("token"))
+	mac.Write([]byte(url + "Bodyhi" + "From+1666" + "To+1555"))
+	sig := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	r := httptest.NewRequest(http.MethodPost, "/sms", nil)
+	r.Header.Set("X-Twilio-Signature", sig)
+	if err := TwilioVerifier("token", url)(r, []byte(body)); err != nil {
+		t.Errorf("verify = %v, want nil", err)
+	}
+	if err := TwilioVerifier("token", url+"/other")(r, []byte(body)); err == nil {
+		t.Error("verify with wrong URL = nil, want error")
+	}
+}